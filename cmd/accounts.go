@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+)
+
+// runAllAccounts runs aws-nuke for every account of the accounts section of
+// the config or, with --org-discover, for the discovered accounts of the
+// organization. Up to --account-concurrency accounts run at the same time.
+// The account-role is assumed into each of them. A failed account does not
+// stop the remaining ones. The pause applies to all runs.
+func runAllAccounts(params *NukeParameters, creds *awsutil.Credentials, defaultRegion string, pause *PauseControl) error {
+	conf, err := config.Load(params.ConfigPath)
+	if err != nil {
+		log.Errorf("Failed to parse config file %s", params.ConfigPath)
+		return err
+	}
+
+	if conf.AccountRole.RoleARN == "" {
+		return fmt.Errorf("The config does not specify the account-role, which is required for --all-accounts and --org-discover.")
+	}
+
+	if params.AccountConcurrency > 1 && params.Output == "" && conf.Report.Backend == config.ReportBackendStdout {
+		return fmt.Errorf("The report storage of the config cannot write to stdout with --account-concurrency, " +
+			"since the reports of concurrent runs would be mixed up.")
+	}
+
+	ids := conf.AccountIDs()
+	if params.OrgDiscover {
+		discoverCreds := copyCredentials(*creds)
+		discoverCreds.DefaultRegion = defaultRegion
+		ids, err = discoverAccounts(discoverCreds, conf)
+		if err != nil {
+			return err
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("There are no accounts to nuke, which are not blacklisted.")
+	}
+
+	errs := make([]error, len(ids))
+	parallel := int64(params.AccountConcurrency)
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	w := logOutput(params, conf.Report)
+	ctx := context.Background()
+	sem := semaphore.NewWeighted(parallel)
+	for i, id := range ids {
+		sem.Acquire(ctx, 1)
+		go func(i int, id string) {
+			defer sem.Release(1)
+
+			ColorGroupHeader.Fprintf(w, "Account %s (%d of %d)\n\n", id, i+1, len(ids))
+			errs[i] = runAccount(*params, *creds, defaultRegion, id, pause)
+			fmt.Fprintln(w)
+		}(i, id)
+	}
+
+	// Wait for all runs to finish.
+	sem.Acquire(ctx, parallel)
+
+	failed := []string{}
+	for i, id := range ids {
+		if errs[i] != nil {
+			log.Errorf("Failed to nuke the account %s: %v", id, errs[i])
+			failed = append(failed, id)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Failed to nuke %d of %d accounts: %s.", len(failed), len(ids), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// runAccount nukes a single account of --all-accounts. The parameters and
+// credentials are copies and all settings of the run are kept by its Nuke, so
+// concurrent runs do not affect each other.
+func runAccount(params NukeParameters, creds awsutil.Credentials, defaultRegion, accountID string, pause *PauseControl) error {
+	params.AccountID = accountID
+
+	creds = copyCredentials(creds)
+	n, err := buildNuke(&params, &creds, defaultRegion)
+	if err != nil {
+		return err
+	}
+
+	n.pause = pause
+	return n.Run()
+}
+
+// copyCredentials returns a copy of the credentials, whose session tags can be
+// changed without affecting the original.
+func copyCredentials(creds awsutil.Credentials) awsutil.Credentials {
+	tags := creds.AssumeRole.SessionTags
+	creds.AssumeRole.SessionTags = nil
+	for key, value := range tags {
+		if creds.AssumeRole.SessionTags == nil {
+			creds.AssumeRole.SessionTags = map[string]string{}
+		}
+		creds.AssumeRole.SessionTags[key] = value
+	}
+	return creds
+}
+
+// discoverAccounts lists the active accounts of the organization with the
+// assume-role of the config and returns the IDs of those, which match the
+// org-discovery of the config. Accounts without settings in the config are
+// skipped.
+func discoverAccounts(creds awsutil.Credentials, conf *config.Nuke) ([]string, error) {
+	applyConfigCredentials(&creds, conf)
+	creds.Proxy = conf.Proxy
+	creds.HTTPClient = conf.HTTPClient
+
+	err := creds.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := creds.OrganizationAccounts(len(conf.OrgDiscovery.Tags) > 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, account := range accounts {
+		if conf.InBlacklist(account.ID) || !conf.OrgDiscovery.Matches(account.OUPath, account.Tags) {
+			continue
+		}
+
+		if !conf.UseAccountDefaults(account.ID) {
+			log.Warnf("Skipping the discovered account %s (%s), since it is neither listed in the config "+
+				"nor are there account-defaults in the org-discovery.", account.ID, account.Name)
+			continue
+		}
+
+		ids = append(ids, account.ID)
+	}
+	sort.Strings(ids)
+
+	log.Infof("Discovered %d of %d accounts of the organization.", len(ids), len(accounts))
+
+	return ids, nil
+}
+
+// useAccountRole prepares a run of --all-accounts for the account of the
+// parameters. The assume-role of the config or the flags becomes the last hop
+// of the role chain, since the account-role is assumed from it. Files, which
+// are written per run, get the account ID as suffix.
+func useAccountRole(params *NukeParameters, creds *awsutil.Credentials, c *config.Nuke) error {
+	role, err := c.AccountRole.ForAccount(params.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if creds.HasAssumeRole() {
+		creds.RoleChain = append(creds.RoleChain, creds.AssumeRole)
+	}
+	creds.AssumeRole = role
+
+	if params.OrgDiscover {
+		c.UseAccountDefaults(params.AccountID)
+	}
+
+	params.OutputFile = accountPath(params.OutputFile, params.AccountID)
+	params.ReportHTML = accountPath(params.ReportHTML, params.AccountID)
+	params.StateFile = accountPath(params.StateFile, params.AccountID)
+	params.EventsFile = accountPath(params.EventsFile, params.AccountID)
+
+	if c.Report.Backend == config.ReportBackendFile {
+		c.Report.Path = accountPath(c.Report.Path, params.AccountID)
+	}
+
+	return nil
+}
+
+// accountPath inserts the account ID before the extension of the path. An
+// empty path and - for stdout stay unchanged.
+func accountPath(path, accountID string) string {
+	if path == "" || path == "-" {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), accountID, ext)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+func TestAccountPath(t *testing.T) {
+	cases := map[string]string{
+		"":                  "",
+		"-":                 "-",
+		"report.json":       "report-111111111111.json",
+		"out/state":         "out/state-111111111111",
+		"out.d/report.html": "out.d/report-111111111111.html",
+	}
+
+	for path, want := range cases {
+		have := accountPath(path, "111111111111")
+		if have != want {
+			t.Errorf("Wrong path for %q. Want: %s. Have: %s", path, want, have)
+		}
+	}
+}
+
+func TestUseAccountRole(t *testing.T) {
+	params := &NukeParameters{
+		AccountID:  "111111111111",
+		OutputFile: "report.json",
+		EventsFile: "-",
+	}
+	creds := &awsutil.Credentials{
+		AssumeRole: config.AssumeRole{RoleARN: "arn:aws:iam::000000000000:role/hub"},
+	}
+	c := &config.Nuke{
+		AccountRole: config.AssumeRole{
+			RoleARN:    "arn:aws:iam::{account-id}:role/aws-nuke",
+			ExternalID: "nuke",
+		},
+		Report: config.ReportStorage{Backend: config.ReportBackendFile, Path: "nuke.json"},
+	}
+
+	err := useAccountRole(params, creds, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(creds.RoleChain) != 1 || creds.RoleChain[0].RoleARN != "arn:aws:iam::000000000000:role/hub" {
+		t.Errorf("The assume-role is not the last hop of the role chain: %+v", creds.RoleChain)
+	}
+	if creds.AssumeRole.RoleARN != "arn:aws:iam::111111111111:role/aws-nuke" || creds.AssumeRole.ExternalID != "nuke" {
+		t.Errorf("Wrong account role: %+v", creds.AssumeRole)
+	}
+	if params.OutputFile != "report-111111111111.json" || params.EventsFile != "-" {
+		t.Errorf("Wrong output files: %s, %s", params.OutputFile, params.EventsFile)
+	}
+	if c.Report.Path != "nuke-111111111111.json" {
+		t.Errorf("Wrong report path: %s", c.Report.Path)
+	}
+}
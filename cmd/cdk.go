@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+// cdkBootstrapPreset is the built-in preset, which describes the resources of
+// the CDK bootstrap stack.
+const cdkBootstrapPreset = "cdk-bootstrap"
+
+// deferCDKBootstrap returns true for CDK bootstrap resources, as long as other
+// CloudFormation stacks are being removed and the remove-cdk-bootstrap-last
+// feature flag is enabled, since deleting them first breaks the deletion of
+// CDK apps.
+func (n *Nuke) deferCDKBootstrap(item *Item) bool {
+	return n.stacksPending && n.isCDKBootstrap(item)
+}
+
+// otherStacksPending returns true, if any CloudFormation stack outside of the
+// CDK bootstrap is still being removed.
+func (n *Nuke) otherStacksPending() bool {
+	for _, item := range n.items {
+		if item.Type != "CloudFormationStack" {
+			continue
+		}
+
+		switch item.State {
+		case ItemStateNew, ItemStatePending, ItemStateWaiting:
+			if !n.isCDKBootstrap(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (n *Nuke) isCDKBootstrap(item *Item) bool {
+	preset, ok, err := config.BuiltinPreset(cdkBootstrapPreset)
+	if err != nil || !ok {
+		n.output().Logger.Debugf("failed to load the %s preset: %v", cdkBootstrapPreset, err)
+		return false
+	}
+
+	match, err := item.MatchesAny(preset.Filters[item.Type])
+	if err != nil {
+		n.output().Logger.Debugf("failed to check whether %s is part of the CDK bootstrap: %v", item.Type, err)
+		return false
+	}
+
+	return match
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestDeferCDKBootstrapRemoval(t *testing.T) {
+	newItem := func(resourceType, name string, state ItemState) *Item {
+		return &Item{
+			Type:  resourceType,
+			State: state,
+			Resource: &testResource{
+				props: types.NewProperties().Set("Name", name),
+			},
+		}
+	}
+
+	toolkit := newItem("CloudFormationStack", "CDKToolkit", ItemStateNew)
+	role := newItem("IAMRole", "cdk-hnb659fds-deploy-role-012345678901-eu-west-1", ItemStateNew)
+	other := newItem("IAMRole", "my-app-role", ItemStateNew)
+	app := newItem("CloudFormationStack", "my-app", ItemStatePending)
+
+	n := &Nuke{
+		Config: &config.Nuke{},
+		items:  Queue{toolkit, role, other, app},
+	}
+
+	n.prepareDeferrals()
+	for _, item := range n.items {
+		if n.deferRemoval(item) {
+			t.Errorf("Expected no deferred removal without the feature flag.")
+		}
+	}
+
+	n.Config.FeatureFlags.RemoveCDKBootstrapLast = true
+
+	cases := []struct {
+		appState ItemState
+		deferred bool
+	}{
+		{ItemStatePending, true},
+		{ItemStateWaiting, true},
+		{ItemStateFailed, false},
+		{ItemStateFinished, false},
+	}
+
+	for _, tc := range cases {
+		app.State = tc.appState
+		n.prepareDeferrals()
+
+		if n.deferRemoval(toolkit) != tc.deferred || n.deferRemoval(role) != tc.deferred {
+			t.Errorf("Wrong deferral of CDK bootstrap while app stack is %s. Want: %t.", tc.appState, tc.deferred)
+		}
+		if n.deferRemoval(other) || n.deferRemoval(app) {
+			t.Errorf("Expected no deferral of resources outside of the CDK bootstrap.")
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/resources"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCommand(params *NukeParameters) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "inspects the nuke config file",
+	}
+
+	cmd.AddCommand(NewConfigLintCommand(params))
+	cmd.AddCommand(NewConfigResolveCommand(params))
+
+	return cmd
+}
+
+func NewConfigLintCommand(params *NukeParameters) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "checks the config file for mistakes and violations of best practices",
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := params.Validate()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		conf, err := config.Load(params.ConfigPath)
+		if err != nil {
+			return err
+		}
+
+		err = conf.CheckCompatibility(BuildVersion)
+		if err != nil {
+			return err
+		}
+
+		issues := conf.Lint(config.LintOptions{
+			ResourceTypes: resources.GetListerNames(),
+			Properties:    resources.KnownProperties,
+		})
+
+		switch format {
+		case "text":
+			for _, issue := range issues {
+				fmt.Println(issue)
+			}
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(issues)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("Unsupported value '%s' for --format.", format)
+		}
+
+		errors := 0
+		for _, issue := range issues {
+			if issue.Severity == config.LintSeverityError {
+				errors++
+			}
+		}
+		if errors > 0 {
+			cmd.SilenceErrors = true
+			return fmt.Errorf("found %d errors", errors)
+		}
+
+		return nil
+	}
+
+	cmd.Flags().StringVar(
+		&format, "format", "text",
+		"Output format of the found issues (text, json).")
+
+	return cmd
+}
+
+func NewConfigResolveCommand(params *NukeParameters) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "prints the config with resolved anchors, filter files and presets in canonical form",
+		Long: `Prints the config with resolved YAML anchors, filter files and presets in canonical form.
+Map keys are sorted and empty settings are omitted, so the output can be reviewed in merge requests or attached to approval records.`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := params.Validate()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		conf, err := config.Load(params.ConfigPath)
+		if err != nil {
+			return err
+		}
+
+		err = conf.CheckCompatibility(BuildVersion)
+		if err != nil {
+			return err
+		}
+
+		data, err := conf.Canonical()
+		if err != nil {
+			return err
+		}
+
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	return cmd
+}
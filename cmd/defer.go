@@ -0,0 +1,47 @@
+package cmd
+
+// prepareDeferrals determines the state of the items, which deferRemoval
+// depends on. It is called once per pass over the queue, so deferRemoval does
+// not need to check every item against all other items.
+func (n *Nuke) prepareDeferrals() {
+	n.stacksPending = n.Config.FeatureFlags.RemoveCDKBootstrapLast && n.otherStacksPending()
+}
+
+// deferRemoval returns true, if the removal of the item has to wait for other
+// items, which are still being removed. prepareDeferrals has to be called
+// before.
+func (n *Nuke) deferRemoval(item *Item) bool {
+	return n.deferCDKBootstrap(item) || n.deferNATGatewayAddress(item)
+}
+
+// deferNATGatewayAddress returns true for elastic IPs of NAT gateways, which
+// are still being removed. The address can't be released before the NAT
+// gateway is deleted.
+func (n *Nuke) deferNATGatewayAddress(item *Item) bool {
+	if item.Type != "EC2Address" {
+		return false
+	}
+
+	natGatewayID, err := item.GetProperty("NATGatewayID")
+	if err != nil || natGatewayID == "" {
+		return false
+	}
+
+	for _, other := range n.items {
+		if other.Type != "EC2NATGateway" {
+			continue
+		}
+
+		id, err := other.GetProperty("NATGatewayID")
+		if err != nil || id != natGatewayID {
+			continue
+		}
+
+		switch other.State {
+		case ItemStateNew, ItemStatePending, ItemStateWaiting:
+			return true
+		}
+	}
+
+	return false
+}
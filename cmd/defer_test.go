@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestDeferNATGatewayAddressRemoval(t *testing.T) {
+	natgw := &Item{
+		Type:  "EC2NATGateway",
+		State: ItemStatePending,
+		Resource: &testResource{
+			props: types.NewProperties().Set("NATGatewayID", "nat-0123"),
+		},
+	}
+	address := &Item{
+		Type:  "EC2Address",
+		State: ItemStateNew,
+		Resource: &testResource{
+			props: types.NewProperties().Set("NATGatewayID", "nat-0123"),
+		},
+	}
+	unused := &Item{
+		Type:  "EC2Address",
+		State: ItemStateNew,
+		Resource: &testResource{
+			props: types.NewProperties().Set("AllocationID", "eipalloc-0123"),
+		},
+	}
+
+	n := &Nuke{
+		Config: &config.Nuke{},
+		items:  Queue{natgw, address, unused},
+	}
+
+	cases := []struct {
+		natgwState ItemState
+		deferred   bool
+	}{
+		{ItemStateNew, true},
+		{ItemStatePending, true},
+		{ItemStateWaiting, true},
+		{ItemStateFiltered, false},
+		{ItemStateFailed, false},
+		{ItemStateFinished, false},
+	}
+
+	for _, tc := range cases {
+		natgw.State = tc.natgwState
+
+		if n.deferRemoval(address) != tc.deferred {
+			t.Errorf("Wrong deferral of NAT gateway address while NAT gateway is %s. Want: %t.", tc.natgwState, tc.deferred)
+		}
+		if n.deferRemoval(unused) || n.deferRemoval(natgw) {
+			t.Errorf("Expected no deferral of resources without NAT gateway.")
+		}
+	}
+}
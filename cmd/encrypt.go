@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"filippo.io/age/armor"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// Reports are encrypted in the armored age format (https://age-encryption.org),
+// so reports for age and SSH recipients can also be read with the age CLI. The
+// age header is authenticated with the file key, so neither the recipients
+// nor the report can be changed unnoticed.
+
+// kmsStanzaType is the type of the age recipient stanza, which contains the
+// file key encrypted with a KMS key. Its arguments are the ID and the region
+// of the key.
+const kmsStanzaType = "aws-kms"
+
+// kmsRecipient encrypts the file key of a report with a KMS key.
+type kmsRecipient struct {
+	client kmsiface.KMSAPI
+	keyID  string
+	region string
+}
+
+func (r *kmsRecipient) Wrap(fileKey []byte) ([]*age.Stanza, error) {
+	resp, err := r.client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(r.keyID),
+		Plaintext: fileKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to encrypt the report key with the KMS key %s: %v", r.keyID, err)
+	}
+
+	return []*age.Stanza{{
+		Type: kmsStanzaType,
+		Args: []string{r.keyID, r.region},
+		Body: resp.CiphertextBlob,
+	}}, nil
+}
+
+// kmsIdentity decrypts the file key of reports, which were encrypted with a
+// KMS key. The client is only created for the region of a KMS stanza, so
+// reports for other recipients can be decrypted without AWS credentials.
+type kmsIdentity struct {
+	client func(region string) (kmsiface.KMSAPI, error)
+}
+
+func (i *kmsIdentity) Unwrap(stanzas []*age.Stanza) ([]byte, error) {
+	for _, stanza := range stanzas {
+		if stanza.Type != kmsStanzaType {
+			continue
+		}
+
+		if len(stanza.Args) != 2 {
+			return nil, fmt.Errorf("The %s recipient of the encrypted report is invalid.", kmsStanzaType)
+		}
+		keyID, region := stanza.Args[0], stanza.Args[1]
+
+		client, err := i.client(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Decrypt(&kms.DecryptInput{
+			CiphertextBlob: stanza.Body,
+			KeyId:          aws.String(keyID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decrypt the report key with the KMS key %s: %v", keyID, err)
+		}
+		return resp.Plaintext, nil
+	}
+
+	return nil, age.ErrIncorrectIdentity
+}
+
+// kmsKeyRegion returns the region of a KMS key ARN. Key IDs and aliases do not
+// contain a region, so the default region is used for them.
+func kmsKeyRegion(keyID, defaultRegion string) string {
+	parsed, err := arn.Parse(keyID)
+	if err != nil || parsed.Region == "" {
+		return defaultRegion
+	}
+	return parsed.Region
+}
+
+func newKMSClient(creds *awsutil.Credentials, region string) (kmsiface.KMSAPI, error) {
+	sess, err := creds.NewSession(region, "kms")
+	if err != nil {
+		return nil, err
+	}
+	return kms.New(sess), nil
+}
+
+// loadRecipients reads a file with one recipient per line, either an age
+// public key (age1...) or an SSH public key (ssh-ed25519 or ssh-rsa), like
+// the recipients files of the age CLI. Empty lines and comments are skipped.
+func loadRecipients(path string) ([]age.Recipient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	recipients := []age.Recipient{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var recipient age.Recipient
+		if strings.HasPrefix(line, "age1") {
+			recipient, err = age.ParseX25519Recipient(line)
+		} else {
+			recipient, err = agessh.ParseRecipient(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse the recipient in line %d of %s: %v", n, path, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("The recipients file %s does not contain any recipient.", path)
+	}
+	return recipients, nil
+}
+
+// loadIdentities reads an age identity file, as written by age-keygen, or an
+// unencrypted SSH private key.
+func loadIdentities(path string) ([]age.Identity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN")) {
+		identity, err := agessh.ParseIdentity(data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse the SSH private key %s: %v", path, err)
+		}
+		return []age.Identity{identity}, nil
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse the identities of %s: %v", path, err)
+	}
+	return identities, nil
+}
+
+func encryptReport(w io.Writer, recipients []age.Recipient, plaintext []byte) error {
+	armored := armor.NewWriter(w)
+	enc, err := age.Encrypt(armored, recipients...)
+	if err != nil {
+		return err
+	}
+
+	_, err = enc.Write(plaintext)
+	if err != nil {
+		return err
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
+
+	return armored.Close()
+}
+
+func decryptReport(encrypted []byte, identities ...age.Identity) ([]byte, error) {
+	var src io.Reader = bytes.NewReader(encrypted)
+	if bytes.HasPrefix(bytes.TrimSpace(encrypted), []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(encrypted)))
+	}
+
+	plain, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(plain)
+}
+
+// reportRenderer writes a report in a certain format.
+type reportRenderer func(w io.Writer, r *report.Report) error
+
+// reportRenderer returns the renderer for the format. It encrypts the report,
+// if --encrypt-report-kms-key or --encrypt-report-recipients is specified.
+func (n *Nuke) reportRenderer(format string) reportRenderer {
+	return func(w io.Writer, r *report.Report) error {
+		if len(n.reportRecipients) == 0 {
+			return report.Write(format, w, r)
+		}
+
+		buf := new(bytes.Buffer)
+		err := report.Write(format, buf, r)
+		if err != nil {
+			return err
+		}
+
+		return encryptReport(w, n.reportRecipients, buf.Bytes())
+	}
+}
+
+// prepareReportKeys loads the recipients to encrypt the reports for, so a
+// broken key is noticed before the scan and not only at the end of the run.
+func (n *Nuke) prepareReportKeys() error {
+	if n.Parameters.EncryptReportKMSKey != "" {
+		keyID := n.Parameters.EncryptReportKMSKey
+		region := kmsKeyRegion(keyID, n.Account.DefaultRegionID())
+		client, err := newKMSClient(&n.Account.Credentials, region)
+		if err != nil {
+			return err
+		}
+
+		err = n.addKMSRecipient(&kmsRecipient{client: client, keyID: keyID, region: region})
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.Parameters.EncryptReportRecipients != "" {
+		recipients, err := loadRecipients(n.Parameters.EncryptReportRecipients)
+		if err != nil {
+			return err
+		}
+		n.reportRecipients = append(n.reportRecipients, recipients...)
+	}
+
+	return nil
+}
+
+// addKMSRecipient checks that the KMS key can be used and prevents the
+// removal of the key and of the alias it was specified with, since the
+// reports are only encrypted at the end of the run.
+func (n *Nuke) addKMSRecipient(r *kmsRecipient) error {
+	resp, err := r.client.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(r.keyID),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to describe the KMS key %s: %v", r.keyID, err)
+	}
+
+	state := aws.StringValue(resp.KeyMetadata.KeyState)
+	if state != kms.KeyStateEnabled {
+		return fmt.Errorf("The KMS key %s cannot encrypt the reports, since it is in the state %s.",
+			r.keyID, state)
+	}
+
+	n.protect("KMSKey", config.Filter{Value: aws.StringValue(resp.KeyMetadata.KeyId)},
+		"the key of --encrypt-report-kms-key")
+	if alias := kmsAliasName(r.keyID); alias != "" {
+		n.protect("KMSAlias", config.Filter{Value: alias},
+			"the alias of --encrypt-report-kms-key")
+	}
+
+	n.reportRecipients = append(n.reportRecipients, r)
+	return nil
+}
+
+// kmsAliasName returns the name of the alias (alias/...), if the key was
+// specified by an alias or an alias ARN. Otherwise it is empty.
+func kmsAliasName(keyID string) string {
+	parsed, err := arn.Parse(keyID)
+	if err == nil {
+		keyID = parsed.Resource
+	}
+
+	if strings.HasPrefix(keyID, "alias/") {
+		return keyID
+	}
+	return ""
+}
+
+func NewDecryptReportCommand(creds *awsutil.Credentials) *cobra.Command {
+	var identityFile string
+
+	cmd := &cobra.Command{
+		Use:   "decrypt-report <file>",
+		Short: "decrypts a report, which was encrypted with a KMS key or for age recipients, and prints it to stdout",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		// The identities of --identity are tried first, so reports, which
+		// were also encrypted with a KMS key, do not need AWS credentials.
+		identities := []age.Identity{}
+		if identityFile != "" {
+			identities, err = loadIdentities(identityFile)
+			if err != nil {
+				return err
+			}
+		}
+		identities = append(identities, &kmsIdentity{
+			client: func(region string) (kmsiface.KMSAPI, error) {
+				err := creds.Validate()
+				if err != nil {
+					return nil, err
+				}
+				return newKMSClient(creds, region)
+			},
+		})
+
+		cmd.SilenceUsage = true
+
+		plaintext, err := decryptReport(data, identities...)
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return fmt.Errorf("The report %s was not encrypted with a KMS key or for an identity "+
+				"of --identity.", args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to decrypt the report %s: %v", args[0], err)
+		}
+
+		_, err = os.Stdout.Write(plaintext)
+		return err
+	}
+
+	cmd.Flags().StringVar(
+		&identityFile, "identity", "",
+		"Path of an age identity file or an SSH private key, if the report was encrypted "+
+			"with --encrypt-report-recipients.")
+
+	return cmd
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+)
+
+type encryptTestKMS struct {
+	kmsiface.KMSAPI
+	fileKey []byte
+	state   string
+}
+
+func (k *encryptTestKMS) DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	if aws.StringValue(input.KeyId) == "alias/missing" {
+		return nil, errors.New("NotFoundException: Alias is not found")
+	}
+	return &kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{
+		KeyId:    aws.String("1234abcd-12ab-34cd-56ef-1234567890ab"),
+		KeyState: aws.String(k.state),
+	}}, nil
+}
+
+func (k *encryptTestKMS) Encrypt(input *kms.EncryptInput) (*kms.EncryptOutput, error) {
+	k.fileKey = append([]byte{}, input.Plaintext...)
+	return &kms.EncryptOutput{KeyId: input.KeyId, CiphertextBlob: []byte("encrypted-file-key")}, nil
+}
+
+func (k *encryptTestKMS) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	if string(input.CiphertextBlob) != "encrypted-file-key" {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return &kms.DecryptOutput{KeyId: input.KeyId, Plaintext: append([]byte{}, k.fileKey...)}, nil
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func renderEncrypted(t *testing.T, recipients []age.Recipient) ([]byte, []byte) {
+	t.Helper()
+
+	r := storageTestReport()
+	plain := new(bytes.Buffer)
+	err := report.Write("json", plain, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := &Nuke{reportRecipients: recipients}
+	buf := new(bytes.Buffer)
+	err = n.reportRenderer("json")(buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "i-01b489457a60298dd") {
+		t.Errorf("The encrypted report contains the plaintext.")
+	}
+	if !strings.HasPrefix(buf.String(), armor.Header) {
+		t.Errorf("The encrypted report is not armored: %q", buf.String())
+	}
+	return buf.Bytes(), plain.Bytes()
+}
+
+func TestEncryptReportRecipients(t *testing.T) {
+	dir := t.TempDir()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "recipients.txt"),
+		"# reports\n\n"+identity.Recipient().String()+"\n")
+	writeTestFile(t, filepath.Join(dir, "identity.txt"), identity.String()+"\n")
+
+	n := &Nuke{Parameters: NukeParameters{EncryptReportRecipients: filepath.Join(dir, "recipients.txt")}}
+	err = n.prepareReportKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.reportRecipients) != 1 {
+		t.Fatalf("Expected one recipient. Have: %v", n.reportRecipients)
+	}
+
+	encrypted, plain := renderEncrypted(t, n.reportRecipients)
+
+	identities, err := loadIdentities(filepath.Join(dir, "identity.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := decryptReport(encrypted, identities...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plain) {
+		t.Errorf("Wrong decrypted report.\nWant: %s\nHave: %s", plain, decrypted)
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var noMatch *age.NoIdentityMatchError
+	_, err = decryptReport(encrypted, other)
+	if !errors.As(err, &noMatch) {
+		t.Errorf("Expected an error for the wrong identity: %v", err)
+	}
+}
+
+func TestEncryptReportKMS(t *testing.T) {
+	client := &encryptTestKMS{}
+	recipient := &kmsRecipient{client: client, keyID: "alias/aws-nuke", region: "eu-west-1"}
+
+	encrypted, plain := renderEncrypted(t, []age.Recipient{recipient})
+
+	regions := []string{}
+	identity := &kmsIdentity{client: func(region string) (kmsiface.KMSAPI, error) {
+		regions = append(regions, region)
+		return client, nil
+	}}
+
+	decrypted, err := decryptReport(encrypted, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plain) {
+		t.Errorf("Wrong decrypted report.\nWant: %s\nHave: %s", plain, decrypted)
+	}
+	if len(regions) != 1 || regions[0] != "eu-west-1" {
+		t.Errorf("Wrong region of the KMS client: %v", regions)
+	}
+
+	// The recipients are part of the authenticated header, so they cannot be
+	// changed without noticing.
+	raw, err := io.ReadAll(armor.NewReader(bytes.NewReader(encrypted)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Replace(raw, []byte("eu-west-1"), []byte("eu-west-2"), 1)
+	_, err = decryptReport(tampered, identity)
+	if err == nil || !strings.Contains(err.Error(), "header MAC") {
+		t.Errorf("Expected an error for a tampered header: %v", err)
+	}
+}
+
+func TestEncryptReportKMSAndRecipients(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipients := []age.Recipient{
+		&kmsRecipient{client: &encryptTestKMS{}, keyID: "alias/aws-nuke", region: "eu-west-1"},
+		identity.Recipient(),
+	}
+
+	encrypted, plain := renderEncrypted(t, recipients)
+
+	decrypted, err := decryptReport(encrypted, identity, &kmsIdentity{
+		client: func(region string) (kmsiface.KMSAPI, error) {
+			t.Errorf("Unexpected KMS client for %s.", region)
+			return nil, errors.New("no credentials")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plain) {
+		t.Errorf("Wrong decrypted report.\nWant: %s\nHave: %s", plain, decrypted)
+	}
+}
+
+func TestLoadRecipients(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"empty.txt":   "# no recipients\n",
+		"invalid.txt": "age1invalid\n",
+		"pem.txt":     "-----BEGIN PUBLIC KEY-----\n",
+	}
+
+	for name, content := range cases {
+		path := filepath.Join(dir, name)
+		writeTestFile(t, path, content)
+
+		_, err := loadRecipients(path)
+		if err == nil {
+			t.Errorf("Expected an error for %s.", name)
+		}
+	}
+}
+
+func TestAddKMSRecipient(t *testing.T) {
+	client := &encryptTestKMS{state: kms.KeyStateEnabled}
+	n := &Nuke{}
+	err := n.addKMSRecipient(&kmsRecipient{client: client, keyID: "arn:aws:kms:eu-west-1:123456789012:alias/aws-nuke", region: "eu-west-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.reportRecipients) != 1 {
+		t.Errorf("Expected the KMS recipient. Have: %v", n.reportRecipients)
+	}
+
+	cases := []struct {
+		item      *Item
+		protected bool
+	}{
+		{&Item{Type: "KMSKey", Resource: &stringerTestResource{id: "1234abcd-12ab-34cd-56ef-1234567890ab"}}, true},
+		{&Item{Type: "KMSKey", Resource: &stringerTestResource{id: "0987dcba-09fe-87dc-65ba-ab0987654321"}}, false},
+		{&Item{Type: "KMSAlias", Resource: &stringerTestResource{id: "alias/aws-nuke"}}, true},
+		{&Item{Type: "KMSAlias", Resource: &stringerTestResource{id: "alias/other"}}, false},
+	}
+	for _, tc := range cases {
+		reason := n.ownResourceReason(tc.item)
+		if (reason != "") != tc.protected {
+			t.Errorf("Wrong protection of %v: %q", tc.item.Resource, reason)
+		}
+	}
+
+	err = (&Nuke{}).addKMSRecipient(&kmsRecipient{client: client, keyID: "alias/missing", region: "eu-west-1"})
+	if err == nil {
+		t.Errorf("Expected an error for a missing key.")
+	}
+
+	client.state = kms.KeyStatePendingDeletion
+	err = (&Nuke{}).addKMSRecipient(&kmsRecipient{client: client, keyID: "alias/aws-nuke", region: "eu-west-1"})
+	if err == nil {
+		t.Errorf("Expected an error for a key, which is pending deletion.")
+	}
+}
+
+func TestKMSKeyRegion(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:kms:eu-central-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": "eu-central-1",
+		"arn:aws:kms:eu-west-1:123456789012:alias/aws-nuke":                              "eu-west-1",
+		"alias/aws-nuke":                       awsutil.DefaultRegionID,
+		"1234abcd-12ab-34cd-56ef-1234567890ab": awsutil.DefaultRegionID,
+	}
+
+	for keyID, want := range cases {
+		if have := kmsKeyRegion(keyID, awsutil.DefaultRegionID); have != want {
+			t.Errorf("Wrong region for %s. Want: %s. Have: %s", keyID, want, have)
+		}
+	}
+}
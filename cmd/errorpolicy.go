@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+// awsErrorCode returns the error code of an AWS API error or an empty string
+// for other errors.
+func awsErrorCode(err error) string {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return ""
+	}
+	return aerr.Code()
+}
+
+// errorAction returns the action of the configured error policies for the
+// error. It returns an empty string, if no policy matches.
+func (n *Nuke) errorAction(resourceType string, err error) string {
+	if n.Config == nil {
+		return ""
+	}
+	return n.Config.ErrorAction(resourceType, awsErrorCode(err))
+}
+
+// handleError updates the item after a failed request based on the error
+// policies. Without a matching policy, the item fails and is retried in the
+// next iteration, unless an SCP denied the request. Then all items of the type
+// in the region are skipped, since retrying cannot succeed.
+func (n *Nuke) handleError(item *Item, err error) {
+	switch n.errorAction(item.Type, err) {
+	case config.ErrorActionSkip:
+		item.State = ItemStateFiltered
+		item.Reason = fmt.Sprintf("skipped by error policy: %v", err)
+	case config.ErrorActionFail:
+		item.State = ItemStateFailed
+		item.Reason = err.Error()
+		if n.failure == nil {
+			n.failure = fmt.Errorf("Removing %s %s failed and the error policy aborts the run: %v",
+				item.Type, item.Identifier(), err)
+		}
+	default:
+		if isSCPDenied(err) {
+			reason := fmt.Sprintf("blocked by SCP: %v", err)
+			n.blockSCP(item.Region.Name, item.Type, reason)
+			item.State = ItemStateFiltered
+			item.Reason = reason
+			return
+		}
+		item.State = ItemStateFailed
+		item.Reason = err.Error()
+	}
+}
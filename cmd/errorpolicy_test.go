@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestHandleError(t *testing.T) {
+	n := &Nuke{
+		Config: &config.Nuke{
+			ErrorPolicies: []config.ErrorPolicy{
+				{
+					ResourceTypes: []string{"Organizations*"},
+					ErrorCodes:    []string{"AccessDeniedException"},
+					Action:        config.ErrorActionSkip,
+				},
+				{
+					ErrorCodes: []string{"InvalidClientTokenId"},
+					Action:     config.ErrorActionFail,
+				},
+			},
+		},
+	}
+
+	denied := awserr.New("AccessDeniedException", "denied", nil)
+
+	cases := []struct {
+		resourceType string
+		err          error
+		state        ItemState
+		failure      bool
+	}{
+		{"OrganizationsPolicy", denied, ItemStateFiltered, false},
+		{"S3Bucket", denied, ItemStateFailed, false},
+		{"S3Bucket", errors.New("no AWS error"), ItemStateFailed, false},
+		{"S3Bucket", awserr.New("InvalidClientTokenId", "invalid", nil), ItemStateFailed, true},
+	}
+
+	for _, tc := range cases {
+		n.failure = nil
+		item := &Item{
+			Type:     tc.resourceType,
+			State:    ItemStateNew,
+			Resource: &testResource{props: types.NewProperties()},
+		}
+
+		n.handleError(item, tc.err)
+
+		if item.State != tc.state {
+			t.Errorf("Wrong state for %s and %v. Want: %v. Have: %v", tc.resourceType, tc.err, tc.state, item.State)
+		}
+		if (n.failure != nil) != tc.failure {
+			t.Errorf("Wrong failure for %s and %v: %v", tc.resourceType, tc.err, n.failure)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	log "github.com/sirupsen/logrus"
+)
+
+// Names of the events in the --events-file.
+const (
+	EventDiscovered      = "discovered"
+	EventFiltered        = "filtered"
+	EventDeleteRequested = "delete-requested"
+	EventWaiting         = "waiting"
+	EventRemoved         = "removed"
+	EventFailed          = "failed"
+)
+
+var stateEvents = map[ItemState]string{
+	ItemStatePending:  EventDeleteRequested,
+	ItemStateWaiting:  EventWaiting,
+	ItemStateFailed:   EventFailed,
+	ItemStateFiltered: EventFiltered,
+	ItemStateFinished: EventRemoved,
+}
+
+// Event is a single line of the --events-file. Besides the event name and
+// time, it contains the resource like the entries of the reports.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+
+	report.Entry
+}
+
+// asyncSinkBuffer is the number of events, which an asyncSink buffers before
+// Send blocks.
+const asyncSinkBuffer = 1000
+
+// asyncSink sends the events to a slow sink like the ResultPublisher in the
+// background, so the run is not blocked by its requests while the EventWriter
+// is locked. Since the events are sent later, failures are only logged.
+type asyncSink struct {
+	sink   EventSink
+	events chan Event
+	done   chan struct{}
+}
+
+func newAsyncSink(sink EventSink) *asyncSink {
+	s := &asyncSink{
+		sink:   sink,
+		events: make(chan Event, asyncSinkBuffer),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		for event := range s.events {
+			err := s.sink.Send(event)
+			if err != nil {
+				log.Warnf("Failed to publish the event of %s: %v", event.Type, err)
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *asyncSink) Send(event Event) error {
+	s.events <- event
+	return nil
+}
+
+// Close waits until all buffered events are sent.
+func (s *asyncSink) Close() error {
+	close(s.events)
+	<-s.done
+	return nil
+}
+
+// EventWriter streams an event per state transition of the items as newline
+// delimited JSON, so other tools can follow a run while it is in progress. The
+// events are also sent to the sinks.
+type EventWriter struct {
+	mu    sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+	sinks []EventSink
+
+	// states are the states of the items, when their last event was
+	// written.
+	states map[*Item]ItemState
+}
+
+// NewEventWriter creates the events file. If the path is "-", the events are
+// written to stdout instead. If it is empty, they are only sent to the sinks.
+func NewEventWriter(path string) (*EventWriter, error) {
+	if path == "" {
+		return &EventWriter{states: map[*Item]ItemState{}}, nil
+	}
+
+	if path == "-" {
+		return newEventWriter(reportStdout, nil), nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEventWriter(f, f), nil
+}
+
+func newEventWriter(w io.Writer, file *os.File) *EventWriter {
+	return &EventWriter{
+		file:   file,
+		enc:    json.NewEncoder(w),
+		states: map[*Item]ItemState{},
+	}
+}
+
+// AddSink sends all further events also to the sink.
+func (w *EventWriter) AddSink(sink EventSink) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.sinks = append(w.sinks, sink)
+}
+
+// Discovered writes the event of a newly scanned item, followed by the event
+// of its state, if it was already filtered.
+func (w *EventWriter) Discovered(item *Item) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.states[item] = ItemStateNew
+	err := w.write(EventDiscovered, item)
+	if err != nil {
+		return err
+	}
+
+	return w.transition(item)
+}
+
+// Transition writes the event of the state of the item, unless it did not
+// change since the last event of the item.
+func (w *EventWriter) Transition(item *Item) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.transition(item)
+}
+
+func (w *EventWriter) transition(item *Item) error {
+	event, ok := stateEvents[item.State]
+	if !ok {
+		return nil
+	}
+
+	last, ok := w.states[item]
+	if ok && last == item.State {
+		return nil
+	}
+	w.states[item] = item.State
+
+	return w.write(event, item)
+}
+
+func (w *EventWriter) write(name string, item *Item) error {
+	event := Event{
+		Time:  time.Now(),
+		Event: name,
+		Entry: newReportEntry(item),
+	}
+
+	if w.enc != nil {
+		err := w.enc.Encode(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sink := range w.sinks {
+		err := sink.Send(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the events file and waits for the sinks, which send their
+// events in the background.
+func (w *EventWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sink := range w.sinks {
+		closer, ok := sink.(io.Closer)
+		if ok {
+			closer.Close()
+		}
+	}
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
+
+// emitEvent writes the state transition of the item to the --events-file. It
+// is a no-op, if no file was specified.
+func (n *Nuke) emitEvent(item *Item, discovered bool) {
+	if n.events == nil {
+		return
+	}
+
+	var err error
+	if discovered {
+		err = n.events.Discovered(item)
+	} else {
+		err = n.events.Transition(item)
+	}
+	if err != nil {
+		n.output().Logger.Warnf("Failed to publish the event of %s: %v", item.Type, err)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEventWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	w, err := NewEventWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	region := &Region{Name: "eu-west-1"}
+	instance := &Item{Region: region, Type: "EC2Instance",
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	bucket := &Item{Region: region, Type: "S3Bucket", State: ItemStateFiltered, Reason: "filtered by config",
+		Resource: &stringerTestResource{id: "s3://logs"}}
+
+	n := &Nuke{events: w}
+	n.emitEvent(instance, true)
+	n.emitEvent(bucket, true)
+
+	for _, state := range []ItemState{ItemStatePending, ItemStateWaiting, ItemStateWaiting, ItemStateFinished} {
+		instance.State = state
+		n.emitEvent(instance, false)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	have := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatalf("Invalid event %q: %v", scanner.Text(), err)
+		}
+		have = append(have, event.Event+" "+event.ID)
+	}
+
+	want := []string{
+		"discovered i-01b489457a60298dd",
+		"discovered s3://logs",
+		"filtered s3://logs",
+		"delete-requested i-01b489457a60298dd",
+		"waiting i-01b489457a60298dd",
+		"removed i-01b489457a60298dd",
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("Wrong events.\nWant: %v\nHave: %v", want, have)
+	}
+}
+
+func TestEventWriterStdout(t *testing.T) {
+	buf := new(bytes.Buffer)
+	reportStdout = buf
+	defer func() { reportStdout = os.Stdout }()
+
+	w, err := NewEventWriter("-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := &Item{Region: &Region{Name: "eu-west-1"}, Type: "EC2Instance", State: ItemStateFailed,
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	err = w.Transition(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var event Event
+	err = json.Unmarshal(buf.Bytes(), &event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Event != EventFailed || event.ID != "i-01b489457a60298dd" {
+		t.Errorf("Wrong event on stdout: %s", buf.String())
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	log "github.com/sirupsen/logrus"
+)
+
+// EvidenceRecord is a single line of the deletion evidence in S3. The records
+// are stored as newline delimited JSON, which can be queried with Athena.
+type EvidenceRecord struct {
+	Time       time.Time         `json:"time"`
+	RunStarted time.Time         `json:"run-started"`
+	Version    string            `json:"version"`
+	AccountID  string            `json:"account-id"`
+	Region     string            `json:"region"`
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	State      string            `json:"state"`
+	Reason     string            `json:"reason,omitempty"`
+	Owner      string            `json:"owner,omitempty"`
+}
+
+// parseS3URI splits an URI like s3://bucket/prefix into the bucket and the
+// prefix.
+func parseS3URI(uri string) (string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("Invalid S3 URI '%s' for --evidence-s3-uri. Expected s3://bucket/prefix.\n", uri)
+	}
+
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// evidenceObjects groups the removed and failed resources of the report into
+// one object per resource type. The keys are partitioned by account, date and
+// resource type in the Hive layout, which Athena uses as partition columns.
+func evidenceObjects(prefix string, r *report.Report) map[string][]EvidenceRecord {
+	objects := map[string][]EvidenceRecord{}
+	name := r.Started.UTC().Format("20060102T150405Z") + ".json"
+
+	for _, e := range r.Entries {
+		if e.State != report.StateFinished && e.State != report.StateFailed {
+			continue
+		}
+
+		key := path.Join(prefix,
+			"account="+r.AccountID,
+			"date="+r.Started.UTC().Format("2006-01-02"),
+			"type="+e.Type,
+			name)
+
+		objects[key] = append(objects[key], EvidenceRecord{
+			Time:       r.Time,
+			RunStarted: r.Started,
+			Version:    r.Version,
+			AccountID:  r.AccountID,
+			Region:     e.Region,
+			Type:       e.Type,
+			ID:         e.ID,
+			Properties: e.Properties,
+			State:      e.State,
+			Reason:     e.Reason,
+			Owner:      e.Owner,
+		})
+	}
+
+	return objects
+}
+
+func writeEvidence(client s3iface.S3API, bucket, prefix string, r *report.Report) error {
+	objects := evidenceObjects(prefix, r)
+
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		buf := new(bytes.Buffer)
+		enc := json.NewEncoder(buf)
+		for _, record := range objects[key] {
+			err := enc.Encode(record)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := client.PutObject(&s3.PutObjectInput{
+			Bucket:      aws.String(bucket),
+			Key:         aws.String(key),
+			Body:        bytes.NewReader(buf.Bytes()),
+			ContentType: aws.String("application/x-ndjson"),
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to write the evidence s3://%s/%s: %v", bucket, key, err)
+		}
+	}
+
+	log.Infof("Wrote the deletion evidence of %d resource types to s3://%s/%s.", len(keys), bucket, prefix)
+	return nil
+}
+
+// protectEvidence prevents the removal of the bucket of --evidence-s3-uri and
+// of its objects, since the evidence is only written after the removal.
+func (n *Nuke) protectEvidence() error {
+	if n.Parameters.EvidenceURI == "" {
+		return nil
+	}
+
+	bucket, _, err := parseS3URI(n.Parameters.EvidenceURI)
+	if err != nil {
+		return err
+	}
+
+	n.protect("S3Bucket", config.Filter{Value: "s3://" + bucket},
+		"the bucket of --evidence-s3-uri")
+	n.protect("S3Object", config.Filter{Property: "Bucket", Value: bucket},
+		"stored in the bucket of --evidence-s3-uri")
+	return nil
+}
+
+// WriteEvidence writes the removed and failed resources to the bucket of
+// --evidence-s3-uri. Dry runs do not remove anything, so they do not write any
+// evidence.
+func (n *Nuke) WriteEvidence() error {
+	bucket, prefix, err := parseS3URI(n.Parameters.EvidenceURI)
+	if err != nil {
+		return err
+	}
+
+	if !n.Parameters.NoDryRun {
+		n.output().Logger.Debugf("not writing deletion evidence in a dry run")
+		return nil
+	}
+
+	client, err := n.bucketClient(bucket)
+	if err != nil {
+		return err
+	}
+
+	return writeEvidence(client, bucket, prefix, n.Report())
+}
+
+// bucketClient returns an S3 client for the region of the bucket.
+func (n *Nuke) bucketClient(bucket string) (s3iface.S3API, error) {
+	defaultRegion := n.Account.DefaultRegionID()
+	sess, err := n.Account.NewSession(defaultRegion, n.Account.ResourceTypeToServiceType(defaultRegion, "S3Object"))
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := s3manager.GetBucketRegion(aws.BackgroundContext(), sess, bucket, defaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the region of the bucket %s: %v", bucket, err)
+	}
+
+	return s3.New(sess, &aws.Config{Region: aws.String(region)}), nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type evidenceTestS3 struct {
+	s3iface.S3API
+	objects map[string]string
+}
+
+func (s *evidenceTestS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)] = string(data)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestParseS3URI(t *testing.T) {
+	bucket, prefix, err := parseS3URI("s3://audit/aws-nuke/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket != "audit" || prefix != "aws-nuke" {
+		t.Errorf("Wrong bucket or prefix: %s, %s", bucket, prefix)
+	}
+
+	_, _, err = parseS3URI("audit/aws-nuke")
+	if err == nil {
+		t.Errorf("Expected error for URI without s3 scheme.")
+	}
+}
+
+func TestWriteEvidence(t *testing.T) {
+	started := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := &report.Report{
+		AccountID: "123456789012",
+		Started:   started,
+		Time:      started.Add(time.Hour),
+		Entries: []report.Entry{
+			{Region: "eu-west-1", Type: "EC2Instance", ID: "i-01b489457a60298dd", State: report.StateFinished},
+			{Region: "eu-west-1", Type: "EC2Instance", ID: "i-0b0f4b4e8a0a7f3c1", State: report.StateFailed, Reason: "AccessDenied"},
+			{Region: "eu-west-1", Type: "S3Bucket", ID: "s3://logs", State: report.StateFiltered},
+		},
+	}
+
+	client := &evidenceTestS3{objects: map[string]string{}}
+	err := writeEvidence(client, "audit", "aws-nuke", r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "audit/aws-nuke/account=123456789012/date=2024-03-01/type=EC2Instance/20240301T120000Z.json"
+	if len(client.objects) != 1 {
+		t.Fatalf("Expected only the object for %s. Have: %v", key, client.objects)
+	}
+
+	lines := strings.Split(strings.TrimSpace(client.objects[key]), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"reason":"AccessDenied"`) {
+		t.Errorf("Wrong evidence records: %v", lines)
+	}
+}
+
+func TestProtectEvidence(t *testing.T) {
+	n := &Nuke{Parameters: NukeParameters{EvidenceURI: "s3://audit/aws-nuke"}}
+	err := n.protectEvidence()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := func(bucket string) *Item {
+		return &Item{Type: "S3Object", Resource: &testResource{
+			props: types.NewProperties().Set("Bucket", bucket).Set("Key", "aws-nuke/run.json"),
+		}}
+	}
+
+	cases := []struct {
+		item      *Item
+		protected bool
+	}{
+		{&Item{Type: "S3Bucket", Resource: &stringerTestResource{id: "s3://audit"}}, true},
+		{&Item{Type: "S3Bucket", Resource: &stringerTestResource{id: "s3://audit-other"}}, false},
+		{object("audit"), true},
+		{object("logs"), false},
+	}
+
+	for _, tc := range cases {
+		reason := n.ownResourceReason(tc.item)
+		if (reason != "") != tc.protected {
+			t.Errorf("Wrong protection of %v: %q", tc.item.Resource, reason)
+		}
+	}
+
+	n = &Nuke{Parameters: NukeParameters{EvidenceURI: "audit/aws-nuke"}}
+	err = n.protectEvidence()
+	if err == nil {
+		t.Errorf("Expected error for URI without s3 scheme.")
+	}
+}
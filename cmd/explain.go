@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+	"github.com/rebuy-de/aws-nuke/resources"
+	"github.com/spf13/cobra"
+)
+
+func NewExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <resource-type>...",
+		Short: "shows details about resource types, like their filter properties",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		for i, name := range args {
+			meta, ok := resources.GetMetadata(name)
+			if !ok {
+				return fmt.Errorf("Unknown resource type '%s'.", name)
+			}
+
+			if i > 0 {
+				fmt.Println()
+			}
+
+			properties := append(append([]string{}, meta.Properties...), meta.LazyProperties...)
+			if meta.Tags {
+				properties = append(properties, "tag:<key>")
+			}
+			if meta.Raw {
+				properties = append(properties, resources.RawProperty+".<path>")
+			}
+			if len(properties) == 0 {
+				properties = []string{"-"}
+			}
+
+			fmt.Printf("%s:\n", name)
+			fmt.Printf("  service:     %s\n", meta.Service)
+			fmt.Printf("  endpoint:    %s\n", meta.EndpointsID)
+			fmt.Printf("  legacy id:   %t\n", meta.LegacyID)
+			fmt.Printf("  properties:  %s\n", strings.Join(properties, ", "))
+			if meta.DynamicProperties {
+				fmt.Printf("               (the resource has additional properties which are only known at runtime)\n")
+			}
+			fmt.Printf("  iam actions: %s\n", strings.Join(meta.Actions, ", "))
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+type iamPolicyDocument struct {
+	Version   string               `json:"Version"`
+	Statement []iamPolicyStatement `json:"Statement"`
+}
+
+type iamPolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+func NewIAMPolicyCommand(params *NukeParameters) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "iam-policy",
+		Short: "prints an IAM policy which allows aws-nuke to list and remove the selected resource types",
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		resourceTypes := ResolveResourceTypes(
+			resources.GetListerNames(),
+			[]types.Collection{params.Targets},
+			[]types.Collection{params.Excludes},
+		)
+
+		actions := map[string]bool{
+			"sts:GetCallerIdentity":  true,
+			"iam:ListAccountAliases": true,
+		}
+		if params.SweepByTag != "" {
+			actions["tag:GetResources"] = true
+		}
+		for _, name := range resourceTypes {
+			meta, ok := resources.GetMetadata(name)
+			if !ok {
+				continue
+			}
+			for _, action := range meta.Actions {
+				actions[action] = true
+			}
+		}
+
+		statement := iamPolicyStatement{
+			Effect:   "Allow",
+			Resource: "*",
+		}
+		for action := range actions {
+			statement.Action = append(statement.Action, action)
+		}
+		sort.Strings(statement.Action)
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(iamPolicyDocument{
+			Version:   "2012-10-17",
+			Statement: []iamPolicyStatement{statement},
+		})
+	}
+
+	return cmd
+}
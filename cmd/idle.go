@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+// idleReason returns why the item does not count as idle for --only-idle. It
+// returns an empty string for idle items, which had no activity within the
+// configured duration.
+func (n *Nuke) idleReason(item *Item) string {
+	getter, ok := item.Resource.(resources.ActivityGetter)
+	if !ok {
+		return "idle detection not supported"
+	}
+
+	lastActivity, ok := getter.LastActivity()
+	if !ok {
+		return "last activity unknown"
+	}
+
+	if time.Since(lastActivity) < n.Parameters.OnlyIdle {
+		return fmt.Sprintf("last active at %s", lastActivity.UTC().Format(time.RFC3339))
+	}
+
+	return ""
+}
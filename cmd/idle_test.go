@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+type idleTestResource struct {
+	testResource
+	lastActivity time.Time
+	known        bool
+}
+
+func (r *idleTestResource) LastActivity() (time.Time, bool) {
+	return r.lastActivity, r.known
+}
+
+func TestIdleReason(t *testing.T) {
+	n := &Nuke{Parameters: NukeParameters{OnlyIdle: 30 * 24 * time.Hour}}
+	props := testResource{props: types.NewProperties()}
+
+	cases := []struct {
+		name     string
+		resource resources.Resource
+		idle     bool
+	}{
+		{"unsupported", &props, false},
+		{"unknown", &idleTestResource{testResource: props}, false},
+		{"active", &idleTestResource{props, time.Now().Add(-24 * time.Hour), true}, false},
+		{"idle", &idleTestResource{props, time.Now().Add(-60 * 24 * time.Hour), true}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &Item{Resource: tc.resource}
+			reason := n.idleReason(item)
+			if (reason == "") != tc.idle {
+				t.Errorf("Wrong idle state. Want: %t. Reason: %q", tc.idle, reason)
+			}
+		})
+	}
+}
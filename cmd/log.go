@@ -2,26 +2,157 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/util"
 	"github.com/rebuy-de/aws-nuke/resources"
+	log "github.com/sirupsen/logrus"
 )
 
 var (
-	ReasonSkip            = *color.New(color.FgYellow)
+	ReasonSkip            = *color.New(color.FgHiBlack)
 	ReasonError           = *color.New(color.FgRed)
 	ReasonRemoveTriggered = *color.New(color.FgGreen)
 	ReasonWaitPending     = *color.New(color.FgBlue)
 	ReasonSuccess         = *color.New(color.FgGreen)
 )
 
+// Output holds the settings for printing the resources of a run. Every run
+// has its own, since the runs of --all-accounts can use different settings
+// and are nuked at the same time with --account-concurrency.
+type Output struct {
+	// Redactor hides sensitive values in the printed resources. It is nil,
+	// if nothing should be redacted.
+	Redactor *util.Redactor
+
+	// States are the states of the printed resources. It is nil, if every
+	// state should be printed.
+	States map[ItemState]bool
+
+	// Writer is where the resources and messages of the run are printed. It
+	// is nil for stdout.
+	Writer io.Writer
+
+	// Logger prints the log messages of the run. It is nil, if they are
+	// printed unchanged.
+	Logger *Logger
+}
+
+// defaultOutput prints every resource without redaction. It is used, if no
+// output is set, eg in tests.
+var defaultOutput = &Output{}
+
+// NewOutput returns the output of a run with the redaction of the config and
+// the states of --show.
+func NewOutput(redaction config.Redaction, show []string) (*Output, error) {
+	redactor, err := util.NewRedactor(redaction.Properties, redaction.Patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := ParseShowStates(show)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Output{Redactor: redactor, States: states}, nil
+}
+
+func (o *Output) writer() io.Writer {
+	if o.Writer == nil {
+		return os.Stdout
+	}
+	return o.Writer
+}
+
+func (o *Output) shown(state ItemState) bool {
+	return o.States == nil || o.States[state]
+}
+
+// useAccountPrefix prefixes every printed line and log message of the run
+// with the account ID, so the runs of --all-accounts, which are nuked at the
+// same time, can be told apart.
+func (o *Output) useAccountPrefix(accountID string) {
+	o.Writer = &prefixWriter{w: o.writer(), prefix: ColorAccount.Sprint(accountID) + " - "}
+	o.Logger = &Logger{prefix: accountID + " - "}
+}
+
+// Logger prints the log messages of a run with a prefix. A nil Logger prints
+// them unchanged.
+type Logger struct {
+	prefix string
+}
+
+func (l *Logger) format(format string) string {
+	if l == nil {
+		return format
+	}
+	return l.prefix + format
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	log.Debugf(l.format(format), args...)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	log.Infof(l.format(format), args...)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	log.Warnf(l.format(format), args...)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	log.Errorf(l.format(format), args...)
+}
+
+// prefixWriter writes the prefix at the beginning of every line. Each write
+// is passed on at once, so the lines of concurrent runs do not get mixed up.
+type prefixWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+
+	// midLine is true, if the last write did not end with a newline.
+	midLine bool
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	buf := new(strings.Builder)
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if line == "" {
+			continue
+		}
+		if !p.midLine {
+			buf.WriteString(p.prefix)
+		}
+		buf.WriteString(line)
+		p.midLine = !strings.HasSuffix(line, "\n")
+	}
+
+	_, err := io.WriteString(p.w, buf.String())
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
 var (
+	ColorAccount            = *color.New(color.Faint)
 	ColorRegion             = *color.New(color.Bold)
 	ColorResourceType       = *color.New()
 	ColorResourceID         = *color.New(color.Bold)
 	ColorResourceProperties = *color.New(color.Italic)
+	ColorGroupHeader        = *color.New(color.Bold, color.Underline)
 )
 
 // Format the resource properties in sorted order ready for printing.
@@ -40,23 +171,71 @@ func Sorted(m map[string]string) string {
 	return fmt.Sprintf("[%s]", strings.Join(sorted, ", "))
 }
 
+// PrintGrouped prints the items grouped by resource type with a header per
+// type, which contains the counts of the states. Filtered items are only
+// counted, if quiet is set.
+func PrintGrouped(w io.Writer, items []*Item, quiet bool) {
+	groups := map[string][]*Item{}
+	for _, item := range items {
+		groups[item.Type] = append(groups[item.Type], item)
+	}
+
+	resourceTypes := make([]string, 0, len(groups))
+	for resourceType := range groups {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		group := Queue(groups[resourceType])
+
+		ColorGroupHeader.Fprintf(w, "%s - %s - %d nukeable, %d filtered\n",
+			group[0].Region.Name, resourceType,
+			group.Count(ItemStateNew), group.Count(ItemStateFiltered))
+
+		for _, item := range group {
+			if item.State != ItemStateFiltered || !quiet {
+				item.Print()
+			}
+		}
+	}
+}
+
+// Log prints a line about the resource. The line is written at once, so the
+// lines of accounts, which are nuked at the same time, do not get mixed up.
 func Log(region *Region, resourceType string, r resources.Resource, c color.Color, msg string) {
-	ColorRegion.Printf("%s", region.Name)
-	fmt.Printf(" - ")
-	ColorResourceType.Print(resourceType)
-	fmt.Printf(" - ")
+	redactor := region.output().Redactor
+	parts := []string{region.Name, resourceType}
+	line := new(strings.Builder)
+	if region.Account != "" {
+		// The account is only part of the log file, since the output of the
+		// run prefixes every line with it.
+		parts = append([]string{region.Account}, parts...)
+	}
+
+	fmt.Fprintf(line, "%s - %s - ", ColorRegion.Sprint(region.Name), ColorResourceType.Sprint(resourceType))
 
 	rString, ok := r.(resources.LegacyStringer)
 	if ok {
-		ColorResourceID.Print(rString.String())
-		fmt.Printf(" - ")
+		id := redactor.String(rString.String())
+		parts = append(parts, id)
+		fmt.Fprintf(line, "%s - ", ColorResourceID.Sprint(id))
 	}
 
 	rProp, ok := r.(resources.ResourcePropertyGetter)
 	if ok {
-		ColorResourceProperties.Print(Sorted(rProp.Properties()))
-		fmt.Printf(" - ")
+		props := Sorted(redactor.Properties(rProp.Properties()))
+		parts = append(parts, props)
+		fmt.Fprintf(line, "%s - ", ColorResourceProperties.Sprint(props))
 	}
 
-	c.Printf("%s\n", msg)
+	msg = redactor.String(msg)
+	fmt.Fprintf(line, "%s\n", c.Sprint(msg))
+
+	region.progress.Clear()
+	fmt.Fprint(region.output().writer(), line.String())
+
+	if logFile != nil {
+		logFile.printResource(strings.Join(append(parts, msg), " - "))
+	}
 }
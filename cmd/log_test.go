@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := &prefixWriter{w: buf, prefix: "123 - "}
+
+	w.Write([]byte("first\nsecond "))
+	w.Write([]byte("line\n\n"))
+
+	want := "123 - first\n123 - second line\n123 - \n"
+	if buf.String() != want {
+		t.Errorf("Wrong output.\nWant: %q\nHave: %q", want, buf.String())
+	}
+}
+
+func TestOutputAccountPrefix(t *testing.T) {
+	buf := new(bytes.Buffer)
+	out := &Output{Writer: buf}
+	out.useAccountPrefix("123456789012")
+
+	region := &Region{Name: "eu-west-1", Account: "123456789012", Output: out}
+	Log(region, "EC2Instance", &stringerTestResource{id: "i-42"}, ReasonSuccess, "removed")
+
+	want := "123456789012 - eu-west-1 - EC2Instance - i-42 - [] - removed\n"
+	if buf.String() != want {
+		t.Errorf("Wrong resource line.\nWant: %q\nHave: %q", want, buf.String())
+	}
+
+	logs := new(bytes.Buffer)
+	logger := log.StandardLogger()
+	stdout := logger.Out
+	logger.SetOutput(logs)
+	defer logger.SetOutput(stdout)
+
+	out.Logger.Warnf("Failed to write the state file: %v", "denied")
+	if !strings.Contains(logs.String(), "123456789012 - Failed to write the state file: denied") {
+		t.Errorf("The log message is not prefixed with the account. Have: %s", logs.String())
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rebuy-de/aws-nuke/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// logFile receives all log messages and printed resources, if --log-file is
+// set. It is nil otherwise.
+var logFile *logFileHook
+
+// logFileHook writes every log entry to the log file, regardless of the
+// console verbosity.
+type logFileHook struct {
+	out       io.Writer
+	formatter log.Formatter
+}
+
+func (h *logFileHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *logFileHook) Fire(entry *log.Entry) error {
+	data, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.out.Write(data)
+	return err
+}
+
+// printResource writes a printed resource line to the log file, since the
+// resources are not printed with the logger.
+func (h *logFileHook) printResource(line string) {
+	entry := log.NewEntry(log.StandardLogger())
+	entry.Time = time.Now()
+	entry.Level = log.InfoLevel
+	entry.Message = line
+
+	err := h.Fire(entry)
+	if err != nil {
+		log.Warnf("Failed to write to the log file: %v", err)
+	}
+}
+
+// consoleHook writes the log entries up to the console verbosity. It replaces
+// the output of the logger, whose level has to be debug for the log file.
+type consoleHook struct {
+	out       io.Writer
+	level     log.Level
+	formatter log.Formatter
+}
+
+func (h *consoleHook) Levels() []log.Level {
+	return log.AllLevels[:h.level+1]
+}
+
+func (h *consoleHook) Fire(entry *log.Entry) error {
+	data, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.out.Write(data)
+	return err
+}
+
+// setupLogFile makes the logger write all debug messages to the rotated log
+// file, while the console only gets the messages of the given level.
+func setupLogFile(path string, maxSizeMB int, maxBackups int, console log.Level) error {
+	f, err := util.NewRotatingFile(path, int64(maxSizeMB)*1024*1024, maxBackups)
+	if err != nil {
+		return err
+	}
+
+	logger := log.StandardLogger()
+
+	logFile = &logFileHook{
+		out: f,
+		formatter: &log.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		},
+	}
+	logger.AddHook(logFile)
+	logger.AddHook(&consoleHook{
+		out:   logger.Out,
+		level: console,
+		formatter: &log.TextFormatter{
+			ForceColors:   !color.NoColor,
+			DisableColors: color.NoColor,
+		},
+	})
+
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(log.DebugLevel)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestLogFileHooks(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(new(bytes.Buffer))
+	logger.SetLevel(log.DebugLevel)
+
+	file := new(bytes.Buffer)
+	console := new(bytes.Buffer)
+	logger.AddHook(&logFileHook{out: file, formatter: &log.TextFormatter{DisableColors: true}})
+	logger.AddHook(&consoleHook{out: console, level: log.InfoLevel, formatter: &log.TextFormatter{DisableColors: true}})
+
+	logger.Debug("list request")
+	logger.Info("scan complete")
+
+	if !strings.Contains(file.String(), "list request") || !strings.Contains(file.String(), "scan complete") {
+		t.Errorf("The log file should contain all messages. Have: %s", file.String())
+	}
+	if strings.Contains(console.String(), "list request") {
+		t.Errorf("The console should not contain debug messages. Have: %s", console.String())
+	}
+	if !strings.Contains(console.String(), "scan complete") {
+		t.Errorf("The console should contain info messages. Have: %s", console.String())
+	}
+}
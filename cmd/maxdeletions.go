@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// exceededMaxDeletions returns a message for every resource type, of which
+// more resources would be removed than allowed by max-deletions.
+func (n *Nuke) exceededMaxDeletions() []string {
+	counts := map[string]int{}
+	for _, item := range n.items {
+		if item.State == ItemStateNew {
+			counts[item.Type]++
+		}
+	}
+
+	messages := []string{}
+	for resourceType, max := range n.Config.MaxDeletions {
+		if counts[resourceType] > max {
+			messages = append(messages, fmt.Sprintf(
+				"%d resources of type %s would be removed, but max-deletions is %d.",
+				counts[resourceType], resourceType, max))
+		}
+	}
+	sort.Strings(messages)
+
+	return messages
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestExceededMaxDeletions(t *testing.T) {
+	resource := &testResource{props: types.NewProperties()}
+	n := &Nuke{
+		Config: &config.Nuke{
+			MaxDeletions: map[string]int{"IAMRole": 1, "S3Bucket": 2},
+		},
+		items: Queue{
+			{Type: "IAMRole", State: ItemStateNew, Resource: resource},
+			{Type: "IAMRole", State: ItemStateNew, Resource: resource},
+			{Type: "IAMRole", State: ItemStateFiltered, Resource: resource},
+			{Type: "S3Bucket", State: ItemStateNew, Resource: resource},
+			{Type: "S3Bucket", State: ItemStateNew, Resource: resource},
+			{Type: "EC2Instance", State: ItemStateNew, Resource: resource},
+		},
+	}
+
+	have := n.exceededMaxDeletions()
+	want := "2 resources of type IAMRole would be removed, but max-deletions is 1."
+	if len(have) != 1 || have[0] != want {
+		t.Errorf("Wrong exceeded caps. Want: [%s]. Have: %v", want, have)
+	}
+}
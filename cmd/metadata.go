@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/spf13/pflag"
+)
+
+// secretFlags are the flags, whose values are never written to reports or
+// logs.
+var secretFlags = map[string]bool{
+	"secret-access-key": true,
+	"session-token":     true,
+	"mfa-token":         true,
+}
+
+// usedFlags returns the flags, which were set on the command line, as
+// --name=value. The values of secrets are redacted.
+func usedFlags(flags *pflag.FlagSet) []string {
+	result := []string{}
+	flags.Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if secretFlags[f.Name] {
+			value = "<redacted>"
+		}
+		result = append(result, fmt.Sprintf("--%s=%s", f.Name, value))
+	})
+	return result
+}
+
+// fileSHA256 returns the hex encoded SHA-256 checksum of the file.
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// metadata describes how the run was executed. It is part of every report.
+func (n *Nuke) metadata() *report.Metadata {
+	return &report.Metadata{
+		BuildHash:      BuildHash,
+		ConfigSHA256:   n.configSHA256,
+		ConfigRevision: n.Parameters.ConfigRevision,
+		CallerARN:      n.Account.ARN(),
+		Flags:          n.Parameters.Flags,
+	}
+}
+
+// printRunHeader prints the metadata at the start of the run, so the log can
+// be matched to the reports.
+func (n *Nuke) printRunHeader() {
+	w := n.output().writer()
+	fmt.Fprintf(w, "aws-nuke version %s - %s - %s\n", BuildVersion, BuildDate, BuildHash)
+
+	m := n.metadata()
+	config := fmt.Sprintf("%s (sha256 %s)", n.Parameters.ConfigPath, m.ConfigSHA256)
+	if m.ConfigRevision != "" {
+		config = fmt.Sprintf("%s (sha256 %s, revision %s)", n.Parameters.ConfigPath, m.ConfigSHA256, m.ConfigRevision)
+	}
+	fmt.Fprintf(w, "config: %s\n", config)
+	if m.CallerARN != "" {
+		fmt.Fprintf(w, "caller: %s\n", m.CallerARN)
+	}
+	fmt.Fprintf(w, "flags:  %s\n\n", strings.Join(m.Flags, " "))
+}
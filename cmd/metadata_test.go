@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestUsedFlags(t *testing.T) {
+	var (
+		config string
+		secret string
+		dryRun bool
+		quiet  bool
+	)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&config, "config", "", "")
+	flags.StringVar(&secret, "secret-access-key", "", "")
+	flags.BoolVar(&dryRun, "no-dry-run", false, "")
+	flags.BoolVar(&quiet, "quiet", false, "")
+
+	err := flags.Parse([]string{"--config", "nuke.yaml", "--secret-access-key", "s3cr3t", "--no-dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "--config=nuke.yaml --no-dry-run=true --secret-access-key=<redacted>"
+	have := strings.Join(usedFlags(flags), " ")
+	if have != want {
+		t.Errorf("Wrong flags. Want: %s. Have: %s", want, have)
+	}
+}
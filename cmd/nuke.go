@@ -1,24 +1,88 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"filippo.io/age"
 	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 	"github.com/rebuy-de/aws-nuke/resources"
-	"github.com/sirupsen/logrus"
 )
 
 type Nuke struct {
 	Parameters NukeParameters
 	Account    awsutil.Account
 	Config     *config.Nuke
+	Profile    config.RunProfile
+
+	// configSHA256 is the checksum of the config file, which is part of the
+	// run metadata.
+	configSHA256 string
 
 	ResourceTypes types.Collection
 
-	items Queue
+	// cloudControlTypes are the CloudFormation types of the account, which
+	// are listed via the Cloud Control API. They are only known to this run,
+	// since other accounts can configure different ones.
+	cloudControlTypes types.Collection
+
+	// out prints the resources of the run.
+	out *Output
+
+	items  Queue
+	status *StatusServer
+	events *EventWriter
+	sweep  *TagSweep
+
+	// progress shows the progress of --progress. It is nil, if it is
+	// disabled.
+	progress *Progress
+
+	// stacksPending is true, if CloudFormation stacks outside of the CDK
+	// bootstrap were still being removed at the start of the current pass
+	// over the queue.
+	stacksPending bool
+
+	// reportRecipients encrypt the reports, if set.
+	reportRecipients []age.Recipient
+
+	// plan is set by the apply command, which only removes the resources of
+	// the plan.
+	plan *Plan
+
+	// resume is the state of an interrupted run, which is continued.
+	resume *RunState
+
+	pause *PauseControl
+
+	// scpBlocked maps region/type to the reason, why an SCP blocks the
+	// removal. An empty region blocks the type in all regions.
+	scpBlocked map[string]string
+
+	// filters are the resolved filters of the account.
+	filters config.Filters
+
+	// own are the resources per type, which the run itself uses.
+	own map[string][]ownResource
+
+	// failure is set, if an error policy aborts the run.
+	failure error
+
+	// scanErrors are the resource types, which could not be listed. They
+	// are recorded by the listers of a region concurrently.
+	scanErrors     []report.ScanError
+	scanErrorsLock sync.Mutex
+
+	started       time.Time
+	scanCompleted time.Time
 }
 
 func NewNuke(params NukeParameters, account awsutil.Account) *Nuke {
@@ -39,10 +103,11 @@ func (n *Nuke) BuildBlueprint(includeFiltered bool, includeName bool) error {
 	}
 
 	if n.items.Count(ItemStateNew) == 0 {
-		fmt.Println("All resources filtered out.")
+		fmt.Fprintln(n.output().writer(), "All resources filtered out.")
 		return nil
 	}
 
+	redactor := n.output().Redactor
 	resourceMap := make(map[string][]*Item)
 	for _, item := range n.items {
 		n.Filter(item)
@@ -53,7 +118,7 @@ func (n *Nuke) BuildBlueprint(includeFiltered bool, includeName bool) error {
 	}
 
 	for k, v := range resourceMap {
-		fmt.Printf("%s:\n", k)
+		fmt.Fprintf(n.output().writer(), "%s:\n", k)
 		for _, item := range v {
 			rProp, propok := item.Resource.(resources.ResourcePropertyGetter)
 
@@ -72,18 +137,19 @@ func (n *Nuke) BuildBlueprint(includeFiltered bool, includeName bool) error {
 			}
 
 			if stringOk && (!hasProps || includeName) {
-				fmt.Printf("- \"%s\" %s\n", rString.String(), filteredStatus)
+				fmt.Fprintf(n.output().writer(), "- \"%s\" %s\n", redactor.String(rString.String()), filteredStatus)
 			}
 
 			if hasProps {
+				props = redactor.Properties(props)
 				for p := range props {
-					fmt.Printf("- property: \"%s\" %s\n", p, filteredStatus)
-					fmt.Printf("  value: \"%s\"\n", props[p])
+					fmt.Fprintf(n.output().writer(), "- property: \"%s\" %s\n", p, filteredStatus)
+					fmt.Fprintf(n.output().writer(), "  value: \"%s\"\n", props[p])
 				}
 			}
 
 			if !stringOk && !hasProps {
-				fmt.Printf("  # WARN: Cannot find properties or string definition of %v, string: (%v), props: (%v)\n", item, stringOk, propok)
+				fmt.Fprintf(n.output().writer(), "  # WARN: Cannot find properties or string definition of %v, string: (%v), props: (%v)\n", item, stringOk, propok)
 			}
 
 		}
@@ -92,57 +158,205 @@ func (n *Nuke) BuildBlueprint(includeFiltered bool, includeName bool) error {
 	return nil
 }
 
-func (n *Nuke) Run() error {
-	var err error
+func (n *Nuke) Run() (err error) {
 
 	if n.Parameters.ForceSleep < 3 {
 		return fmt.Errorf("Value for --force-sleep cannot be less than 3 seconds. This is for your own protection.")
 	}
 	forceSleep := time.Duration(n.Parameters.ForceSleep) * time.Second
+	n.started = time.Now()
+
+	n.printRunHeader()
+
+	// The pause is set by the command, if it handles the signals for
+	// multiple runs.
+	if n.pause == nil {
+		n.pause = NewPauseControl()
+		defer n.pause.HandleSignals()()
+	}
+
+	if n.Parameters.StatusAddr != "" {
+		n.status = NewStatusServer(n.pause, n.Parameters.StatusControlToken)
+		err = n.status.Start(n.Parameters.StatusAddr)
+		if err != nil {
+			return err
+		}
+		defer n.status.Close()
+	}
+
+	if n.Parameters.Progress {
+		n.progress = NewProgress(os.Stderr, isTerminal(os.Stderr))
+		defer n.progress.Clear()
+		defer addLogHook(n.progress)()
+	}
+
+	publish := n.Parameters.PublishTopic != "" || n.Parameters.PublishQueue != ""
+	if n.Parameters.EventsFile != "" || publish {
+		n.events, err = NewEventWriter(n.Parameters.EventsFile)
+		if err != nil {
+			return err
+		}
+		defer n.events.Close()
+	}
+
+	if publish {
+		publisher, err := NewResultPublisher(&n.Account, n.Parameters.PublishTopic, n.Parameters.PublishQueue)
+		if err != nil {
+			return err
+		}
+		n.events.AddSink(newAsyncSink(publisher))
+		n.protectPublisher()
+	}
 
-	fmt.Printf("aws-nuke version %s - %s - %s\n\n", BuildVersion, BuildDate, BuildHash)
+	err = n.protectEvidence()
+	if err != nil {
+		return err
+	}
+	n.protectReportStorage()
+
+	err = n.prepareReportKeys()
+	if err != nil {
+		return err
+	}
+
+	err = checkExpectedAccount(n.Parameters.ExpectedAccountIDs, n.Account.ID())
+	if err != nil {
+		return err
+	}
+
+	if !n.Parameters.AllowManagementAccount {
+		err = checkManagementAccount(n.Account.ID(), n.Account.ManagementAccountID)
+		if err != nil {
+			return err
+		}
+	}
 
 	err = n.Config.ValidateAccount(n.Account.ID(), n.Account.Aliases())
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Do you really want to nuke the account with "+
+	if n.Parameters.NoDryRun && !n.Config.InRunWindow(time.Now()) {
+		if !n.Parameters.OverrideWindow {
+			return fmt.Errorf("Runs with --no-dry-run are only allowed within the allowed-run-windows of the config (%s). "+
+				"Specify --override-window to run anyway.", strings.Join(n.Config.AllowedRunWindows, ", "))
+		}
+		n.output().Logger.Warnf("Running outside of the allowed-run-windows, since --override-window is specified.")
+	}
+
+	if n.plan != nil && n.plan.AccountID != n.Account.ID() {
+		return fmt.Errorf("The plan was created for the account %s, but the current account is %s.",
+			n.plan.AccountID, n.Account.ID())
+	}
+
+	if n.Parameters.Resume {
+		n.resume, err = LoadRunState(n.Parameters.StateFile)
+		if err != nil {
+			return err
+		}
+
+		if n.resume == nil {
+			n.output().Logger.Infof("The state file %s does not exist. Starting a new run.", n.Parameters.StateFile)
+		} else if n.resume.AccountID != n.Account.ID() {
+			return fmt.Errorf("The state file was written for the account %s, but the current account is %s.",
+				n.resume.AccountID, n.Account.ID())
+		}
+	}
+
+	if n.Parameters.Preflight {
+		err = n.Preflight()
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(n.output().writer(), "Do you really want to nuke the account with "+
 		"the ID %s and the alias '%s'?\n", n.Account.ID(), n.Account.Alias())
 	if n.Parameters.Force {
-		fmt.Printf("Waiting %v before continuing.\n", forceSleep)
+		fmt.Fprintf(n.output().writer(), "Waiting %v before continuing.\n", forceSleep)
 		time.Sleep(forceSleep)
 	} else {
-		fmt.Printf("Do you want to continue? Enter account alias to continue.\n")
-		err = Prompt(n.Account.Alias())
+		fmt.Fprintf(n.output().writer(), "Do you want to continue? Enter account alias to continue.\n")
+		err = Prompt(n.output().writer(), n.Account.Alias())
 		if err != nil {
 			return err
 		}
 	}
 
+	n.checkServiceControlPolicies(n.resolveResourceTypes())
+
 	err = n.Scan()
 	if err != nil {
 		return err
 	}
+	n.scanCompleted = time.Now()
+	n.saveState()
+
+	defer func() {
+		printThrottling(n.output().writer(), awsutil.Throttling.Counts())
+	}()
+
+	defer func() {
+		rerr := n.WriteReport()
+		if rerr != nil {
+			n.output().Logger.Errorf("Failed to write report: %v", rerr)
+			if err == nil {
+				err = rerr
+			}
+		}
+	}()
+
+	if n.Parameters.PlanFile != "" {
+		err = n.WritePlan(n.Parameters.PlanFile)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(n.output().writer(), "The plan with %d resources was written to %s. Run 'aws-nuke apply %s' to remove them.\n",
+			n.items.Count(ItemStateNew), n.Parameters.PlanFile, n.Parameters.PlanFile)
+		return nil
+	}
+
+	if n.plan != nil {
+		warnMissingPlanResources(n.plan, n.output())
+	}
 
 	if n.items.Count(ItemStateNew) == 0 {
-		fmt.Println("No resource to delete.")
+		fmt.Fprintln(n.output().writer(), "No resource to delete.")
 		return nil
 	}
 
+	exceeded := n.exceededMaxDeletions()
+	for _, msg := range exceeded {
+		n.output().Logger.Warnf("%s", msg)
+	}
+
 	if !n.Parameters.NoDryRun {
-		fmt.Println("The above resources would be deleted with the supplied configuration. Provide --no-dry-run to actually destroy resources.")
+		fmt.Fprintln(n.output().writer(), "The above resources would be deleted with the supplied configuration. Provide --no-dry-run to actually destroy resources.")
 		return nil
 	}
 
-	fmt.Printf("Do you really want to nuke these resources on the account with "+
+	if len(exceeded) > 0 {
+		if n.Parameters.Force {
+			return fmt.Errorf("Aborting, since max-deletions is exceeded for %d resource types.", len(exceeded))
+		}
+
+		fmt.Fprintf(n.output().writer(), "The max-deletions of the config are exceeded. Do you want to continue anyway? "+
+			"Enter account alias to continue.\n")
+		err = Prompt(n.output().writer(), n.Account.Alias())
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(n.output().writer(), "Do you really want to nuke these resources on the account with "+
 		"the ID %s and the alias '%s'?\n", n.Account.ID(), n.Account.Alias())
 	if n.Parameters.Force {
-		fmt.Printf("Waiting %v before continuing.\n", forceSleep)
+		fmt.Fprintf(n.output().writer(), "Waiting %v before continuing.\n", forceSleep)
 		time.Sleep(forceSleep)
 	} else {
-		fmt.Printf("Do you want to continue? Enter account alias to continue.\n")
-		err = Prompt(n.Account.Alias())
+		fmt.Fprintf(n.output().writer(), "Do you want to continue? Enter account alias to continue.\n")
+		err = Prompt(n.output().writer(), n.Account.Alias())
 		if err != nil {
 			return err
 		}
@@ -150,14 +364,23 @@ func (n *Nuke) Run() error {
 
 	failCount := 0
 	waitingCount := 0
+	iteration := 0
+	n.progress.StartRemoval(n.items)
 
 	for {
+		iteration++
 		n.HandleQueue()
+		n.publishStatus(PhaseRemoving, iteration)
+		n.saveState()
+
+		if n.failure != nil {
+			return n.failure
+		}
 
 		if n.items.Count(ItemStatePending, ItemStateWaiting, ItemStateNew) == 0 && n.items.Count(ItemStateFailed) > 0 {
 			if failCount >= 2 {
-				logrus.Errorf("There are resources in failed state, but none are ready for deletion, anymore.")
-				fmt.Println()
+				n.output().Logger.Errorf("There are resources in failed state, but none are ready for deletion, anymore.")
+				fmt.Fprintln(n.output().writer())
 
 				for _, item := range n.items {
 					if item.State != ItemStateFailed {
@@ -165,7 +388,7 @@ func (n *Nuke) Run() error {
 					}
 
 					item.Print()
-					logrus.Error(item.Reason)
+					n.output().Logger.Errorf("%s", item.Reason)
 				}
 
 				return fmt.Errorf("failed")
@@ -190,35 +413,117 @@ func (n *Nuke) Run() error {
 		time.Sleep(5 * time.Second)
 	}
 
-	fmt.Printf("Nuke complete: %d failed, %d skipped, %d finished.\n\n",
+	n.publishStatus(PhaseDone, iteration)
+
+	fmt.Fprintf(n.output().writer(), "Nuke complete: %d failed, %d skipped, %d finished.\n\n",
 		n.items.Count(ItemStateFailed), n.items.Count(ItemStateFiltered), n.items.Count(ItemStateFinished))
 
 	return nil
 }
 
-func (n *Nuke) Scan() error {
+// checkExpectedAccount fails, if expected accounts are specified and the
+// account is none of them.
+func checkExpectedAccount(expected []string, accountID string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	for _, id := range expected {
+		if id == accountID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The credentials belong to the account %s, but --expected-account-id is %s. "+
+		"Aborting, since the credentials point to an unexpected account.",
+		accountID, strings.Join(expected, ", "))
+}
+
+// checkManagementAccount fails, if the account is the management account of
+// its organization or if this cannot be determined.
+func checkManagementAccount(accountID string, managementAccountID func() (string, error)) error {
+	management, err := managementAccountID()
+	if err != nil {
+		return fmt.Errorf("Failed to check whether the account %s is the management account of an organization: %v. "+
+			"Specify --allow-management-account to run without this check.", accountID, err)
+	}
+
+	if management == accountID {
+		return fmt.Errorf("The account %s is the management account of its organization. "+
+			"Removing its resources can break the whole organization. "+
+			"Specify --allow-management-account, if this is really intended.", accountID)
+	}
+
+	return nil
+}
+
+// resolveResourceTypes returns the resource types of the run, which are
+// selected by the flags, the config, the account config and the run profile.
+func (n *Nuke) resolveResourceTypes() types.Collection {
 	accountConfig := n.Config.Accounts[n.Account.ID()]
 
+	excludes := []types.Collection{
+		n.Parameters.Excludes,
+		n.Config.ResourceTypes.Excludes,
+		accountConfig.ResourceTypes.Excludes,
+		n.Profile.ResourceTypes.Excludes,
+	}
+	if !n.Config.FeatureFlags.EnableCloudControlFallback {
+		excludes = append(excludes, types.Collection{resources.CLOUDCONTROL_RESOURCE_TYPE})
+	}
+
 	resourceTypes := ResolveResourceTypes(
-		resources.GetListerNames(),
+		types.Collection(resources.GetListerNames()).Union(n.cloudControlTypes),
 		[]types.Collection{
 			n.Parameters.Targets,
 			n.Config.ResourceTypes.Targets,
 			accountConfig.ResourceTypes.Targets,
+			n.Profile.ResourceTypes.Targets,
 		},
-		[]types.Collection{
-			n.Parameters.Excludes,
-			n.Config.ResourceTypes.Excludes,
-			accountConfig.ResourceTypes.Excludes,
-		},
+		excludes,
 	)
+	if n.plan != nil {
+		resourceTypes = resourceTypes.Intersect(n.plan.ResourceTypes())
+	}
+
+	return resourceTypes
+}
+
+func (n *Nuke) Scan() error {
+	resourceTypes := n.resolveResourceTypes()
 
 	queue := make(Queue, 0)
 
-	for _, regionName := range n.Config.Regions {
-		region := NewRegion(regionName, n.Account.ResourceTypeToServiceType, n.Account.NewSession)
+	var owners *OwnerLookup
+	if n.Parameters.LookupOwner {
+		owners = NewOwnerLookup(&n.Account)
+	}
 
-		items := Scan(region, resourceTypes)
+	var sampler *resourceSampler
+	if n.Parameters.Sample > 0 {
+		sampler = newResourceSampler(n.Parameters.Sample)
+	}
+
+	regions := n.Config.AccountRegions(n.Account.ID())
+	n.progress.StartScan()
+
+	for i, regionName := range regions {
+		region := n.newRegion(regionName)
+		region.Account = n.Parameters.AccountID
+
+		regionTypes := resourceTypes
+		if n.sweep != nil {
+			regionTypes = n.sweep.Prefilter(&n.Account, regionName, resourceTypes)
+		}
+		if n.resume != nil {
+			regionTypes = regionTypes.Intersect(n.resume.PendingTypes(regionName))
+		}
+		if n.plan != nil {
+			regionTypes = regionTypes.Intersect(n.plan.RegionResourceTypes(regionName))
+		}
+
+		regionItems := []*Item{}
+		items, errs := Scan(region, regionTypes, n.Profile.ParallelQueries, n.errorAction, n.recordScanError)
 		for item := range items {
 			ffGetter, ok := item.Resource.(resources.FeatureFlagGetter)
 			if ok {
@@ -231,20 +536,81 @@ func (n *Nuke) Scan() error {
 				return err
 			}
 
-			if item.State != ItemStateFiltered || !n.Parameters.Quiet {
-				item.Print()
+			if sampler != nil {
+				sampler.Apply(item)
+			}
+
+			if owners != nil && item.State == ItemStateNew {
+				owners.Annotate(item)
 			}
+
+			if n.Parameters.DeepDryRun && !n.Parameters.NoDryRun && item.State == ItemStateNew {
+				item.CheckRemove()
+			}
+
+			n.emitEvent(item, true)
+			regionItems = append(regionItems, item)
+			n.progress.Scan(regionName, i, len(regions), len(queue))
 		}
+
+		if err := <-errs; err != nil {
+			return err
+		}
+
+		n.progress.Clear()
+		PrintGrouped(n.output().writer(), regionItems, n.Parameters.Quiet)
+		n.progress.Scan(regionName, i+1, len(regions), len(queue))
+
+		n.items = queue
+		n.publishStatus(PhaseScanning, 0)
 	}
 
-	fmt.Printf("Scan complete: %d total, %d nukeable, %d filtered.\n\n",
+	n.progress.Clear()
+	fmt.Fprintf(n.output().writer(), "Scan complete: %d total, %d nukeable, %d filtered.\n\n",
 		queue.CountTotal(), queue.Count(ItemStateNew), queue.Count(ItemStateFiltered))
 
+	warnEndOfLife(n.output().Logger, queue)
+	printScanErrors(n.output().Logger, n.ScanErrors())
+
 	n.items = queue
 
 	return nil
 }
 
+// newRegion returns the region of the account, which also resolves the Cloud
+// Control types of the account.
+func (n *Nuke) newRegion(name string) *Region {
+	region := NewRegion(name, n.Account.ResourceTypeToServiceType, n.Account.NewSession)
+	region.Listers = n.lister
+	region.Output = n.output()
+	region.progress = n.progress
+	return region
+}
+
+func (n *Nuke) output() *Output {
+	if n.out == nil {
+		return defaultOutput
+	}
+	return n.out
+}
+
+// lister returns the lister of the resource type, which might be a Cloud
+// Control type of the account.
+func (n *Nuke) lister(resourceType string) resources.ResourceLister {
+	lister := resources.GetLister(resourceType)
+	if lister != nil {
+		return lister
+	}
+
+	for _, t := range n.cloudControlTypes {
+		if t == resourceType {
+			return resources.CloudControlLister(resourceType)
+		}
+	}
+
+	return nil
+}
+
 func (n *Nuke) Filter(item *Item) error {
 
 	checker, ok := item.Resource.(resources.Filter)
@@ -261,44 +627,114 @@ func (n *Nuke) Filter(item *Item) error {
 		}
 	}
 
-	accountFilters, err := n.Config.Filters(n.Account.ID())
-	if err != nil {
-		return err
+	if reason := n.ownResourceReason(item); reason != "" {
+		item.State = ItemStateFiltered
+		item.Reason = reason
+		return nil
 	}
 
-	itemFilters, ok := accountFilters[item.Type]
-	if !ok {
+	if n.isListOnly(item.Type) {
+		item.State = ItemStateFiltered
+		item.Reason = "list-only resource type"
 		return nil
 	}
 
-	for _, filter := range itemFilters {
-		prop, err := item.GetProperty(filter.Property)
-
-		match, err := filter.Match(prop)
-		if err != nil {
-			return err
-		}
+	if n.plan != nil && !n.plan.Contains(item) {
+		item.State = ItemStateFiltered
+		item.Reason = "not part of the plan"
+		return nil
+	}
 
-		if IsTrue(filter.Invert) {
-			match = !match
-		}
+	if n.sweep != nil && !n.sweep.Match(item) {
+		item.State = ItemStateFiltered
+		item.Reason = fmt.Sprintf("not tagged with %s", n.sweep)
+		return nil
+	}
 
-		if match {
+	if n.Parameters.OnlyIdle > 0 {
+		reason := n.idleReason(item)
+		if reason != "" {
 			item.State = ItemStateFiltered
-			item.Reason = "filtered by config"
+			item.Reason = reason
 			return nil
 		}
 	}
 
+	accountFilters, err := n.accountFilters()
+	if err != nil {
+		return err
+	}
+
+	match, err := item.MatchesAny(accountFilters[item.Type])
+	if errors.As(err, &ErrUnknownProperty{}) {
+		item.State = ItemStateFiltered
+		item.Reason = err.Error()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if match {
+		item.State = ItemStateFiltered
+		item.Reason = "filtered by config"
+		return nil
+	}
+
+	if reason := n.scpReason(item); reason != "" {
+		item.State = ItemStateFiltered
+		item.Reason = reason
+	}
+
 	return nil
 }
 
+// accountFilters returns the filters of the account and its presets. They are
+// only resolved once, since they are needed for every item.
+func (n *Nuke) accountFilters() (config.Filters, error) {
+	if n.filters != nil {
+		return n.filters, nil
+	}
+
+	filters, err := n.Config.Filters(n.Account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	n.filters = filters
+	return filters, nil
+}
+
+// isListOnly returns true, if the resource type is configured as list-only
+// globally, for the account or in the run profile.
+func (n *Nuke) isListOnly(resourceType string) bool {
+	accountConfig := n.Config.Accounts[n.Account.ID()]
+
+	listOnly := n.Config.ResourceTypes.ListOnly.
+		Union(accountConfig.ResourceTypes.ListOnly).
+		Union(n.Profile.ResourceTypes.ListOnly)
+
+	for _, t := range listOnly {
+		if t == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (n *Nuke) HandleQueue() {
 	listCache := make(map[string]map[string][]resources.Resource)
+	n.prepareDeferrals()
 
 	for _, item := range n.items {
+		n.waitIfPaused()
+
 		switch item.State {
 		case ItemStateNew:
+			if n.deferRemoval(item) {
+				continue
+			}
 			n.HandleRemove(item)
 			item.Print()
 		case ItemStateFailed:
@@ -311,27 +747,36 @@ func (n *Nuke) HandleQueue() {
 			item.Print()
 		case ItemStateWaiting:
 			n.HandleWait(item, listCache)
-			item.Print()
+			item.PrintChanged()
 		}
-
+		n.emitEvent(item, false)
+		n.progress.Remove(n.items)
 	}
 
-	fmt.Println()
-	fmt.Printf("Removal requested: %d waiting, %d failed, %d skipped, %d finished\n\n",
+	n.progress.Clear()
+	fmt.Fprintln(n.output().writer())
+	fmt.Fprintf(n.output().writer(), "Removal requested: %d waiting, %d failed, %d skipped, %d finished\n\n",
 		n.items.Count(ItemStateWaiting, ItemStatePending), n.items.Count(ItemStateFailed),
 		n.items.Count(ItemStateFiltered), n.items.Count(ItemStateFinished))
+	n.progress.Remove(n.items)
 }
 
 func (n *Nuke) HandleRemove(item *Item) {
+	if reason := n.scpReason(item); reason != "" {
+		item.State = ItemStateFiltered
+		item.Reason = reason
+		return
+	}
+
 	err := item.Resource.Remove()
 	if err != nil {
-		item.State = ItemStateFailed
-		item.Reason = err.Error()
+		n.handleError(item, err)
 		return
 	}
 
 	item.State = ItemStatePending
 	item.Reason = ""
+	n.emitEvent(item, false)
 }
 
 func (n *Nuke) HandleWait(item *Item, cache map[string]map[string][]resources.Resource) {
@@ -345,8 +790,7 @@ func (n *Nuke) HandleWait(item *Item, cache map[string]map[string][]resources.Re
 	if !ok {
 		left, err = item.List()
 		if err != nil {
-			item.State = ItemStateFailed
-			item.Reason = err.Error()
+			n.handleError(item, err)
 			return
 		}
 		cache[region][item.Type] = left
@@ -369,3 +813,26 @@ func (n *Nuke) HandleWait(item *Item, cache map[string]map[string][]resources.Re
 	item.State = ItemStateFinished
 	item.Reason = ""
 }
+
+// warnEndOfLife summarizes the resources of deprecated services, since their
+// removal might need manual work, once the APIs are shut down.
+func warnEndOfLife(logger *Logger, queue Queue) {
+	counts := map[string]int{}
+	for _, item := range queue {
+		if _, ok := resources.GetEndOfLife(item.Type); ok {
+			counts[item.Type]++
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for resourceType := range counts {
+		names = append(names, resourceType)
+	}
+	sort.Strings(names)
+
+	for _, resourceType := range names {
+		eol, _ := resources.GetEndOfLife(resourceType)
+		logger.Warnf("found %d %s resources of a deprecated service: %s",
+			counts[resourceType], resourceType, eol)
+	}
+}
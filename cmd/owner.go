@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+)
+
+// ownerLookupInterval is the minimum time between two LookupEvents requests
+// in the same region, since AWS limits them to two per second.
+const ownerLookupInterval = 500 * time.Millisecond
+
+// creatingEventPrefixes are the prefixes of CloudTrail event names, which are
+// considered to be the creation of a resource.
+var creatingEventPrefixes = []string{
+	"Allocate",
+	"Copy",
+	"Create",
+	"Import",
+	"Put",
+	"Register",
+	"Request",
+	"Run",
+}
+
+// OwnerLookup resolves the principal that created a resource by searching the
+// CloudTrail event history of the region the resource lives in.
+type OwnerLookup struct {
+	account *awsutil.Account
+
+	// newClient creates the CloudTrail client of a region. It is replaced in
+	// tests.
+	newClient func(region string) (cloudtrailiface.CloudTrailAPI, error)
+	interval  time.Duration
+
+	clients  map[string]cloudtrailiface.CloudTrailAPI
+	requests map[string]time.Time
+	cache    map[string]string
+}
+
+func NewOwnerLookup(account *awsutil.Account) *OwnerLookup {
+	o := &OwnerLookup{
+		account:  account,
+		interval: ownerLookupInterval,
+		clients:  make(map[string]cloudtrailiface.CloudTrailAPI),
+		requests: make(map[string]time.Time),
+		cache:    make(map[string]string),
+	}
+	o.newClient = o.sessionClient
+	return o
+}
+
+// Annotate sets the owner of the item. Lookup errors are only logged, since
+// the owner is purely informational. Resources with the same name in the same
+// region are only looked up once.
+func (o *OwnerLookup) Annotate(item *Item) {
+	id := item.Identifier()
+	if id == "" {
+		return
+	}
+
+	region := item.Region.Name
+	if region == awsutil.GlobalRegionID {
+		// Events of global services are recorded in the default region.
+		region = o.account.DefaultRegionID()
+	}
+
+	key := fmt.Sprintf("%s/%s", region, id)
+	owner, ok := o.cache[key]
+	if !ok {
+		var err error
+		owner, err = o.lookup(region, id)
+		if _, ok := err.(awsutil.ErrSkipRequest); ok {
+			item.Region.output().Logger.Debugf("not looking up owner of %s - %s: %v", item.Type, id, err)
+		} else if err != nil {
+			item.Region.output().Logger.Warnf("failed to look up owner of %s - %s: %v", item.Type, id, err)
+		}
+		o.cache[key] = owner
+	}
+
+	item.Owner = owner
+}
+
+func (o *OwnerLookup) lookup(region, id string) (string, error) {
+	client, err := o.client(region)
+	if err != nil {
+		return "", err
+	}
+
+	params := &cloudtrail.LookupEventsInput{
+		LookupAttributes: []*cloudtrail.LookupAttribute{{
+			AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyResourceName),
+			AttributeValue: aws.String(id),
+		}},
+	}
+
+	// Events are returned with the latest event first, therefore the last
+	// matching event is the creation of the resource.
+	var owner string
+	for {
+		o.wait(region)
+		page, err := client.LookupEvents(params)
+		if err != nil {
+			return "", err
+		}
+
+		for _, event := range page.Events {
+			if event.Username == nil || !isCreatingEvent(aws.StringValue(event.EventName)) {
+				continue
+			}
+			owner = *event.Username
+		}
+
+		if page.NextToken == nil {
+			return owner, nil
+		}
+		params.NextToken = page.NextToken
+	}
+}
+
+// wait blocks until the next request to CloudTrail in the region does not
+// exceed its rate limit.
+func (o *OwnerLookup) wait(region string) {
+	last, ok := o.requests[region]
+	if ok {
+		time.Sleep(o.interval - time.Since(last))
+	}
+	o.requests[region] = time.Now()
+}
+
+func (o *OwnerLookup) client(region string) (cloudtrailiface.CloudTrailAPI, error) {
+	client, ok := o.clients[region]
+	if ok {
+		return client, nil
+	}
+
+	client, err := o.newClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	o.clients[region] = client
+	return client, nil
+}
+
+func (o *OwnerLookup) sessionClient(region string) (cloudtrailiface.CloudTrailAPI, error) {
+	svcType := o.account.ResourceTypeToServiceType(region, "CloudTrailTrail")
+	if svcType == "" {
+		return nil, awsutil.ErrSkipRequest(fmt.Sprintf(
+			"CloudTrail is not available in region '%s'", region))
+	}
+
+	sess, err := o.account.NewSession(region, svcType)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudtrail.New(sess), nil
+}
+
+func isCreatingEvent(name string) bool {
+	for _, prefix := range creatingEventPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+)
+
+type ownerTestCloudTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	pages map[string][]*cloudtrail.LookupEventsOutput
+	err   error
+
+	requests []time.Time
+}
+
+func (c *ownerTestCloudTrail) LookupEvents(input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	c.requests = append(c.requests, time.Now())
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	pages := c.pages[aws.StringValue(input.LookupAttributes[0].AttributeValue)]
+	if len(pages) == 0 {
+		return &cloudtrail.LookupEventsOutput{}, nil
+	}
+
+	page := 0
+	fmt.Sscan(aws.StringValue(input.NextToken), &page)
+	return pages[page], nil
+}
+
+func ownerTestEvent(name, user string) *cloudtrail.Event {
+	return &cloudtrail.Event{EventName: aws.String(name), Username: aws.String(user)}
+}
+
+func newOwnerTestLookup(client cloudtrailiface.CloudTrailAPI) *OwnerLookup {
+	o := NewOwnerLookup(nil)
+	o.interval = 0
+	o.newClient = func(region string) (cloudtrailiface.CloudTrailAPI, error) {
+		return client, nil
+	}
+	return o
+}
+
+func TestOwnerLookupAnnotate(t *testing.T) {
+	cases := []struct {
+		name  string
+		pages []*cloudtrail.LookupEventsOutput
+		err   error
+		want  string
+	}{
+		{
+			name: "created",
+			pages: []*cloudtrail.LookupEventsOutput{{Events: []*cloudtrail.Event{
+				ownerTestEvent("StopInstances", "ops"),
+				ownerTestEvent("RunInstances", "jdoe"),
+			}}},
+			want: "jdoe",
+		},
+		{
+			name: "paged",
+			pages: []*cloudtrail.LookupEventsOutput{
+				{Events: []*cloudtrail.Event{ownerTestEvent("RunInstances", "jdoe")}, NextToken: aws.String("1")},
+				{Events: []*cloudtrail.Event{ownerTestEvent("DescribeInstances", "audit")}},
+			},
+			want: "jdoe",
+		},
+		{
+			name: "no creation",
+			pages: []*cloudtrail.LookupEventsOutput{{Events: []*cloudtrail.Event{
+				ownerTestEvent("TerminateInstances", "ops"),
+			}}},
+			want: "",
+		},
+		{
+			name: "access denied",
+			err:  fmt.Errorf("AccessDeniedException"),
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &ownerTestCloudTrail{
+				pages: map[string][]*cloudtrail.LookupEventsOutput{"i-42": tc.pages},
+				err:   tc.err,
+			}
+			item := &Item{
+				Region:   &Region{Name: "eu-west-1"},
+				Type:     "EC2Instance",
+				Resource: &stringerTestResource{id: "i-42"},
+			}
+
+			newOwnerTestLookup(client).Annotate(item)
+			if item.Owner != tc.want {
+				t.Errorf("Wrong owner. Want: %#v. Have: %#v", tc.want, item.Owner)
+			}
+		})
+	}
+}
+
+func TestOwnerLookupCache(t *testing.T) {
+	client := &ownerTestCloudTrail{err: fmt.Errorf("AccessDeniedException")}
+	o := newOwnerTestLookup(client)
+
+	for i := 0; i < 3; i++ {
+		o.Annotate(&Item{
+			Region:   &Region{Name: "eu-west-1"},
+			Type:     "EC2Instance",
+			Resource: &stringerTestResource{id: "i-42"},
+		})
+	}
+
+	if len(client.requests) != 1 {
+		t.Errorf("The same resource was looked up %d times.", len(client.requests))
+	}
+}
+
+func TestOwnerLookupRateLimit(t *testing.T) {
+	client := &ownerTestCloudTrail{}
+	o := newOwnerTestLookup(client)
+	o.interval = 20 * time.Millisecond
+
+	for _, id := range []string{"i-1", "i-2", "i-3"} {
+		o.Annotate(&Item{
+			Region:   &Region{Name: "eu-west-1"},
+			Type:     "EC2Instance",
+			Resource: &stringerTestResource{id: id},
+		})
+	}
+
+	for i := 1; i < len(client.requests); i++ {
+		gap := client.requests[i].Sub(client.requests[i-1])
+		if gap < o.interval {
+			t.Errorf("The requests %d and %d are only %v apart.", i-1, i, gap)
+		}
+	}
+}
@@ -2,12 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
 )
 
 type NukeParameters struct {
 	ConfigPath string
 
+	// ConfigRevision is the commit of the repository containing the config.
+	// It is only recorded in the run metadata.
+	ConfigRevision string
+
+	// Flags are the command line flags which were set. They are recorded in
+	// the run metadata.
+	Flags []string
+
 	Targets  []string
 	Excludes []string
 
@@ -15,14 +27,155 @@ type NukeParameters struct {
 	Force      bool
 	ForceSleep int
 	Quiet      bool
+	Show       []string
 
 	MaxWaitRetries int
+
+	// OverrideWindow allows runs outside of the allowed-run-windows.
+	OverrideWindow bool
+
+	RunProfile string
+
+	// ExpectedAccountIDs abort the run, if the credentials belong to none of
+	// these accounts.
+	ExpectedAccountIDs []string
+
+	AllowManagementAccount bool
+
+	// AllAccounts runs aws-nuke for every account of the config and
+	// OrgDiscover for the discovered accounts of the organization. AccountID
+	// is the account of a single of these runs.
+	AllAccounts bool
+	OrgDiscover bool
+	AccountID   string
+
+	// AccountConcurrency is the number of accounts of --all-accounts or
+	// --org-discover, which are nuked at the same time. 0 means 1.
+	AccountConcurrency int
+
+	SweepByTag string
+
+	LookupOwner bool
+
+	DeepDryRun bool
+
+	Preflight bool
+
+	OnlyIdle time.Duration
+
+	Sample int
+
+	Output     string
+	OutputFile string
+	ReportHTML string
+
+	Summary     string
+	SummaryFile string
+
+	EvidenceURI string
+
+	EncryptReportKMSKey     string
+	EncryptReportRecipients string
+
+	StateFile string
+	Resume    bool
+
+	// PlanFile is set by the plan command. Instead of removing resources,
+	// the run writes the plan to this file.
+	PlanFile string
+
+	StatusAddr string
+
+	// StatusControlToken enables POST /pause and POST /resume of the status
+	// API, which require it as bearer token.
+	StatusControlToken string
+
+	EventsFile string
+	Progress   bool
+
+	PublishTopic string
+	PublishQueue string
 }
 
+var accountIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+var configRevisionPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+
 func (p *NukeParameters) Validate() error {
 	if strings.TrimSpace(p.ConfigPath) == "" {
 		return fmt.Errorf("You have to specify the --config flag.\n")
 	}
 
+	if p.Output != "" && !report.Supports(p.Output) {
+		return fmt.Errorf("Unsupported value '%s' for --output. Supported formats are: %s.\n",
+			p.Output, strings.Join(report.Formats(), ", "))
+	}
+
+	if p.Summary != "" && p.Summary != "markdown" {
+		return fmt.Errorf("Unsupported value '%s' for --summary. Supported formats are: markdown.\n", p.Summary)
+	}
+
+	if p.EvidenceURI != "" {
+		_, _, err := parseS3URI(p.EvidenceURI)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range p.ExpectedAccountIDs {
+		if !accountIDPattern.MatchString(id) {
+			return fmt.Errorf("The value '%s' of --expected-account-id is not a 12 digit account ID.\n", id)
+		}
+	}
+
+	if p.ConfigRevision != "" && !configRevisionPattern.MatchString(p.ConfigRevision) {
+		return fmt.Errorf("The value '%s' of --config-revision is not a git commit SHA.\n", p.ConfigRevision)
+	}
+
+	if p.AccountConcurrency < 0 {
+		return fmt.Errorf("The value of --account-concurrency must not be negative.\n")
+	}
+
+	if p.AccountConcurrency > 1 {
+		if !p.Force {
+			return fmt.Errorf("You have to specify --force to use --account-concurrency, " +
+				"since the confirmations of concurrent runs cannot be answered.\n")
+		}
+		if p.Progress || p.StatusAddr != "" {
+			return fmt.Errorf("The flags --progress and --status-addr cannot be used with --account-concurrency.\n")
+		}
+		if flags := p.stdoutFlags(); len(flags) > 0 {
+			return fmt.Errorf("The flags %s cannot write to stdout with --account-concurrency, "+
+				"since the outputs of concurrent runs would be mixed up. Specify a file instead.\n",
+				strings.Join(flags, ", "))
+		}
+	}
+
+	if p.StatusControlToken != "" && p.StatusAddr == "" {
+		return fmt.Errorf("You have to specify the --status-addr flag to use --status-control-token.\n")
+	}
+
+	if p.Resume && p.StateFile == "" {
+		return fmt.Errorf("You have to specify the --state flag to use --resume.\n")
+	}
+
+	if p.Sample < 0 {
+		return fmt.Errorf("The value of --sample must not be negative.\n")
+	}
+
+	if p.OnlyIdle < 0 {
+		return fmt.Errorf("The value of --only-idle must not be negative.\n")
+	}
+
+	_, err := ParseTagSweep(p.SweepByTag)
+	if err != nil {
+		return err
+	}
+
+	_, err = ParseShowStates(p.Show)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExpectedAccountIDs(t *testing.T) {
+	params := NukeParameters{
+		ConfigPath:         "config.yaml",
+		ExpectedAccountIDs: []string{"111111111111", "222222222222"},
+	}
+	err := params.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params.ExpectedAccountIDs = append(params.ExpectedAccountIDs, "sandbox")
+	if params.Validate() == nil {
+		t.Errorf("Expected an error for an invalid account ID.")
+	}
+
+	cases := []struct {
+		expected []string
+		account  string
+		valid    bool
+	}{
+		{nil, "333333333333", true},
+		{[]string{"111111111111", "222222222222"}, "222222222222", true},
+		{[]string{"111111111111", "222222222222"}, "333333333333", false},
+	}
+
+	for _, tc := range cases {
+		err := checkExpectedAccount(tc.expected, tc.account)
+		if (err == nil) != tc.valid {
+			t.Errorf("Wrong result for %s and %v: %v", tc.account, tc.expected, err)
+		}
+	}
+}
+
+func TestManagementAccount(t *testing.T) {
+	cases := []struct {
+		management string
+		err        error
+		valid      bool
+	}{
+		{"", nil, true},
+		{"222222222222", nil, true},
+		{"111111111111", nil, false},
+		{"", fmt.Errorf("AccessDeniedException"), false},
+	}
+
+	for _, tc := range cases {
+		err := checkManagementAccount("111111111111", func() (string, error) {
+			return tc.management, tc.err
+		})
+		if (err == nil) != tc.valid {
+			t.Errorf("Wrong result for %q and %v: %v", tc.management, tc.err, err)
+		}
+	}
+}
+
+func TestConfigRevision(t *testing.T) {
+	params := NukeParameters{
+		ConfigPath:     "config.yaml",
+		ConfigRevision: "4ee6ea0",
+	}
+	err := params.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params.ConfigRevision = "main"
+	if params.Validate() == nil {
+		t.Errorf("Expected an error for a branch name.")
+	}
+}
+
+func TestAccountConcurrency(t *testing.T) {
+	cases := []struct {
+		params NukeParameters
+		valid  bool
+	}{
+		{NukeParameters{AccountConcurrency: 0}, true},
+		{NukeParameters{AccountConcurrency: 1}, true},
+		{NukeParameters{AccountConcurrency: -1}, false},
+		{NukeParameters{AccountConcurrency: 8}, false},
+		{NukeParameters{AccountConcurrency: 8, Force: true}, true},
+		{NukeParameters{AccountConcurrency: 8, Force: true, Progress: true}, false},
+		{NukeParameters{AccountConcurrency: 8, Force: true, StatusAddr: ":8080"}, false},
+		{NukeParameters{AccountConcurrency: 1, Progress: true}, true},
+		{NukeParameters{AccountConcurrency: 8, Force: true, EventsFile: "-"}, false},
+		{NukeParameters{AccountConcurrency: 8, Force: true, EventsFile: "events.ndjson"}, true},
+		{NukeParameters{AccountConcurrency: 8, Force: true, Output: "json"}, false},
+		{NukeParameters{AccountConcurrency: 8, Force: true, Output: "json", OutputFile: "report.json"}, true},
+		{NukeParameters{AccountConcurrency: 8, Force: true, Summary: "markdown"}, false},
+		{NukeParameters{AccountConcurrency: 8, Force: true, Summary: "markdown", SummaryFile: "summary.md"}, true},
+		{NukeParameters{AccountConcurrency: 1, EventsFile: "-"}, true},
+	}
+
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	for i, tc := range cases {
+		tc.params.ConfigPath = "config.yaml"
+		err := tc.params.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d: wrong validation result: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"sync"
+)
+
+// PauseControl pauses the deletion loop, so an operator can halt the run
+// without killing it. It is controlled via SIGUSR1 or the status API.
+type PauseControl struct {
+	lock   sync.Mutex
+	cond   *sync.Cond
+	paused bool
+}
+
+func NewPauseControl() *PauseControl {
+	p := &PauseControl{}
+	p.cond = sync.NewCond(&p.lock)
+	return p
+}
+
+// Pause halts the deletion loop before the next resource.
+func (p *PauseControl) Pause() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.paused = true
+}
+
+// Resume continues the deletion loop.
+func (p *PauseControl) Resume() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.paused = false
+	p.cond.Broadcast()
+}
+
+// Toggle pauses a running and resumes a paused deletion loop. It returns
+// whether the loop is paused afterwards.
+func (p *PauseControl) Toggle() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.paused = !p.paused
+	p.cond.Broadcast()
+	return p.paused
+}
+
+// Paused returns true, if the deletion loop is paused.
+func (p *PauseControl) Paused() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.paused
+}
+
+// waitIfPaused blocks the deletion loop as long as the run is paused.
+func (n *Nuke) waitIfPaused() {
+	if n.pause != nil {
+		n.pause.Wait()
+	}
+}
+
+// Wait blocks as long as the deletion loop is paused.
+func (p *PauseControl) Wait() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for p.paused {
+		p.cond.Wait()
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// HandleSignals toggles the pause on SIGUSR1, until the returned function is
+// called.
+func (p *PauseControl) HandleSignals() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				if p.Toggle() {
+					log.Warn("Received SIGUSR1, pausing the removal. Send SIGUSR1 again to resume.")
+				} else {
+					log.Warn("Received SIGUSR1, resuming the removal.")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
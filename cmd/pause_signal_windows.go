@@ -0,0 +1,7 @@
+package cmd
+
+// HandleSignals is a no-op on Windows, since there is no SIGUSR1. The pause
+// can only be controlled via the status API.
+func (p *PauseControl) HandleSignals() func() {
+	return func() {}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseControlWait(t *testing.T) {
+	pause := NewPauseControl()
+	pause.Wait()
+
+	if !pause.Toggle() {
+		t.Fatal("Toggle should pause a running loop.")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pause.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Wait should block while paused.")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pause.Resume()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait should return after resuming.")
+	}
+}
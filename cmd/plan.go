@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+	"github.com/rebuy-de/aws-nuke/resources"
+	"github.com/spf13/cobra"
+)
+
+// Plan lists the resources, which a dry run would remove. It is written by
+// the plan command, so it can be reviewed before the apply command removes
+// exactly these resources.
+type Plan struct {
+	Version   string    `json:"version"`
+	Config    string    `json:"config"`
+	AccountID string    `json:"account-id"`
+	Time      time.Time `json:"time"`
+
+	Resources []PlanResource `json:"resources"`
+
+	// index maps the keys of the resources to their positions, so Contains
+	// does not compare every scanned item with every planned resource.
+	index map[string][]int
+}
+
+// PlanResource identifies a single resource of a plan. Like Item.Equals, it
+// uses the legacy string of the resource, if it supports it, and all
+// properties otherwise. Lazy properties like Empty or LastInvocation are not
+// part of Properties, so resources are still found, when they change between
+// the plan and the apply.
+type PlanResource struct {
+	Region     string            `json:"region"`
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+
+	found bool
+}
+
+func newPlanResource(item *Item) PlanResource {
+	r := PlanResource{
+		Region: item.Region.Name,
+		Type:   item.Type,
+	}
+
+	stringer, ok := item.Resource.(resources.LegacyStringer)
+	if ok {
+		r.ID = stringer.String()
+		return r
+	}
+
+	getter, ok := item.Resource.(resources.ResourcePropertyGetter)
+	if ok {
+		props := getter.Properties()
+		if len(props) > 0 {
+			r.Properties = props
+		}
+	}
+
+	return r
+}
+
+// key is the same for equal resources.
+func (r PlanResource) key() string {
+	id := r.ID
+	if id == "" {
+		id = Sorted(r.Properties)
+	}
+	return r.Region + "/" + r.Type + "/" + id
+}
+
+func (r PlanResource) equals(o PlanResource) bool {
+	return r.Region == o.Region &&
+		r.Type == o.Type &&
+		r.ID == o.ID &&
+		types.Properties(r.Properties).Equals(o.Properties)
+}
+
+// Plan returns the plan of all resources, which would be removed.
+func (n *Nuke) Plan() *Plan {
+	p := &Plan{
+		Version:   BuildVersion,
+		Config:    n.Parameters.ConfigPath,
+		AccountID: n.Account.ID(),
+		Time:      time.Now(),
+		Resources: []PlanResource{},
+	}
+
+	for _, item := range n.items {
+		if item.State == ItemStateNew {
+			p.Resources = append(p.Resources, newPlanResource(item))
+		}
+	}
+
+	return p
+}
+
+// WritePlan writes the plan as JSON to the given file.
+func (n *Nuke) WritePlan(path string) error {
+	data, err := json.MarshalIndent(n.Plan(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// LoadPlan reads a plan, which was written by the plan command.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := new(Plan)
+	err = json.Unmarshal(data, p)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse plan %s: %v", path, err)
+	}
+
+	return p, nil
+}
+
+// ResourceTypes returns all resource types, which are part of the plan.
+func (p *Plan) ResourceTypes() types.Collection {
+	seen := map[string]bool{}
+	result := types.Collection{}
+	for _, r := range p.Resources {
+		if !seen[r.Type] {
+			seen[r.Type] = true
+			result = append(result, r.Type)
+		}
+	}
+	return result
+}
+
+// RegionResourceTypes returns the resource types of the plan, which have
+// resources in the region.
+func (p *Plan) RegionResourceTypes(region string) types.Collection {
+	result := types.Collection{}
+	for _, r := range p.Resources {
+		if r.Region == region {
+			result = result.Union(types.Collection{r.Type})
+		}
+	}
+	return result
+}
+
+// Contains returns true, if the item is part of the plan. It also marks the
+// resource of the plan as found.
+func (p *Plan) Contains(item *Item) bool {
+	if p.index == nil {
+		p.index = map[string][]int{}
+		for i, r := range p.Resources {
+			p.index[r.key()] = append(p.index[r.key()], i)
+		}
+	}
+
+	other := newPlanResource(item)
+	for _, i := range p.index[other.key()] {
+		if p.Resources[i].equals(other) {
+			p.Resources[i].found = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// Missing returns the resources of the plan, which were not found by the
+// scan.
+func (p *Plan) Missing() []PlanResource {
+	missing := []PlanResource{}
+	for _, r := range p.Resources {
+		if !r.found {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// warnMissingPlanResources warns about the resources of the plan, which do not
+// exist anymore, eg because they were removed in the meantime.
+func warnMissingPlanResources(p *Plan, out *Output) {
+	for _, r := range p.Missing() {
+		id := r.ID
+		if id == "" {
+			id = Sorted(r.Properties)
+		}
+		out.Logger.Warnf("The planned resource %s - %s - %s was not found.",
+			r.Region, r.Type, out.Redactor.String(id))
+	}
+}
+
+func NewPlanCommand(params *NukeParameters, creds *awsutil.Credentials, defaultRegion *string) *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "scans the account and writes the resources which would be removed into a plan file",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := params.Validate()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		params.NoDryRun = false
+		params.PlanFile = out
+
+		nuke, err := buildNuke(params, creds, *defaultRegion)
+		if err != nil {
+			return err
+		}
+
+		return nuke.Run()
+	}
+
+	cmd.Flags().StringVar(
+		&out, "out", "plan.json",
+		"Path of the plan file.")
+
+	return cmd
+}
+
+func NewApplyCommand(params *NukeParameters, creds *awsutil.Credentials, defaultRegion *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <plan>",
+		Short: "removes exactly the resources of a plan file",
+		Long: `Removes exactly the resources of a plan file, which was written by the plan command.
+Resources which are not part of the plan are never removed, even if the config does not protect them.`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := params.Validate()
+		if err != nil {
+			return err
+		}
+
+		plan, err := LoadPlan(args[0])
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		params.NoDryRun = true
+
+		nuke, err := buildNuke(params, creds, *defaultRegion)
+		if err != nil {
+			return err
+		}
+
+		nuke.plan = plan
+		return nuke.Run()
+	}
+
+	return cmd
+}
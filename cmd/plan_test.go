@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestPlanRoundTrip(t *testing.T) {
+	region := &Region{Name: "eu-west-1"}
+	planned := &Item{Region: region, Type: "EC2Instance", State: ItemStateNew,
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	byProperties := &Item{Region: region, Type: "IAMUserPolicyAttachment", State: ItemStateNew,
+		Resource: &testResource{props: types.NewProperties().
+			Set("UserName", "admin").Set("PolicyArn", "arn:aws:iam::aws:policy/AdministratorAccess")}}
+	filtered := &Item{Region: region, Type: "EC2Instance", State: ItemStateFiltered,
+		Resource: &stringerTestResource{id: "i-0b0f4b4e8a0a7f3c1"}}
+
+	n := &Nuke{items: Queue{planned, byProperties, filtered}}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	err := n.WritePlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := LoadPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.Resources) != 2 {
+		t.Fatalf("Wrong number of planned resources. Want: 2. Have: %d", len(plan.Resources))
+	}
+
+	if !plan.Contains(planned) {
+		t.Errorf("Plan should contain %v.", planned.Resource)
+	}
+
+	if plan.Contains(filtered) {
+		t.Errorf("Plan should not contain the filtered resource %v.", filtered.Resource)
+	}
+
+	otherRegion := &Item{Region: &Region{Name: "us-east-1"}, Type: "EC2Instance",
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	if plan.Contains(otherRegion) {
+		t.Errorf("Plan should not contain resources of other regions.")
+	}
+
+	otherPolicy := &Item{Region: region, Type: "IAMUserPolicyAttachment",
+		Resource: &testResource{props: types.NewProperties().
+			Set("UserName", "admin").Set("PolicyArn", "arn:aws:iam::aws:policy/ReadOnlyAccess")}}
+	if plan.Contains(otherPolicy) {
+		t.Errorf("Plan should not contain resources with other properties.")
+	}
+
+	missing := plan.Missing()
+	if len(missing) != 1 || missing[0].Type != "IAMUserPolicyAttachment" {
+		t.Errorf("Wrong missing resources: %#v", missing)
+	}
+
+	if !plan.Contains(byProperties) || len(plan.Missing()) != 0 {
+		t.Errorf("Plan should contain %v.", byProperties.Resource)
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+// preflightFailure is a service, whose lister was denied by IAM.
+type preflightFailure struct {
+	Service string
+	Type    string
+	Err     error
+}
+
+// preflightResourceTypes returns one resource type per service, whose lister
+// is called to check the permissions for the service.
+func preflightResourceTypes(resourceTypes []string) []string {
+	sorted := append([]string{}, resourceTypes...)
+	sort.Strings(sorted)
+
+	seen := map[string]bool{}
+	result := []string{}
+	for _, resourceType := range sorted {
+		service := resourceType
+		meta, ok := resources.GetMetadata(resourceType)
+		if ok && meta.Service != "" {
+			service = meta.Service
+		}
+
+		if !seen[service] {
+			seen[service] = true
+			result = append(result, resourceType)
+		}
+	}
+
+	return result
+}
+
+// isAccessDenied returns true, if IAM denied the request.
+func isAccessDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	code := awsErrorCode(errors.Cause(err))
+	switch {
+	case strings.Contains(code, "AccessDenied"),
+		code == "UnauthorizedOperation",
+		code == "AuthorizationError",
+		code == "UnauthorizedAccess":
+		return true
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "AccessDenied") || strings.Contains(msg, "not authorized to perform")
+}
+
+// Preflight calls one lister per service of the selected resource types and
+// fails with a consolidated list of all denied services, so missing
+// permissions are found before the scan. Denied requests, which are skipped by
+// the error policies, are ignored.
+func (n *Nuke) Preflight() error {
+	regionName := n.Account.DefaultRegionID()
+	for _, name := range n.Config.AccountRegions(n.Account.ID()) {
+		if name != awsutil.GlobalRegionID {
+			regionName = name
+			break
+		}
+	}
+
+	region := n.newRegion(regionName)
+	global := n.newRegion(awsutil.GlobalRegionID)
+
+	resourceTypes := preflightResourceTypes(n.resolveResourceTypes())
+	n.output().Logger.Infof("Checking the permissions for %d services in %s.", len(resourceTypes), regionName)
+
+	parallel := n.Profile.ParallelQueries
+	if parallel <= 0 {
+		parallel = ScannerParallelQueries
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures []preflightFailure
+	)
+	limit := make(chan struct{}, parallel)
+
+	for _, resourceType := range resourceTypes {
+		wg.Add(1)
+		limit <- struct{}{}
+
+		go func(resourceType string) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			err := preflightList(region, resourceType)
+			if _, ok := err.(awsutil.ErrSkipRequest); ok {
+				err = preflightList(global, resourceType)
+			}
+
+			if !isAccessDenied(err) || n.errorAction(resourceType, err) == config.ErrorActionSkip {
+				if err != nil {
+					n.output().Logger.Debugf("preflight of %s: %v", resourceType, err)
+				}
+				return
+			}
+
+			service := resourceType
+			if meta, ok := resources.GetMetadata(resourceType); ok && meta.Service != "" {
+				service = meta.Service
+			}
+
+			mu.Lock()
+			failures = append(failures, preflightFailure{Service: service, Type: resourceType, Err: err})
+			mu.Unlock()
+		}(resourceType)
+	}
+	wg.Wait()
+
+	if len(failures) == 0 {
+		n.output().Logger.Infof("The permissions for all %d services are fine.", len(resourceTypes))
+		return nil
+	}
+
+	return preflightError(failures)
+}
+
+func preflightList(region *Region, resourceType string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("listing %s panicked: %v", resourceType, r)
+		}
+	}()
+
+	sess, err := region.Session(resourceType)
+	if err != nil {
+		return err
+	}
+
+	_, err = region.Lister(resourceType)(sess)
+	return err
+}
+
+func preflightError(failures []preflightFailure) error {
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Service < failures[j].Service
+	})
+
+	lines := make([]string, 0, len(failures))
+	for _, f := range failures {
+		msg := strings.SplitN(f.Err.Error(), "\n", 2)[0]
+		lines = append(lines, fmt.Sprintf("  %s (%s): %s", f.Service, f.Type, msg))
+	}
+
+	return fmt.Errorf("The preflight found missing permissions for %d services:\n%s\n"+
+		"Use 'aws-nuke iam-policy' to print a policy with all required actions.\n",
+		len(failures), strings.Join(lines, "\n"))
+}
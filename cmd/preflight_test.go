@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestPreflightResourceTypes(t *testing.T) {
+	have := preflightResourceTypes([]string{"IAMUser", "EC2Volume", "S3Bucket", "EC2Instance", "IAMRole"})
+	want := []string{"EC2Instance", "IAMRole", "S3Bucket"}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("Expected one resource type per service. Want: %v. Have: %v", want, have)
+	}
+}
+
+func TestIsAccessDenied(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{err: awserr.New("AccessDeniedException", "not allowed", nil), want: true},
+		{err: awserr.New("UnauthorizedOperation", "not allowed", nil), want: true},
+		{err: errors.New("User: arn:aws:iam::123456789012:user/ci is not authorized to perform: s3:ListAllMyBuckets"), want: true},
+		{err: awserr.New("Throttling", "rate exceeded", nil), want: false},
+		{err: nil, want: false},
+	}
+
+	for _, tc := range cases {
+		have := isAccessDenied(tc.err)
+		if have != tc.want {
+			t.Errorf("Wrong result for %v. Want: %t. Have: %t", tc.err, tc.want, have)
+		}
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	err := preflightError([]preflightFailure{
+		{Service: "s3", Type: "S3Bucket", Err: awserr.New("AccessDenied", "Access Denied", nil)},
+		{Service: "ec2", Type: "EC2Instance", Err: awserr.New("UnauthorizedOperation", "not allowed", nil)},
+	})
+
+	msg := err.Error()
+	if !strings.Contains(msg, "2 services") || strings.Index(msg, "ec2 (EC2Instance)") > strings.Index(msg, "s3 (S3Bucket)") {
+		t.Errorf("Expected sorted list of the denied services. Have: %s", msg)
+	}
+}
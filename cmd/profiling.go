@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// profiles are the pprof profiles of --profile-cpu and --profile-mem. They
+// are written by StopProfiling. It is nil, if profiling is disabled.
+var profiles *profiler
+
+type profiler struct {
+	cpu     *os.File
+	memPath string
+}
+
+// startProfiling starts the CPU profile and remembers where the heap profile
+// is written to. Empty paths disable the profile.
+func startProfiling(cpuPath, memPath string) error {
+	if cpuPath == "" && memPath == "" {
+		return nil
+	}
+
+	p := &profiler{memPath: memPath}
+	if cpuPath != "" {
+		f, err := os.Create(cpuPath)
+		if err != nil {
+			return fmt.Errorf("Failed to create the CPU profile %s: %v\n", cpuPath, err)
+		}
+
+		err = pprof.StartCPUProfile(f)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("Failed to start the CPU profile: %v\n", err)
+		}
+		p.cpu = f
+	}
+
+	profiles = p
+	return nil
+}
+
+// StopProfiling writes the profiles of --profile-cpu and --profile-mem. It
+// has to be called after the command finished, regardless of its result.
+func StopProfiling() {
+	p := profiles
+	if p == nil {
+		return
+	}
+	profiles = nil
+
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		err := p.cpu.Close()
+		if err != nil {
+			log.Warnf("Failed to write the CPU profile: %v", err)
+		}
+	}
+
+	if p.memPath != "" {
+		err := writeHeapProfile(p.memPath)
+		if err != nil {
+			log.Warnf("Failed to write the memory profile %s: %v", p.memPath, err)
+		}
+	}
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Collect the garbage first, so the profile shows the live objects.
+	runtime.GC()
+	return pprof.WriteHeapProfile(f)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// progressTTYInterval limits how often the progress bar is redrawn.
+	progressTTYInterval = 250 * time.Millisecond
+
+	// progressLogInterval is the interval of the progress lines, if stderr
+	// is not a terminal, eg in CI logs.
+	progressLogInterval = 30 * time.Second
+
+	progressBarWidth = 30
+)
+
+// Progress shows the number of scanned and removed resources with an ETA. On a
+// terminal, it is a single line which is redrawn in place. Otherwise, a plain
+// line is printed every progressLogInterval, so logs do not get flooded.
+type Progress struct {
+	mu sync.Mutex
+
+	out      io.Writer
+	tty      bool
+	interval time.Duration
+
+	started time.Time
+	drawn   time.Time
+	visible bool
+
+	// total and finished are the numbers of resources to remove and of
+	// already removed resources, when the removal started.
+	total    int
+	finished int
+}
+
+func NewProgress(out io.Writer, tty bool) *Progress {
+	p := &Progress{
+		out:      out,
+		tty:      tty,
+		interval: progressLogInterval,
+	}
+	if tty {
+		p.interval = progressTTYInterval
+	}
+	return p
+}
+
+// isTerminal returns true, if the file is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// StartScan resets the ETA for the scan.
+func (p *Progress) StartScan() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.started = time.Now()
+	p.drawn = time.Time{}
+}
+
+// StartRemoval resets the ETA for the removal of the items.
+func (p *Progress) StartRemoval(items Queue) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.started = time.Now()
+	p.drawn = time.Time{}
+	p.total = items.Count(ItemStateNew, ItemStatePending, ItemStateWaiting, ItemStateFailed)
+	p.finished = items.Count(ItemStateFinished)
+}
+
+// Scan shows the progress of the scan, which is estimated by the number of
+// scanned regions.
+func (p *Progress) Scan(region string, done, regions, found int) {
+	if p == nil {
+		return
+	}
+
+	p.update(func() string {
+		return fmt.Sprintf("Scanning %s: %s %d/%d regions, %d resources found, %s",
+			region, progressBar(done, regions), done, regions, found, p.eta(done, regions))
+	})
+}
+
+// Remove shows the progress of the removal, which is estimated by the number
+// of removed resources.
+func (p *Progress) Remove(items Queue) {
+	if p == nil {
+		return
+	}
+
+	p.update(func() string {
+		done := items.Count(ItemStateFinished) - p.finished
+		return fmt.Sprintf("Removing: %s %d/%d resources, %d failed, %s",
+			progressBar(done, p.total), done, p.total, items.Count(ItemStateFailed), p.eta(done, p.total))
+	})
+}
+
+func (p *Progress) eta(done, total int) string {
+	elapsed := time.Since(p.started)
+	if done >= total {
+		return "done"
+	}
+	if done <= 0 || elapsed <= 0 {
+		return "ETA unknown"
+	}
+
+	rate := float64(done) / elapsed.Seconds()
+	remaining := time.Duration(float64(total-done) / rate * float64(time.Second))
+	return fmt.Sprintf("ETA %v", remaining.Round(time.Second))
+}
+
+func progressBar(done, total int) string {
+	filled, percent := progressBarWidth, 100
+	if total > 0 && done < total {
+		filled = progressBarWidth * done / total
+		percent = 100 * done / total
+	}
+
+	return fmt.Sprintf("[%s%s] %3d%%",
+		strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), percent)
+}
+
+// update draws the line, unless it was drawn within the interval. The line is
+// only built when it is drawn, since counting the items is not free.
+func (p *Progress) update(line func() string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(p.drawn) < p.interval {
+		return
+	}
+	p.drawn = now
+
+	if p.tty {
+		fmt.Fprintf(p.out, "\r\033[K%s", line())
+		p.visible = true
+	} else {
+		fmt.Fprintln(p.out, line())
+	}
+}
+
+// Clear removes the progress bar from the terminal, so other output does not
+// get mixed up with it. It is redrawn with the next update.
+func (p *Progress) Clear() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.visible {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.visible = false
+		p.drawn = time.Time{}
+	}
+}
+
+// Levels and Fire make the progress a log hook, which clears the progress bar
+// before log messages are written.
+func (p *Progress) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (p *Progress) Fire(entry *log.Entry) error {
+	p.Clear()
+	return nil
+}
+
+// addLogHook adds the hook to the standard logger. The returned function
+// restores the previous hooks, so the hooks of consecutive runs do not pile up.
+func addLogHook(hook log.Hook) func() {
+	logger := log.StandardLogger()
+	previous := log.LevelHooks{}
+	for level, hooks := range logger.Hooks {
+		previous[level] = append([]log.Hook{}, hooks...)
+	}
+
+	logger.AddHook(hook)
+	return func() {
+		logger.ReplaceHooks(previous)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestProgressBar(t *testing.T) {
+	cases := map[string]string{
+		progressBar(0, 10):  "[------------------------------]   0%",
+		progressBar(5, 10):  "[###############---------------]  50%",
+		progressBar(10, 10): "[##############################] 100%",
+		progressBar(0, 0):   "[##############################] 100%",
+	}
+
+	for have, want := range cases {
+		if have != want {
+			t.Errorf("Wrong progress bar. Want: %q. Have: %q", want, have)
+		}
+	}
+}
+
+func TestProgressRemove(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewProgress(buf, false)
+
+	items := Queue{
+		&Item{State: ItemStateFinished},
+		&Item{State: ItemStateNew},
+		&Item{State: ItemStateNew},
+		&Item{State: ItemStateNew},
+		&Item{State: ItemStateFiltered},
+	}
+	p.StartRemoval(items)
+	p.started = time.Now().Add(-time.Minute)
+
+	items[1].State = ItemStateFinished
+	items[2].State = ItemStateFailed
+	p.Remove(items)
+
+	want := "Removing: [##########--------------------]  33% 1/3 resources, 1 failed, ETA 2m0s\n"
+	if buf.String() != want {
+		t.Errorf("Wrong progress line.\nWant: %q\nHave: %q", want, buf.String())
+	}
+
+	p.Remove(items)
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Progress should not be printed again within the interval. Have: %q", buf.String())
+	}
+}
+
+func TestProgressClear(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewProgress(buf, true)
+
+	p.StartScan()
+	p.Scan("eu-west-1", 0, 2, 10)
+	p.Clear()
+
+	have := buf.String()
+	if !strings.HasPrefix(have, "\r\033[KScanning eu-west-1: ") || !strings.HasSuffix(have, "ETA unknown\r\033[K") {
+		t.Errorf("Wrong terminal output: %q", have)
+	}
+
+	var disabled *Progress
+	disabled.Scan("eu-west-1", 0, 2, 10)
+	disabled.Clear()
+}
+
+func TestAddLogHook(t *testing.T) {
+	hooks := len(log.StandardLogger().Hooks[log.InfoLevel])
+
+	for i := 0; i < 3; i++ {
+		restore := addLogHook(NewProgress(new(bytes.Buffer), false))
+		if len(log.StandardLogger().Hooks[log.InfoLevel]) != hooks+1 {
+			t.Errorf("The progress hook was not added.")
+		}
+		restore()
+	}
+
+	if len(log.StandardLogger().Hooks[log.InfoLevel]) != hooks {
+		t.Errorf("The hooks of the runs pile up. Want: %d. Have: %d",
+			hooks, len(log.StandardLogger().Hooks[log.InfoLevel]))
+	}
+}
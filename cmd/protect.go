@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+// ownResource is a resource, which the run itself depends on, like the topic
+// of --publish-sns-topic. It is never removed, regardless of the config.
+type ownResource struct {
+	filter config.Filter
+	reason string
+}
+
+// protect prevents the removal of the resources of the type, which match the
+// filter.
+func (n *Nuke) protect(resourceType string, filter config.Filter, reason string) {
+	if n.own == nil {
+		n.own = map[string][]ownResource{}
+	}
+	n.own[resourceType] = append(n.own[resourceType], ownResource{filter: filter, reason: reason})
+}
+
+// ownResourceReason returns why the item is used by the run. It is empty, if
+// the item is not used.
+func (n *Nuke) ownResourceReason(item *Item) string {
+	for _, own := range n.own[item.Type] {
+		match, err := item.MatchesAny([]config.Filter{own.filter})
+		if err == nil && match {
+			return own.reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+// EventSink receives the events of the items besides the --events-file.
+type EventSink interface {
+	Send(event Event) error
+}
+
+// ResultPublisher sends the result of every removal to an SNS topic or an SQS
+// queue, so other automation can react on removed resources without polling
+// the reports.
+type ResultPublisher struct {
+	topicARN string
+	sns      snsiface.SNSAPI
+
+	queueURL string
+	sqs      sqsiface.SQSAPI
+}
+
+// NewResultPublisher creates a publisher for the topic and the queue. Either
+// of them might be empty.
+func NewResultPublisher(account *awsutil.Account, topicARN, queueURL string) (*ResultPublisher, error) {
+	p := &ResultPublisher{
+		topicARN: topicARN,
+		queueURL: queueURL,
+	}
+
+	if topicARN != "" {
+		parsed, err := arn.Parse(topicARN)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid SNS topic ARN '%s' for --publish-sns-topic: %v\n", topicARN, err)
+		}
+
+		sess, err := account.NewSession(parsed.Region, account.ResourceTypeToServiceType(parsed.Region, "SNSTopic"))
+		if err != nil {
+			return nil, err
+		}
+		p.sns = sns.New(sess)
+	}
+
+	if queueURL != "" {
+		region, err := sqsQueueRegion(queueURL)
+		if err != nil {
+			return nil, err
+		}
+
+		sess, err := account.NewSession(region, account.ResourceTypeToServiceType(region, "SQSQueue"))
+		if err != nil {
+			return nil, err
+		}
+		p.sqs = sqs.New(sess)
+	}
+
+	return p, nil
+}
+
+// protectPublisher prevents the removal of the topic and the queue, which
+// the results are published to. The queue is matched by its account and name,
+// since the URLs of the SQS API have the sqs.<region>.amazonaws.com host, even
+// if the queue was specified with the legacy <region>.queue.amazonaws.com
+// host.
+func (n *Nuke) protectPublisher() {
+	if n.Parameters.PublishTopic != "" {
+		n.protect("SNSTopic", config.Filter{Value: "TopicARN: " + n.Parameters.PublishTopic},
+			"the topic of --publish-sns-topic")
+	}
+	if n.Parameters.PublishQueue != "" {
+		filter := config.Filter{Value: n.Parameters.PublishQueue}
+		u, err := url.Parse(n.Parameters.PublishQueue)
+		if err == nil && strings.Trim(u.Path, "/") != "" {
+			filter = config.Filter{Type: config.FilterTypeGlob, Value: "*://*/" + strings.Trim(u.Path, "/")}
+		}
+		n.protect("SQSQueue", filter, "the queue of --publish-sqs-queue")
+	}
+}
+
+// sqsQueueRegion extracts the region of an SQS queue URL like
+// https://sqs.eu-west-1.amazonaws.com/123456789012/results or the legacy
+// https://eu-west-1.queue.amazonaws.com/123456789012/results.
+func sqsQueueRegion(queueURL string) (string, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("Invalid SQS queue URL '%s' for --publish-sqs-queue.\n", queueURL)
+	}
+
+	parts := strings.Split(u.Hostname(), ".")
+	switch {
+	case len(parts) >= 3 && parts[0] == "sqs":
+		return parts[1], nil
+	case len(parts) >= 3 && parts[1] == "queue":
+		return parts[0], nil
+	}
+
+	return "", fmt.Errorf("Cannot determine the region of the SQS queue URL '%s' for --publish-sqs-queue.\n", queueURL)
+}
+
+// Send publishes the event, if it is the result of a removal.
+func (p *ResultPublisher) Send(event Event) error {
+	if event.Event != EventRemoved && event.Event != EventFailed {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	body := string(data)
+
+	sum := sha256.Sum256(data)
+	deduplicationID := hex.EncodeToString(sum[:])
+
+	if p.sns != nil {
+		input := &sns.PublishInput{
+			TopicArn: aws.String(p.topicARN),
+			Message:  aws.String(body),
+			MessageAttributes: map[string]*sns.MessageAttributeValue{
+				"event":         {DataType: aws.String("String"), StringValue: aws.String(event.Event)},
+				"resource-type": {DataType: aws.String("String"), StringValue: aws.String(event.Type)},
+			},
+		}
+
+		if strings.HasSuffix(p.topicARN, ".fifo") {
+			input.MessageGroupId = aws.String("aws-nuke")
+			input.MessageDeduplicationId = aws.String(deduplicationID)
+		}
+
+		_, err = p.sns.Publish(input)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.sqs != nil {
+		input := &sqs.SendMessageInput{
+			QueueUrl:    aws.String(p.queueURL),
+			MessageBody: aws.String(body),
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"event":         {DataType: aws.String("String"), StringValue: aws.String(event.Event)},
+				"resource-type": {DataType: aws.String("String"), StringValue: aws.String(event.Type)},
+			},
+		}
+
+		if strings.HasSuffix(p.queueURL, ".fifo") {
+			input.MessageGroupId = aws.String("aws-nuke")
+			input.MessageDeduplicationId = aws.String(deduplicationID)
+		}
+
+		_, err = p.sqs.SendMessage(input)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
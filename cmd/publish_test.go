@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+)
+
+type publishTestSNS struct {
+	snsiface.SNSAPI
+	inputs []*sns.PublishInput
+}
+
+func (s *publishTestSNS) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
+	s.inputs = append(s.inputs, input)
+	return &sns.PublishOutput{}, nil
+}
+
+type publishTestSQS struct {
+	sqsiface.SQSAPI
+	inputs []*sqs.SendMessageInput
+}
+
+func (s *publishTestSQS) SendMessage(input *sqs.SendMessageInput) (*sqs.SendMessageOutput, error) {
+	s.inputs = append(s.inputs, input)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func TestSQSQueueRegion(t *testing.T) {
+	cases := map[string]string{
+		"https://sqs.eu-west-1.amazonaws.com/123456789012/results":  "eu-west-1",
+		"https://eu-central-1.queue.amazonaws.com/123456789012/res": "eu-central-1",
+	}
+
+	for queueURL, want := range cases {
+		have, err := sqsQueueRegion(queueURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("Wrong region for %s. Want: %s. Have: %s", queueURL, want, have)
+		}
+	}
+
+	_, err := sqsQueueRegion("results")
+	if err == nil {
+		t.Errorf("Expected error for invalid queue URL.")
+	}
+}
+
+func TestResultPublisherSend(t *testing.T) {
+	topic := &publishTestSNS{}
+	queue := &publishTestSQS{}
+	p := &ResultPublisher{
+		topicARN: "arn:aws:sns:eu-west-1:123456789012:results",
+		sns:      topic,
+		queueURL: "https://sqs.eu-west-1.amazonaws.com/123456789012/results.fifo",
+		sqs:      queue,
+	}
+
+	for _, name := range []string{EventDiscovered, EventDeleteRequested, EventRemoved, EventFailed} {
+		err := p.Send(Event{Event: name, Entry: report.Entry{Type: "EC2Instance"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(topic.inputs) != 2 || len(queue.inputs) != 2 {
+		t.Fatalf("Only removal results should be published. Have: %d SNS, %d SQS messages",
+			len(topic.inputs), len(queue.inputs))
+	}
+
+	if aws.StringValue(topic.inputs[0].MessageAttributes["event"].StringValue) != EventRemoved {
+		t.Errorf("Wrong event attribute: %v", topic.inputs[0].MessageAttributes)
+	}
+	if topic.inputs[0].MessageGroupId != nil {
+		t.Errorf("Standard topics must not get a message group.")
+	}
+	if aws.StringValue(queue.inputs[1].MessageGroupId) == "" || aws.StringValue(queue.inputs[1].MessageDeduplicationId) == "" {
+		t.Errorf("FIFO queues need a message group and deduplication ID.")
+	}
+}
+
+func TestAsyncSink(t *testing.T) {
+	topic := &publishTestSNS{}
+	sink := newAsyncSink(&ResultPublisher{topicARN: "arn:aws:sns:eu-west-1:123456789012:results", sns: topic})
+
+	for i := 0; i < 3; i++ {
+		err := sink.Send(Event{Event: EventRemoved, Entry: report.Entry{Type: "EC2Instance"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topic.inputs) != 3 {
+		t.Errorf("Not all events were published after closing the sink. Have: %d", len(topic.inputs))
+	}
+}
+
+func TestProtectPublisher(t *testing.T) {
+	topicARN := "arn:aws:sns:eu-west-1:123456789012:results"
+	queueURL := "https://sqs.eu-west-1.amazonaws.com/123456789012/results"
+
+	cases := []struct {
+		queue     string
+		item      *Item
+		protected bool
+	}{
+		{queueURL, &Item{Type: "SNSTopic", Resource: &stringerTestResource{id: "TopicARN: " + topicARN}}, true},
+		{queueURL, &Item{Type: "SNSTopic", Resource: &stringerTestResource{id: "TopicARN: " + topicARN + "-other"}}, false},
+		{queueURL, &Item{Type: "SQSQueue", Resource: &stringerTestResource{id: queueURL}}, true},
+		{queueURL, &Item{Type: "SQSQueue", Resource: &stringerTestResource{id: queueURL + "-other"}}, false},
+		{queueURL, &Item{Type: "SQSQueue", Resource: &stringerTestResource{
+			id: "https://sqs.eu-west-1.amazonaws.com/210987654321/results"}}, false},
+		{"https://eu-west-1.queue.amazonaws.com/123456789012/results",
+			&Item{Type: "SQSQueue", Resource: &stringerTestResource{id: queueURL}}, true},
+		{"https://eu-west-1.queue.amazonaws.com/123456789012/results",
+			&Item{Type: "SQSQueue", Resource: &stringerTestResource{id: queueURL + "-other"}}, false},
+	}
+
+	for _, tc := range cases {
+		n := &Nuke{Parameters: NukeParameters{PublishTopic: topicARN, PublishQueue: tc.queue}}
+		n.protectPublisher()
+
+		reason := n.ownResourceReason(tc.item)
+		if (reason != "") != tc.protected {
+			t.Errorf("Wrong protection of %v for %s: %q", tc.item.Resource, tc.queue, reason)
+		}
+	}
+}
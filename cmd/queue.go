@@ -1,11 +1,28 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/pkg/util"
 	"github.com/rebuy-de/aws-nuke/resources"
 )
 
+// ErrUnknownProperty is returned, if a lazy property of an item could not be
+// determined. Since the filters cannot tell whether such an item is
+// protected, it must not be removed.
+type ErrUnknownProperty struct {
+	Property string
+	Err      error
+}
+
+func (err ErrUnknownProperty) Error() string {
+	return fmt.Sprintf("unknown %s: %v", err.Property, err.Err)
+}
+
 type ItemState int
 
 // States of Items based on the latest request to AWS.
@@ -18,6 +35,25 @@ const (
 	ItemStateFinished
 )
 
+func (s ItemState) String() string {
+	switch s {
+	case ItemStateNew:
+		return report.StateNew
+	case ItemStatePending:
+		return report.StatePending
+	case ItemStateWaiting:
+		return report.StateWaiting
+	case ItemStateFailed:
+		return report.StateFailed
+	case ItemStateFiltered:
+		return report.StateFiltered
+	case ItemStateFinished:
+		return report.StateFinished
+	default:
+		return "unknown"
+	}
+}
+
 // An Item describes an actual AWS resource entity with the current state and
 // some metadata.
 type Item struct {
@@ -28,33 +64,120 @@ type Item struct {
 
 	Region *Region
 	Type   string
+
+	// Owner is the principal which created the resource, as found in the
+	// CloudTrail event history. It is empty if the lookup is disabled or
+	// did not yield a result.
+	Owner string
+
+	// printed is the state and reason of the last Print.
+	printed string
+
+	// raw is the sanitized raw response of the resource. It is determined
+	// on the first lookup of a _raw property, since the filters of a
+	// resource type usually look up several of them.
+	raw       interface{}
+	rawLoaded bool
+}
+
+// PrintChanged prints the item, unless its state and reason did not change
+// since it was printed last. This collapses repeated identical lines, eg of
+// resources which are waiting for their removal.
+func (i *Item) PrintChanged() {
+	if i.printed == i.printKey() {
+		return
+	}
+
+	i.Print()
+}
+
+func (i *Item) printKey() string {
+	return fmt.Sprintf("%d:%s", i.State, i.Reason)
 }
 
 func (i *Item) Print() {
+	i.printed = i.printKey()
+
+	if !i.Region.output().shown(i.State) {
+		return
+	}
+
 	switch i.State {
 	case ItemStateNew:
-		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, "would remove")
+		msg := "would remove"
+		if i.Reason != "" {
+			msg = fmt.Sprintf("%s, %s", msg, i.Reason)
+		}
+		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, i.annotate(msg))
 	case ItemStatePending:
-		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, "triggered remove")
+		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, i.annotate("triggered remove"))
 	case ItemStateWaiting:
-		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, "waiting")
+		Log(i.Region, i.Type, i.Resource, ReasonWaitPending, i.annotate("waiting"))
 	case ItemStateFailed:
-		Log(i.Region, i.Type, i.Resource, ReasonError, "failed")
+		Log(i.Region, i.Type, i.Resource, ReasonError, i.annotate("failed"))
 	case ItemStateFiltered:
-		Log(i.Region, i.Type, i.Resource, ReasonSkip, i.Reason)
+		Log(i.Region, i.Type, i.Resource, ReasonSkip, i.annotate(i.Reason))
 	case ItemStateFinished:
-		Log(i.Region, i.Type, i.Resource, ReasonSuccess, "removed")
+		Log(i.Region, i.Type, i.Resource, ReasonSuccess, i.annotate("removed"))
 	}
 }
 
+func (i *Item) annotate(msg string) string {
+	if i.Owner != "" {
+		msg = fmt.Sprintf("%s (created by %s)", msg, i.Owner)
+	}
+	if eol, ok := resources.GetEndOfLife(i.Type); ok {
+		msg = fmt.Sprintf("%s [deprecated service: %s]", msg, eol)
+	}
+	return msg
+}
+
+// CheckRemove sets the reason of the item, if the resource knows in advance
+// that its removal is going to fail.
+func (i *Item) CheckRemove() {
+	checker, ok := i.Resource.(resources.RemoveChecker)
+	if !ok {
+		return
+	}
+
+	err := checker.CheckRemove()
+	if err != nil {
+		i.Reason = fmt.Sprintf("will fail unless %v", err)
+	}
+}
+
+// Identifier returns a human readable identification of the resource. This is
+// the legacy string, if the resource supports it, or one of the commonly used
+// identifying properties otherwise.
+func (i *Item) Identifier() string {
+	stringer, ok := i.Resource.(resources.LegacyStringer)
+	if ok {
+		return stringer.String()
+	}
+
+	getter, ok := i.Resource.(resources.ResourcePropertyGetter)
+	if !ok {
+		return ""
+	}
+
+	props := getter.Properties()
+	for _, key := range []string{"Name", "ID", "Id", "Arn", "ARN"} {
+		value := props.Get(key)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
 // List gets all resource items of the same resource type like the Item.
 func (i *Item) List() ([]resources.Resource, error) {
-	listers := resources.GetListers()
 	sess, err := i.Region.Session(i.Type)
 	if err != nil {
 		return nil, err
 	}
-	return listers[i.Type](sess)
+	return i.Region.Lister(i.Type)(sess)
 }
 
 func (i *Item) GetProperty(key string) (string, error) {
@@ -66,6 +189,31 @@ func (i *Item) GetProperty(key string) (string, error) {
 		return stringer.String(), nil
 	}
 
+	if key == resources.RawProperty || strings.HasPrefix(key, resources.RawProperty+".") {
+		rawGetter, ok := i.Resource.(resources.RawGetter)
+		if !ok {
+			return "", fmt.Errorf("%T does not support the %s property", i.Resource, resources.RawProperty)
+		}
+
+		if !i.rawLoaded {
+			i.raw = util.SanitizeRaw(rawGetter.Raw())
+			i.rawLoaded = true
+		}
+
+		path := strings.TrimPrefix(strings.TrimPrefix(key, resources.RawProperty), ".")
+		value, _ := util.LookupRaw(i.raw, path)
+		return value, nil
+	}
+
+	lazyGetter, ok := i.Resource.(resources.LazyPropertyGetter)
+	if ok && resources.IsLazyProperty(i.Type, key) {
+		value, err := lazyGetter.LazyProperty(key)
+		if err != nil {
+			return "", ErrUnknownProperty{Property: key, Err: err}
+		}
+		return value, nil
+	}
+
 	getter, ok := i.Resource.(resources.ResourcePropertyGetter)
 	if !ok {
 		return "", fmt.Errorf("%T does not support custom properties", i.Resource)
@@ -74,6 +222,31 @@ func (i *Item) GetProperty(key string) (string, error) {
 	return getter.Properties().Get(key), nil
 }
 
+// MatchesAny returns true, if any of the filters matches the item.
+func (i *Item) MatchesAny(filters []config.Filter) (bool, error) {
+	for _, filter := range filters {
+		prop, err := i.GetProperty(filter.Property)
+		if errors.As(err, &ErrUnknownProperty{}) {
+			return false, err
+		}
+
+		match, err := filter.Match(prop)
+		if err != nil {
+			return false, err
+		}
+
+		if IsTrue(filter.Invert) {
+			match = !match
+		}
+
+		if match {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (i *Item) Equals(o resources.Resource) bool {
 	iType := fmt.Sprintf("%T", i.Resource)
 	oType := fmt.Sprintf("%T", o)
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+type rawTestResource struct {
+	raw   interface{}
+	calls int
+}
+
+func (r *rawTestResource) Remove() error {
+	return nil
+}
+
+func (r *rawTestResource) Raw() interface{} {
+	r.calls++
+	return r.raw
+}
+
+func TestItemGetRawProperty(t *testing.T) {
+	resource := &rawTestResource{raw: &ec2.Instance{
+		InstanceId: aws.String("i-01b489457a60298dd"),
+		State:      &ec2.InstanceState{Name: aws.String("running")},
+	}}
+	item := &Item{Resource: resource}
+
+	cases := map[string]string{
+		"_raw.State.Name": "running",
+		"_raw.KernelId":   "",
+		"_raw":            `{"InstanceId":"i-01b489457a60298dd","State":{"Name":"running"}}`,
+	}
+
+	for key, want := range cases {
+		have, err := item.GetProperty(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("Wrong value for %s. Want: %#v. Have: %#v", key, want, have)
+		}
+	}
+
+	if resource.calls != 1 {
+		t.Errorf("The raw response should be sanitized once. Have: %d", resource.calls)
+	}
+
+	_, err := (&Item{Resource: &testResource{}}).GetProperty("_raw.State.Name")
+	if err == nil {
+		t.Errorf("Expected error for resource without raw response.")
+	}
+}
+
+type lazyTestResource struct {
+	calls int
+	err   error
+}
+
+func (r *lazyTestResource) Remove() error {
+	return nil
+}
+
+func (r *lazyTestResource) Properties() types.Properties {
+	return types.NewProperties().Set("QueueURL", "https://sqs/queue")
+}
+
+func (r *lazyTestResource) LazyProperty(key string) (string, error) {
+	r.calls++
+	if r.err != nil {
+		return "", r.err
+	}
+	return types.NewProperties().Set("Empty", true).Get(key), nil
+}
+
+func TestItemGetLazyProperty(t *testing.T) {
+	resource := &lazyTestResource{}
+	item := &Item{Type: "SQSQueue", Resource: resource}
+
+	cases := map[string]string{
+		"QueueURL":  "https://sqs/queue",
+		"tag:owner": "",
+	}
+	for key, want := range cases {
+		have, err := item.GetProperty(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("Wrong value for %s. Want: %#v. Have: %#v", key, want, have)
+		}
+	}
+	if resource.calls != 0 {
+		t.Errorf("The lazy properties were determined for other properties.")
+	}
+
+	have, err := item.GetProperty("Empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != "true" || resource.calls != 1 {
+		t.Errorf("Wrong lazy property. Have: %#v after %d calls", have, resource.calls)
+	}
+}
+
+func TestFilterUnknownLazyProperty(t *testing.T) {
+	for _, invert := range []string{"", "true"} {
+		item := &Item{
+			Type:     "SQSQueue",
+			State:    ItemStateNew,
+			Resource: &lazyTestResource{err: fmt.Errorf("access denied")},
+		}
+		n := &Nuke{
+			Config: &config.Nuke{},
+			filters: config.Filters{
+				"SQSQueue": {{Property: "Empty", Value: "true", Invert: invert}},
+			},
+		}
+
+		err := n.Filter(item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item.State != ItemStateFiltered || item.Reason != "unknown Empty: access denied" {
+			t.Errorf("Item with an unknown property is not protected with invert %#v. Have: %v %#v",
+				invert, item.State, item.Reason)
+		}
+	}
+}
+
+func TestItemPrintChanged(t *testing.T) {
+	item := &Item{
+		Region:   &Region{Name: "eu-west-1"},
+		Type:     "EC2Instance",
+		State:    ItemStateWaiting,
+		Resource: &rawTestResource{},
+	}
+
+	if item.printed == item.printKey() {
+		t.Fatal("Unprinted item should be printed.")
+	}
+
+	item.PrintChanged()
+	if item.printed != item.printKey() {
+		t.Errorf("Item should be marked as printed.")
+	}
+
+	item.State = ItemStateFinished
+	if item.printed == item.printKey() {
+		t.Errorf("Item with changed state should be printed again.")
+	}
+}
+
+// BenchmarkMatchesAny filters many resources against hundreds of filters
+// like the filter phase of a large account.
+func BenchmarkMatchesAny(b *testing.B) {
+	filters := []config.Filter{}
+	for i := 0; i < 300; i++ {
+		filters = append(filters,
+			config.Filter{Property: "Name", Type: config.FilterTypeGlob, Value: fmt.Sprintf("team-%d-*", i)},
+			config.Filter{Property: "tag:owner", Type: config.FilterTypeRegex, Value: fmt.Sprintf("^svc-%d-[a-z]+$", i)},
+		)
+	}
+
+	items := make([]*Item, 1000)
+	for i := range items {
+		items[i] = &Item{Resource: &testResource{props: types.NewProperties().
+			Set("Name", fmt.Sprintf("app-%d", i)).
+			Set("tag:owner", fmt.Sprintf("user-%d", i))}}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			_, err := item.MatchesAny(filters)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+type checkRemoveTestResource struct {
+	err error
+}
+
+func (r *checkRemoveTestResource) Remove() error {
+	return nil
+}
+
+func (r *checkRemoveTestResource) CheckRemove() error {
+	return r.err
+}
+
+func TestItemCheckRemove(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource resources.Resource
+		reason   string
+	}{
+		{
+			name:     "without checker",
+			resource: &testResource{},
+			reason:   "",
+		},
+		{
+			name:     "removable",
+			resource: &checkRemoveTestResource{},
+			reason:   "",
+		},
+		{
+			name:     "blocked",
+			resource: &checkRemoveTestResource{err: fmt.Errorf("termination protection is disabled")},
+			reason:   "will fail unless termination protection is disabled",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &Item{Type: "EC2Instance", State: ItemStateNew, Resource: tc.resource}
+			item.CheckRemove()
+			if item.Reason != tc.reason {
+				t.Errorf("Wrong reason. Want: %q. Have: %q", tc.reason, item.Reason)
+			}
+			if item.State != ItemStateNew {
+				t.Errorf("The check must not change the state. Have: %v", item.State)
+			}
+		})
+	}
+}
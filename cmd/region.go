@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/resources"
 )
 
 // SessionFactory support for custom endpoints
@@ -14,13 +15,37 @@ type SessionFactory func(regionName, svcType string) (*session.Session, error)
 // ResourceTypeResolver returns the service type from the resourceType
 type ResourceTypeResolver func(regionName, resourceType string) string
 
+// ListerResolver returns the lister of the resource type or nil, if the type
+// is unknown.
+type ListerResolver func(resourceType string) resources.ResourceLister
+
+// missingEndpointWarnings are the regions and resource types, which were
+// already skipped because of the endpoint data of the SDK. Each skip is only
+// logged once, even though they are looked up for the preflight check and the
+// scan.
+var missingEndpointWarnings sync.Map
+
 type Region struct {
-	Name            string
+	Name string
+
+	// Account is written to the log file with the resources of the region,
+	// if aws-nuke runs for multiple accounts. The output of these runs
+	// prefixes every line with it.
+	Account string
+
 	NewSession      SessionFactory
 	ResTypeResolver ResourceTypeResolver
 
-	cache map[string]*session.Session
-	lock  *sync.RWMutex
+	// Output prints the resources of the region. The default output is used,
+	// if it is nil.
+	Output *Output
+
+	// Listers resolves the listers of the resource types. The registered
+	// listers are used, if it is nil.
+	Listers ListerResolver
+
+	// progress is cleared before the resources are printed.
+	progress *Progress
 }
 
 func NewRegion(name string, typeResolver ResourceTypeResolver, sessionFactory SessionFactory) *Region {
@@ -28,11 +53,25 @@ func NewRegion(name string, typeResolver ResourceTypeResolver, sessionFactory Se
 		Name:            name,
 		NewSession:      sessionFactory,
 		ResTypeResolver: typeResolver,
-		lock:            &sync.RWMutex{},
-		cache:           make(map[string]*session.Session),
 	}
 }
 
+func (region *Region) output() *Output {
+	if region == nil || region.Output == nil {
+		return defaultOutput
+	}
+	return region.Output
+}
+
+// Lister returns the lister of the resource type or nil, if the type is
+// unknown.
+func (region *Region) Lister(resourceType string) resources.ResourceLister {
+	if region.Listers != nil {
+		return region.Listers(resourceType)
+	}
+	return resources.GetLister(resourceType)
+}
+
 func (region *Region) Session(resourceType string) (*session.Session, error) {
 	svcType := region.ResTypeResolver(region.Name, resourceType)
 	if svcType == "" {
@@ -41,22 +80,27 @@ func (region *Region) Session(resourceType string) (*session.Session, error) {
 			region.Name, resourceType))
 	}
 
-	// Need to read
-	region.lock.RLock()
-	sess := region.cache[svcType]
-	region.lock.RUnlock()
-	if sess != nil {
-		return sess, nil
-	}
+	// Only the standard AWS endpoints are known to the availability data.
+	if svcType == "-" {
+		reason, unavailable := resources.Unavailable(resourceType, region.Name)
+		if unavailable {
+			return nil, awsutil.ErrSkipRequest(reason)
+		}
 
-	// Need to write:
-	region.lock.Lock()
-	sess, err := region.NewSession(region.Name, svcType)
-	if err != nil {
-		region.lock.Unlock()
-		return nil, err
+		// The endpoint data of the SDK might be outdated, so these skips are
+		// not silent.
+		reason, unavailable = resources.MissingEndpoint(resourceType, region.Name)
+		if unavailable {
+			_, warned := missingEndpointWarnings.LoadOrStore(region.Name+"/"+resourceType, true)
+			if !warned {
+				region.output().Logger.Warnf("Skipping %s in %s, since the %s. "+
+					"Update aws-nuke, if the service was added recently.", resourceType, region.Name, reason)
+			}
+			return nil, awsutil.ErrSkipRequest(reason)
+		}
 	}
-	region.cache[svcType] = sess
-	region.lock.Unlock()
-	return sess, nil
+
+	// The sessions are cached by the account, so the clients of all resource
+	// types of the same service share them.
+	return region.NewSession(region.Name, svcType)
 }
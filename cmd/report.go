@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+// Report converts the current state of all items into a report.
+func (n *Nuke) Report() *report.Report {
+	r := &report.Report{
+		Version:       BuildVersion,
+		Config:        n.Parameters.ConfigPath,
+		AccountID:     n.Account.ID(),
+		AccountAlias:  n.Account.Alias(),
+		DryRun:        !n.Parameters.NoDryRun,
+		Time:          time.Now(),
+		Started:       n.started,
+		ScanCompleted: n.scanCompleted,
+		Metadata:      n.metadata(),
+		Redacted:      n.output().Redactor.Enabled(),
+		Entries:       make([]report.Entry, 0, len(n.items)),
+		ScanErrors:    n.ScanErrors(),
+	}
+
+	for _, item := range n.items {
+		r.Entries = append(r.Entries, newReportEntry(item))
+	}
+
+	return r
+}
+
+// newReportEntry converts a single item into a report entry.
+func newReportEntry(item *Item) report.Entry {
+	redactor := item.Region.output().Redactor
+	entry := report.Entry{
+		Region: item.Region.Name,
+		Type:   item.Type,
+		State:  item.State.String(),
+		Reason: redactor.String(item.Reason),
+		Owner:  item.Owner,
+	}
+
+	if eol, ok := resources.GetEndOfLife(item.Type); ok {
+		entry.EndOfLife = eol.String()
+	}
+
+	stringer, ok := item.Resource.(resources.LegacyStringer)
+	if ok {
+		entry.ID = redactor.String(stringer.String())
+	}
+
+	getter, ok := item.Resource.(resources.ResourcePropertyGetter)
+	if ok {
+		entry.Properties = redactor.Properties(getter.Properties())
+	}
+
+	return entry
+}
+
+// reportStdout is where the report is written to, if no --output-file is
+// specified.
+var reportStdout io.Writer = os.Stdout
+
+// logOutput returns where the resources and messages of a run are printed.
+// It is stderr, if the report, the summary or the events are written to
+// stdout. Otherwise both would be mixed up and the report could not be
+// parsed.
+func logOutput(params *NukeParameters, c config.ReportStorage) io.Writer {
+	if len(params.stdoutFlags()) > 0 || (params.Output == "" && c.Backend == config.ReportBackendStdout) {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// stdoutFlags returns the flags, which write to stdout.
+func (p *NukeParameters) stdoutFlags() []string {
+	flags := []string{}
+	if p.Output != "" && p.OutputFile == "" {
+		flags = append(flags, "--output")
+	}
+	if p.Summary != "" && p.SummaryFile == "" && os.Getenv("GITHUB_STEP_SUMMARY") == "" {
+		flags = append(flags, "--summary")
+	}
+	if p.EventsFile == "-" {
+		flags = append(flags, "--events-file -")
+	}
+	return flags
+}
+
+// WriteReport writes the report to the storage of --output or the config, the
+// HTML report specified by --report-html, the summary specified by --summary
+// and the evidence specified by --evidence-s3-uri. It is a no-op, if none of
+// them was specified.
+func (n *Nuke) WriteReport() error {
+	if n.Parameters.Summary != "" {
+		err := n.writeSummary()
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.Parameters.ReportHTML != "" {
+		err := writeReportFile(n.reportRenderer("html"), n.Parameters.ReportHTML, n.Report())
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.Parameters.EvidenceURI != "" {
+		err := n.WriteEvidence()
+		if err != nil {
+			return err
+		}
+	}
+
+	storage, err := n.reportStorage()
+	if err != nil || storage == nil {
+		return err
+	}
+
+	return storage.Store(n.Report())
+}
+
+// summaryMu serializes the summaries of the runs of --all-accounts, which all
+// append to the same file.
+var summaryMu sync.Mutex
+
+// writeSummary appends the summary to the summary file, since CI systems like
+// GitHub Actions collect the summaries of multiple steps in the same file.
+func (n *Nuke) writeSummary() error {
+	summaryMu.Lock()
+	defer summaryMu.Unlock()
+
+	path := n.Parameters.SummaryFile
+	if path == "" {
+		path = os.Getenv("GITHUB_STEP_SUMMARY")
+	}
+
+	if path == "" {
+		return report.Write(n.Parameters.Summary, reportStdout, n.Report())
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = report.Write(n.Parameters.Summary, f, n.Report())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func writeReportFile(render reportRenderer, path string, r *report.Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = render(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
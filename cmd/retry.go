@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// LoadReport reads a report, which was written with --output json.
+func LoadReport(path string) (*report.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(report.Report)
+	err = json.Unmarshal(data, r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse report %s. Only reports in the json format can be retried: %v", path, err)
+	}
+
+	return r, nil
+}
+
+// retryPlan returns a plan with the failed resources of the report. Like a
+// plan, the resources are identified by their legacy string, if the report
+// has one, and by their properties otherwise. Reports with redacted values
+// are rejected, since they cannot identify the resources.
+func retryPlan(r *report.Report) (*Plan, error) {
+	if r.Redacted {
+		return nil, fmt.Errorf("The report was written with redaction and cannot be retried. " +
+			"Write the report of the previous run without a redaction section in the config.")
+	}
+
+	p := &Plan{
+		Version:   r.Version,
+		Config:    r.Config,
+		AccountID: r.AccountID,
+		Time:      r.Time,
+		Resources: []PlanResource{},
+	}
+
+	for _, e := range r.Entries {
+		if e.State != report.StateFailed {
+			continue
+		}
+
+		resource := PlanResource{
+			Region: e.Region,
+			Type:   e.Type,
+			ID:     e.ID,
+		}
+		if e.ID == "" {
+			resource.Properties = e.Properties
+		}
+		p.Resources = append(p.Resources, resource)
+	}
+
+	if len(p.Resources) == 0 {
+		return nil, fmt.Errorf("The report contains no failed resources.")
+	}
+
+	return p, nil
+}
+
+func NewRetryCommand(params *NukeParameters, creds *awsutil.Credentials, defaultRegion *string) *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "removes only the resources which failed in a previous run",
+		Long: `Removes only the resources, which failed in a previous run, based on its JSON report.
+Only the resource types and regions of the failed resources are scanned again, to verify they still exist.
+Resources which are not part of the report are never removed.
+Like the root command, it only lists the resources unless --no-dry-run is specified.`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := params.Validate()
+		if err != nil {
+			return err
+		}
+
+		if path == "" {
+			return fmt.Errorf("You have to specify the report of the previous run with --report.\n")
+		}
+
+		r, err := LoadReport(path)
+		if err != nil {
+			return err
+		}
+
+		plan, err := retryPlan(r)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		nuke, err := buildNuke(params, creds, *defaultRegion)
+		if err != nil {
+			return err
+		}
+
+		nuke.plan = plan
+		return nuke.Run()
+	}
+
+	cmd.Flags().StringVar(
+		&path, "report", "",
+		"Path of the JSON report of the previous run.")
+
+	return cmd
+}
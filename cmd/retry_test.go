@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestRetryPlan(t *testing.T) {
+	r := &report.Report{
+		AccountID: "123456789012",
+		Entries: []report.Entry{
+			{Region: "eu-west-1", Type: "EC2Instance", ID: "i-01b489457a60298dd",
+				Properties: map[string]string{"InstanceType": "t3.micro"}, State: report.StateFailed},
+			{Region: "global", Type: "IAMUserPolicyAttachment",
+				Properties: map[string]string{"UserName": "admin"}, State: report.StateFailed},
+			{Region: "eu-west-1", Type: "S3Bucket", ID: "s3://logs", State: report.StateFinished},
+			{Region: "eu-west-1", Type: "EC2VPC", ID: "vpc-42", State: report.StateFiltered},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = report.Write("json", f, r)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := retryPlan(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []PlanResource{
+		{Region: "eu-west-1", Type: "EC2Instance", ID: "i-01b489457a60298dd"},
+		{Region: "global", Type: "IAMUserPolicyAttachment", Properties: map[string]string{"UserName": "admin"}},
+	}
+	if !reflect.DeepEqual(plan.Resources, want) {
+		t.Errorf("Wrong resources.\nWant: %#v\nHave: %#v", want, plan.Resources)
+	}
+	if plan.AccountID != "123456789012" {
+		t.Errorf("Wrong account ID: %s", plan.AccountID)
+	}
+
+	if !reflect.DeepEqual(plan.RegionResourceTypes("eu-west-1"), types.Collection{"EC2Instance"}) {
+		t.Errorf("Wrong resource types of eu-west-1: %v", plan.RegionResourceTypes("eu-west-1"))
+	}
+	if len(plan.RegionResourceTypes("us-east-1")) != 0 {
+		t.Errorf("Expected no resource types for us-east-1: %v", plan.RegionResourceTypes("us-east-1"))
+	}
+
+	item := &Item{Region: &Region{Name: "eu-west-1"}, Type: "EC2Instance",
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	if !plan.Contains(item) {
+		t.Errorf("Expected the failed instance to be part of the plan.")
+	}
+}
+
+func TestRetryPlanWithoutFailures(t *testing.T) {
+	_, err := retryPlan(&report.Report{Entries: []report.Entry{
+		{Region: "eu-west-1", Type: "S3Bucket", ID: "s3://logs", State: report.StateFinished},
+	}})
+	if err == nil {
+		t.Errorf("Expected an error for a report without failed resources.")
+	}
+}
+
+func TestRetryPlanRedacted(t *testing.T) {
+	_, err := retryPlan(&report.Report{Redacted: true, Entries: []report.Entry{
+		{Region: "eu-west-1", Type: "S3Bucket", ID: "s3://<redacted>", State: report.StateFailed},
+	}})
+	if err == nil {
+		t.Errorf("Expected an error for a redacted report.")
+	}
+}
+
+func TestLoadReportInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+	err := os.WriteFile(path, []byte("region,type\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = LoadReport(path)
+	if err == nil {
+		t.Errorf("Expected an error for a report, which is not JSON.")
+	}
+}
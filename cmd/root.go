@@ -5,6 +5,7 @@ import (
 	"os"
 	"sort"
 
+	"github.com/fatih/color"
 	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/rebuy-de/aws-nuke/resources"
@@ -18,6 +19,14 @@ func NewRootCommand() *cobra.Command {
 		creds         awsutil.Credentials
 		defaultRegion string
 		verbose       bool
+		noColor       bool
+
+		logFilePath       string
+		logFileMaxSize    int
+		logFileMaxBackups int
+
+		profileCPU string
+		profileMem string
 	)
 
 	command := &cobra.Command{
@@ -26,11 +35,27 @@ func NewRootCommand() *cobra.Command {
 		Long:  `A tool which removes every resource from an AWS account.  Use it with caution, since it cannot distinguish between production and non-production.`,
 	}
 
-	command.PreRun = func(cmd *cobra.Command, args []string) {
-		log.SetLevel(log.InfoLevel)
+	command.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		params.Flags = usedFlags(cmd.Flags())
+
+		level := log.InfoLevel
 		if verbose {
-			log.SetLevel(log.DebugLevel)
+			level = log.DebugLevel
 		}
+		log.SetLevel(level)
+		if noColor {
+			color.NoColor = true
+			log.SetFormatter(&log.TextFormatter{DisableColors: true})
+		}
+
+		if logFilePath != "" {
+			err := setupLogFile(logFilePath, logFileMaxSize, logFileMaxBackups, level)
+			if err != nil {
+				return fmt.Errorf("Failed to open the log file %s: %v\n", logFilePath, err)
+			}
+		}
+
+		return startProfiling(profileCPU, profileMem)
 	}
 
 	command.RunE = func(cmd *cobra.Command, args []string) error {
@@ -43,21 +68,54 @@ func NewRootCommand() *cobra.Command {
 
 		command.SilenceUsage = true
 
+		// SIGUSR1 is handled for the whole command, so it does not kill the
+		// process before or between the runs of the accounts.
+		pause := NewPauseControl()
+		defer pause.HandleSignals()()
+
+		if params.AllAccounts || params.OrgDiscover {
+			return runAllAccounts(&params, &creds, defaultRegion, pause)
+		}
+
 		nuke, err := buildNuke(&params, &creds, defaultRegion)
 		if err != nil {
 			return err
 		}
 
+		nuke.pause = pause
 		return nuke.Run()
 	}
 
 	command.PersistentFlags().BoolVarP(
 		&verbose, "verbose", "v", false,
 		"Enables debug output.")
+	command.PersistentFlags().BoolVar(
+		&noColor, "no-color", os.Getenv("NO_COLOR") != "",
+		"Disables colored output. Defaults to true, if the NO_COLOR environment variable is set.")
+	command.PersistentFlags().StringVar(
+		&logFilePath, "log-file", "",
+		"If specified, all log messages including debug output and the printed resources "+
+			"are appended to this file, regardless of --verbose.")
+	command.PersistentFlags().IntVar(
+		&logFileMaxSize, "log-file-max-size", 100,
+		"Size in megabytes after which the --log-file is rotated. 0 disables the rotation.")
+	command.PersistentFlags().IntVar(
+		&logFileMaxBackups, "log-file-max-backups", 3,
+		"Number of rotated --log-file files to keep.")
+	command.PersistentFlags().StringVar(
+		&profileCPU, "profile-cpu", "",
+		"If specified, a pprof CPU profile of the whole run is written to this file.")
+	command.PersistentFlags().StringVar(
+		&profileMem, "profile-mem", "",
+		"If specified, a pprof heap profile is written to this file at the end of the run.")
 
 	command.PersistentFlags().StringVarP(
 		&params.ConfigPath, "config", "c", "",
 		"(required) Path to the nuke config file.")
+	command.PersistentFlags().StringVar(
+		&params.ConfigRevision, "config-revision", "",
+		"Git commit SHA of the repository containing the config. "+
+			"It is recorded in the reports and the log header, so audits can reconstruct the run.")
 
 	command.PersistentFlags().StringVar(
 		&creds.Profile, "profile", "",
@@ -78,9 +136,47 @@ func NewRootCommand() *cobra.Command {
 		"AWS session token for accessing the AWS API. "+
 			"Must be used together with --access-key-id and --secret-access-key. "+
 			"Cannot be used together with --profile.")
+	command.PersistentFlags().StringVar(
+		&creds.CredentialCommand, "credential-command", "",
+		"Command which prints the credentials for accessing the AWS API as JSON, "+
+			"in the same format as the credential_process setting of AWS profiles. "+
+			"Cannot be used together with --profile or --access-key-id.")
+	command.PersistentFlags().StringVar(
+		&creds.AssumeRole.RoleARN, "assume-role-arn", "",
+		"ARN of a role in the target account, which is assumed with the given credentials. "+
+			"Overrides the assume-role setting of the config.")
+	command.PersistentFlags().StringVar(
+		&creds.AssumeRole.ExternalID, "assume-role-external-id", "",
+		"External ID, which is required by the trust policy of the --assume-role-arn.")
+	command.PersistentFlags().StringVar(
+		&creds.AssumeRole.SessionName, "assume-role-session-name", "",
+		"Session name of the --assume-role-arn, which shows up in CloudTrail. Defaults to aws-nuke.")
+	command.PersistentFlags().StringVar(
+		&creds.AssumeRole.SourceIdentity, "assume-role-source-identity", "",
+		"Source identity of the --assume-role-arn session (eg jenkins), which shows up in CloudTrail "+
+			"and persists through role chaining.")
+	command.PersistentFlags().StringToStringVar(
+		&creds.AssumeRole.SessionTags, "assume-role-tag", nil,
+		"Session tag of the --assume-role-arn session (eg initiator=jenkins,ticket=OPS-1234). "+
+			"Can be specified multiple times and extends the session-tags of the config.")
+	command.PersistentFlags().StringVar(
+		&creds.MFASerial, "mfa-serial", "",
+		"ARN or serial number of the MFA device, which is required to assume the --assume-role-arn "+
+			"or the first role of the role-chain.")
+	command.PersistentFlags().StringVar(
+		&creds.MFAToken, "mfa-token", "",
+		"Current code of the MFA device. If it is not specified or the credentials have to be refreshed, "+
+			"the code is read from stdin.")
 	command.PersistentFlags().StringVar(
 		&defaultRegion, "default-region", "",
 		"Custom default region name.")
+	command.PersistentFlags().BoolVar(
+		&creds.UseFIPSEndpoints, "use-fips-endpoints", false,
+		"Use the FIPS 140-2 validated endpoints of the AWS services.")
+	command.PersistentFlags().StringVar(
+		&creds.CABundle, "ca-bundle", "",
+		"Path to a PEM file with additional certificate authorities to trust, "+
+			"eg for TLS intercepting proxies. Defaults to the AWS_CA_BUNDLE environment variable.")
 
 	command.PersistentFlags().StringSliceVarP(
 		&params.Targets, "target", "t", []string{},
@@ -94,6 +190,9 @@ func NewRootCommand() *cobra.Command {
 		&params.NoDryRun, "no-dry-run", false,
 		"If specified, it actually deletes found resources. "+
 			"Otherwise it just lists all candidates.")
+	command.PersistentFlags().BoolVar(
+		&params.OverrideWindow, "override-window", false,
+		"Allows runs with --no-dry-run outside of the allowed-run-windows of the config.")
 	command.PersistentFlags().BoolVar(
 		&params.Force, "force", false,
 		"Don't ask for confirmation before deleting resources. "+
@@ -106,13 +205,137 @@ func NewRootCommand() *cobra.Command {
 		&params.MaxWaitRetries, "max-wait-retries", 0,
 		"If specified, the program will exit if resources are stuck in waiting for this many iterations. "+
 			"0 (default) disables early exit.")
+	command.Flags().BoolVar(
+		&params.AllAccounts, "all-accounts", false,
+		"Runs for every account of the accounts section of the config one after another. "+
+			"The account-role of the config is assumed into each of them.")
+	command.Flags().BoolVar(
+		&params.OrgDiscover, "org-discover", false,
+		"Like --all-accounts, but runs for the active accounts of the organization, which match the org-discovery of the config.")
+	command.Flags().IntVar(
+		&params.AccountConcurrency, "account-concurrency", 1,
+		"The number of accounts of --all-accounts or --org-discover, which are nuked at the same time. "+
+			"Requires --force, if greater than 1.")
+	command.PersistentFlags().StringSliceVar(
+		&params.ExpectedAccountIDs, "expected-account-id", []string{},
+		"If specified, the run aborts before the scan unless the credentials belong to this account ID, "+
+			"regardless of the accounts in the config. Can be specified multiple times to allow any of them.")
+	command.PersistentFlags().BoolVar(
+		&params.AllowManagementAccount, "allow-management-account", false,
+		"Allows to run against the management account of an AWS organization, "+
+			"which is refused by default.")
+	command.PersistentFlags().StringVar(
+		&params.RunProfile, "run-profile", "",
+		"Name of the run profile from the config, which selects the resource types and "+
+			"settings of this run (eg nightly-cheap).")
+	command.PersistentFlags().StringVar(
+		&params.SweepByTag, "sweep-by-tag", "",
+		"If specified, only resources with this tag (key=value or just key) are removed, "+
+			"eg to clean up the environment of a pull request.")
 	command.PersistentFlags().BoolVarP(
 		&params.Quiet, "quiet", "q", false,
 		"Don't show filtered resources.")
+	command.PersistentFlags().StringSliceVar(
+		&params.Show, "show", []string{},
+		"If specified, only resources in these states are printed (eg failed,removed). "+
+			"Supported states are new, pending, waiting, failed, filtered and finished. "+
+			"The counts per resource type and the summaries are printed regardless.")
+	command.PersistentFlags().BoolVar(
+		&params.LookupOwner, "lookup-owner", false,
+		"If specified, the creator of every resource which would be removed is looked up "+
+			"in the CloudTrail event history and shown next to the resource.")
+	command.PersistentFlags().BoolVar(
+		&params.DeepDryRun, "deep-dry-run", false,
+		"If specified, a dry run checks known blockers like deletion protection or object lock "+
+			"and marks the resources whose removal would fail.")
+	command.PersistentFlags().BoolVar(
+		&params.Preflight, "preflight", false,
+		"If specified, the permissions are checked with one list request per service before the scan "+
+			"and the run aborts with a list of all services with missing permissions.")
+	command.PersistentFlags().StringVar(
+		&params.StateFile, "state", "",
+		"If specified, the progress of the run is written to this file, "+
+			"so it can be continued with --resume if it gets interrupted.")
+	command.PersistentFlags().BoolVar(
+		&params.Resume, "resume", false,
+		"If specified, the run continues from the --state file of an interrupted run "+
+			"and only scans resource types which still had resources to remove.")
+	command.PersistentFlags().IntVar(
+		&params.Sample, "sample", 0,
+		"If specified, at most this many resources per resource type are removed, "+
+			"eg to validate a new config with a small blast radius.")
+	command.PersistentFlags().DurationVar(
+		&params.OnlyIdle, "only-idle", 0,
+		"If specified, only resources without activity within this duration (eg 720h) are removed. "+
+			"Resource types which cannot tell their last activity are filtered.")
+	command.PersistentFlags().StringVar(
+		&params.Output, "output", "",
+		"If specified, a machine readable report of all scanned resources and their final state "+
+			"is written in this format at the end of the run (eg csv, html, json, junit, sarif).")
+	command.PersistentFlags().StringVar(
+		&params.OutputFile, "output-file", "",
+		"Path of the file the --output report is written to. "+
+			"Defaults to stdout.")
+	command.PersistentFlags().StringVar(
+		&params.ReportHTML, "report-html", "",
+		"If specified, a self-contained HTML report of all scanned resources, their final state, "+
+			"failures and timings is written to this file at the end of the run.")
+	command.PersistentFlags().StringVar(
+		&params.Summary, "summary", "",
+		"If specified, a compact summary in this format (markdown) is written at the end of the run. "+
+			"It is appended to --summary-file, $GITHUB_STEP_SUMMARY or written to stdout.")
+	command.PersistentFlags().StringVar(
+		&params.SummaryFile, "summary-file", "",
+		"Path of the file the --summary is appended to. "+
+			"Defaults to $GITHUB_STEP_SUMMARY, if set, and stdout otherwise.")
+	command.PersistentFlags().StringVar(
+		&params.EvidenceURI, "evidence-s3-uri", "",
+		"If specified, the removed and failed resources are written to this S3 location "+
+			"(eg s3://audit-bucket/aws-nuke) at the end of the run, partitioned by account, date "+
+			"and resource type for Athena.")
+	command.PersistentFlags().StringVar(
+		&params.EncryptReportKMSKey, "encrypt-report-kms-key", "",
+		"If specified, the reports are encrypted in the age format with this KMS key (ID, alias or ARN). "+
+			"Use 'aws-nuke decrypt-report' to read them. The summary and the events file are not encrypted.")
+	command.PersistentFlags().StringVar(
+		&params.EncryptReportRecipients, "encrypt-report-recipients", "",
+		"If specified, the reports are encrypted in the age format for the age or SSH public keys "+
+			"in this file, one per line. Use 'aws-nuke decrypt-report --identity' or 'age -d' to read them. "+
+			"The summary and the events file are not encrypted.")
+	command.PersistentFlags().StringVar(
+		&params.StatusAddr, "status-addr", "",
+		"If specified, the live state of the run is served as JSON on this address "+
+			"(eg localhost:8080) under the path /status.")
+	command.PersistentFlags().StringVar(
+		&params.StatusControlToken, "status-control-token", os.Getenv("AWS_NUKE_STATUS_CONTROL_TOKEN"),
+		"If specified, the removal can be paused and resumed via POST /pause and POST /resume of the --status-addr "+
+			"with this bearer token. Defaults to the AWS_NUKE_STATUS_CONTROL_TOKEN environment variable.")
+	command.PersistentFlags().StringVar(
+		&params.PublishTopic, "publish-sns-topic", "",
+		"If specified, the result of every removal is published as JSON to the SNS topic with this ARN.")
+	command.PersistentFlags().StringVar(
+		&params.PublishQueue, "publish-sqs-queue", "",
+		"If specified, the result of every removal is sent as JSON to the SQS queue with this URL.")
+	command.PersistentFlags().BoolVar(
+		&params.Progress, "progress", false,
+		"If specified, the progress of the scan and the removal is shown with an ETA on stderr. "+
+			"If stderr is not a terminal, a progress line is printed every 30 seconds instead.")
+	command.PersistentFlags().StringVar(
+		&params.EventsFile, "events-file", "",
+		"If specified, an event per state change of every resource is streamed "+
+			"as newline delimited JSON to this file (eg events.ndjson). Use - to stream them to stdout, "+
+			"the printed resources are moved to stderr then.")
 
 	command.AddCommand(NewVersionCommand())
 	command.AddCommand(NewResourceTypesCommand())
 	command.AddCommand(NewAccountBlueprintCommand(&params, &creds, defaultRegion))
+	command.AddCommand(NewPlanCommand(&params, &creds, &defaultRegion))
+	command.AddCommand(NewApplyCommand(&params, &creds, &defaultRegion))
+	command.AddCommand(NewRetryCommand(&params, &creds, &defaultRegion))
+	command.AddCommand(NewDecryptReportCommand(&creds))
+	command.AddCommand(NewConfigCommand(&params))
+	command.AddCommand(NewExplainCommand())
+	command.AddCommand(NewIAMPolicyCommand(&params))
 
 	return command
 }
@@ -170,23 +393,52 @@ func NewAccountBlueprintCommand(params *NukeParameters, creds *awsutil.Credentia
 }
 
 func buildNuke(params *NukeParameters, creds *awsutil.Credentials, defaultRegion string) (*Nuke, error) {
-	if !creds.HasKeys() && !creds.HasProfile() && defaultRegion != "" {
+	if !creds.HasKeys() && !creds.HasProfile() && !creds.HasCredentialCommand() && !creds.HasWebIdentity() && defaultRegion != "" {
 		creds.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
 		creds.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
 	}
-	err := creds.Validate()
+
+	config, err := config.Load(params.ConfigPath)
 	if err != nil {
+		log.Errorf("Failed to parse config file %s", params.ConfigPath)
 		return nil, err
 	}
 
-	config, err := config.Load(params.ConfigPath)
+	applyConfigCredentials(creds, config)
+
+	if params.AccountID != "" {
+		err = useAccountRole(params, creds, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = creds.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.CheckCompatibility(BuildVersion)
 	if err != nil {
-		log.Errorf("Failed to parse config file %s", params.ConfigPath)
 		return nil, err
 	}
 
+	out, err := NewOutput(config.Redaction, params.Show)
+	if err != nil {
+		return nil, err
+	}
+
+	err = prepareReportStorage(config.Report)
+	if err != nil {
+		return nil, err
+	}
+	out.Writer = logOutput(params, config.Report)
+	if params.AccountID != "" {
+		out.useAccountPrefix(params.AccountID)
+	}
+
 	if defaultRegion != "" {
-		awsutil.DefaultRegionID = defaultRegion
+		creds.DefaultRegion = defaultRegion
 		if config.CustomEndpoints.GetRegion(defaultRegion) == nil {
 			err = fmt.Errorf("The custom region '%s' must be specified in the configuration 'endpoints'", defaultRegion)
 			log.Error(err.Error())
@@ -194,14 +446,74 @@ func buildNuke(params *NukeParameters, creds *awsutil.Credentials, defaultRegion
 		}
 	}
 
+	profile, err := config.RunProfile(params.RunProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.MaxWaitRetries == 0 {
+		params.MaxWaitRetries = profile.MaxWaitRetries
+	}
+
+	creds.Proxy = config.Proxy
+	creds.HTTPClient = config.HTTPClient
+
+	configSHA256, err := fileSHA256(params.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
 	account, err := awsutil.NewAccount(*creds, config.CustomEndpoints)
 	if err != nil {
 		return nil, err
 	}
 
+	if params.AccountID != "" && account.ID() != params.AccountID {
+		return nil, fmt.Errorf("The account-role for the account %s resolved to the account %s.",
+			params.AccountID, account.ID())
+	}
+
 	n := NewNuke(*params, *account)
 
 	n.Config = config
+	n.Profile = profile
+	n.configSHA256 = configSHA256
+	n.out = out
+	n.cloudControlTypes = config.CloudControlTypes(account.ID(), profile)
+
+	n.sweep, err = ParseTagSweep(params.SweepByTag)
+	if err != nil {
+		return nil, err
+	}
 
 	return n, nil
 }
+
+// applyConfigCredentials adds the assume-role and the role-chain of the config
+// to the credentials. The --assume-role-* flags take precedence.
+func applyConfigCredentials(creds *awsutil.Credentials, c *config.Nuke) {
+	if creds.AssumeRole.RoleARN == "" {
+		creds.AssumeRole.RoleARN = c.AssumeRole.RoleARN
+	}
+	if creds.AssumeRole.ExternalID == "" {
+		creds.AssumeRole.ExternalID = c.AssumeRole.ExternalID
+	}
+	if creds.AssumeRole.SessionName == "" {
+		creds.AssumeRole.SessionName = c.AssumeRole.SessionName
+	}
+	if creds.AssumeRole.SourceIdentity == "" {
+		creds.AssumeRole.SourceIdentity = c.AssumeRole.SourceIdentity
+	}
+	for key, value := range c.AssumeRole.SessionTags {
+		if creds.AssumeRole.SessionTags == nil {
+			creds.AssumeRole.SessionTags = map[string]string{}
+		}
+		if _, ok := creds.AssumeRole.SessionTags[key]; !ok {
+			creds.AssumeRole.SessionTags[key] = value
+		}
+	}
+	if len(creds.AssumeRole.TransitiveTagKeys) == 0 {
+		creds.AssumeRole.TransitiveTagKeys = c.AssumeRole.TransitiveTagKeys
+	}
+	creds.RoleChain = c.RoleChain
+}
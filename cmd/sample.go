@@ -0,0 +1,33 @@
+package cmd
+
+import "fmt"
+
+// resourceSampler limits the number of resources per type, which are removed
+// with --sample.
+type resourceSampler struct {
+	limit  int
+	counts map[string]int
+}
+
+func newResourceSampler(limit int) *resourceSampler {
+	return &resourceSampler{
+		limit:  limit,
+		counts: map[string]int{},
+	}
+}
+
+// Apply filters the item, if the limit of its resource type is already
+// reached. Only items which would be removed count towards the limit.
+func (s *resourceSampler) Apply(item *Item) {
+	if item.State != ItemStateNew {
+		return
+	}
+
+	if s.counts[item.Type] >= s.limit {
+		item.State = ItemStateFiltered
+		item.Reason = fmt.Sprintf("exceeds sample of %d per resource type", s.limit)
+		return
+	}
+
+	s.counts[item.Type]++
+}
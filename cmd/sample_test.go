@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestResourceSampler(t *testing.T) {
+	sampler := newResourceSampler(2)
+	resource := &testResource{props: types.NewProperties()}
+
+	cases := []struct {
+		resourceType string
+		state        ItemState
+		want         ItemState
+	}{
+		{"EC2Instance", ItemStateNew, ItemStateNew},
+		{"EC2Instance", ItemStateFiltered, ItemStateFiltered},
+		{"EC2Instance", ItemStateNew, ItemStateNew},
+		{"S3Bucket", ItemStateNew, ItemStateNew},
+		{"EC2Instance", ItemStateNew, ItemStateFiltered},
+	}
+
+	for i, tc := range cases {
+		item := &Item{Type: tc.resourceType, State: tc.state, Resource: resource}
+		sampler.Apply(item)
+		if item.State != tc.want {
+			t.Errorf("Wrong state of item %d. Want: %v. Have: %v", i, tc.want, item.State)
+		}
+	}
+}
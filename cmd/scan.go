@@ -4,29 +4,67 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"sort"
 
 	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
 	"github.com/rebuy-de/aws-nuke/pkg/util"
 	"github.com/rebuy-de/aws-nuke/resources"
-	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/semaphore"
 )
 
 const ScannerParallelQueries = 16
 
-func Scan(region *Region, resourceTypes []string) <-chan *Item {
+// ErrorActionFunc returns the action of the error policies for a failed
+// request of the resource type.
+type ErrorActionFunc func(resourceType string, err error) string
+
+// ScanFailureFunc is called for every resource type, which could not be
+// listed, since its lister failed or panicked. The other resource types are
+// still listed.
+type ScanFailureFunc func(region, resourceType string, err error)
+
+// listerPanic is the error of a lister, which panicked.
+type listerPanic struct {
+	value interface{}
+	stack string
+}
+
+func (p listerPanic) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+// Scan lists the given resource types in the region. At most parallel
+// listers run at the same time, where 0 means ScannerParallelQueries. The
+// error channel yields the first listing error, which aborts the run
+// according to the error policies, after the item channel is closed. All
+// other failed listers are passed to failure, if set.
+func Scan(region *Region, resourceTypes []string, parallel int, errorAction ErrorActionFunc, failure ScanFailureFunc) (<-chan *Item, <-chan error) {
+	if parallel <= 0 {
+		parallel = ScannerParallelQueries
+	}
+
 	s := &scanner{
-		items:     make(chan *Item, 100),
-		semaphore: semaphore.NewWeighted(ScannerParallelQueries),
+		items:       make(chan *Item, 100),
+		errs:        make(chan error, 1),
+		parallel:    int64(parallel),
+		semaphore:   semaphore.NewWeighted(int64(parallel)),
+		errorAction: errorAction,
+		failure:     failure,
 	}
 	go s.run(region, resourceTypes)
 
-	return s.items
+	return s.items, s.errs
 }
 
 type scanner struct {
-	items     chan *Item
-	semaphore *semaphore.Weighted
+	items       chan *Item
+	errs        chan error
+	parallel    int64
+	semaphore   *semaphore.Weighted
+	errorAction ErrorActionFunc
+	failure     ScanFailureFunc
 }
 
 func (s *scanner) run(region *Region, resourceTypes []string) {
@@ -38,22 +76,26 @@ func (s *scanner) run(region *Region, resourceTypes []string) {
 	}
 
 	// Wait for all routines to finish.
-	s.semaphore.Acquire(ctx, ScannerParallelQueries)
+	s.semaphore.Acquire(ctx, s.parallel)
 
 	close(s.items)
+	close(s.errs)
 }
 
 func (s *scanner) list(region *Region, resourceType string) {
+	// The semaphore is released last, so the failure is recorded before the
+	// scan finishes.
+	defer s.semaphore.Release(1)
 	defer func() {
 		if r := recover(); r != nil {
-			err := fmt.Errorf("%v\n\n%s", r.(error), string(debug.Stack()))
-			dump := util.Indent(fmt.Sprintf("%v", err), "    ")
-			log.Errorf("Listing %s failed:\n%s", resourceType, dump)
+			err := listerPanic{value: r, stack: string(debug.Stack())}
+			dump := util.Indent(fmt.Sprintf("%v\n\n%s", err, err.stack), "    ")
+			region.output().Logger.Errorf("Listing %s failed:\n%s", resourceType, dump)
+			s.fail(region, resourceType, err)
 		}
 	}()
-	defer s.semaphore.Release(1)
 
-	lister := resources.GetLister(resourceType)
+	lister := region.Lister(resourceType)
 	var rs []resources.Resource
 	sess, err := region.Session(resourceType)
 	if err == nil {
@@ -62,18 +104,35 @@ func (s *scanner) list(region *Region, resourceType string) {
 	if err != nil {
 		_, ok := err.(awsutil.ErrSkipRequest)
 		if ok {
-			log.Debugf("skipping request: %v", err)
+			region.output().Logger.Debugf("skipping request: %v", err)
 			return
 		}
 
 		_, ok = err.(awsutil.ErrUnknownEndpoint)
 		if ok {
-			log.Warnf("skipping request: %v", err)
+			region.output().Logger.Warnf("skipping request: %v", err)
+			return
+		}
+
+		action := ""
+		if s.errorAction != nil {
+			action = s.errorAction(resourceType, err)
+		}
+
+		switch action {
+		case config.ErrorActionSkip:
+			region.output().Logger.Debugf("skipping %s by error policy: %v", resourceType, err)
 			return
+		case config.ErrorActionFail:
+			select {
+			case s.errs <- fmt.Errorf("Listing %s failed and the error policy aborts the run: %v", resourceType, err):
+			default:
+			}
 		}
 
 		dump := util.Indent(fmt.Sprintf("%v", err), "    ")
-		log.Errorf("Listing %s failed:\n%s", resourceType, dump)
+		region.output().Logger.Errorf("Listing %s failed:\n%s", resourceType, dump)
+		s.fail(region, resourceType, err)
 		return
 	}
 
@@ -86,3 +145,54 @@ func (s *scanner) list(region *Region, resourceType string) {
 		}
 	}
 }
+
+func (s *scanner) fail(region *Region, resourceType string, err error) {
+	if s.failure != nil {
+		s.failure(region.Name, resourceType, err)
+	}
+}
+
+// recordScanError is the ScanFailureFunc of the scan.
+func (n *Nuke) recordScanError(region, resourceType string, err error) {
+	_, panicked := err.(listerPanic)
+
+	n.scanErrorsLock.Lock()
+	defer n.scanErrorsLock.Unlock()
+
+	n.scanErrors = append(n.scanErrors, report.ScanError{
+		Region: region,
+		Type:   resourceType,
+		Error:  n.output().Redactor.String(err.Error()),
+		Panic:  panicked,
+	})
+}
+
+// ScanErrors returns the resource types, which could not be listed, sorted by
+// region and type.
+func (n *Nuke) ScanErrors() []report.ScanError {
+	n.scanErrorsLock.Lock()
+	defer n.scanErrorsLock.Unlock()
+
+	result := append([]report.ScanError{}, n.scanErrors...)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Region != result[j].Region {
+			return result[i].Region < result[j].Region
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+// printScanErrors lists the failed listers after the scan, since their
+// resources are neither shown nor removed and their errors are easily missed
+// among the printed resources.
+func printScanErrors(logger *Logger, errs []report.ScanError) {
+	if len(errs) == 0 {
+		return
+	}
+
+	logger.Warnf("%d resource types could not be listed. Their resources are not part of this run:", len(errs))
+	for _, e := range errs {
+		logger.Warnf("  %s - %s: %s", e.Region, e.Type, e.Error)
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+	"github.com/rebuy-de/aws-nuke/resources"
+)
+
+func TestScanIsolatesFailingListers(t *testing.T) {
+	n := &Nuke{}
+	region := NewRegion("eu-west-1",
+		func(regionName, resourceType string) string { return resourceType },
+		func(regionName, svcType string) (*session.Session, error) {
+			if svcType == "EC2Instance" {
+				return nil, fmt.Errorf("no credentials")
+			}
+			return session.NewSession()
+		})
+
+	// The unknown resource type has no lister, so listing it panics.
+	items, errs := Scan(region, []string{"EC2Instance", "UnknownResource"}, 1, nil, n.recordScanError)
+	for range items {
+		t.Error("Expected no items.")
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected no aborting error. Have: %v", err)
+	}
+
+	have := n.ScanErrors()
+	if len(have) != 2 {
+		t.Fatalf("Wrong number of scan errors. Want: 2. Have: %#v", have)
+	}
+
+	if have[0].Type != "EC2Instance" || have[0].Region != "eu-west-1" ||
+		have[0].Panic || have[0].Error != "no credentials" {
+		t.Errorf("Wrong scan error of the failed lister: %#v", have[0])
+	}
+
+	if have[1].Type != "UnknownResource" || !have[1].Panic {
+		t.Errorf("Wrong scan error of the panicking lister: %#v", have[1])
+	}
+}
+
+func TestListerPanic(t *testing.T) {
+	// Listers may panic with any value, not only errors.
+	err := listerPanic{value: 42}
+	if err.Error() != "panic: 42" {
+		t.Errorf("Wrong error. Have: %q", err.Error())
+	}
+}
+
+func TestCloudControlTypesPerAccount(t *testing.T) {
+	a := &Nuke{Config: &config.Nuke{}, cloudControlTypes: types.Collection{"AWS::ECR::PublicRepository"}}
+	b := &Nuke{Config: &config.Nuke{}}
+
+	contains := func(c types.Collection, name string) bool {
+		for _, t := range c {
+			if t == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !contains(a.resolveResourceTypes(), "AWS::ECR::PublicRepository") {
+		t.Errorf("Expected the Cloud Control type to be scanned in its account.")
+	}
+	if a.newRegion("eu-west-1").Lister("AWS::ECR::PublicRepository") == nil {
+		t.Errorf("Expected a lister for the Cloud Control type of the account.")
+	}
+
+	if contains(b.resolveResourceTypes(), "AWS::ECR::PublicRepository") {
+		t.Errorf("Expected the Cloud Control type of another account not to be scanned.")
+	}
+	if b.newRegion("eu-west-1").Lister("AWS::ECR::PublicRepository") != nil {
+		t.Errorf("Expected no lister for the Cloud Control type of another account.")
+	}
+	if resources.GetLister("AWS::ECR::PublicRepository") != nil {
+		t.Errorf("Expected the Cloud Control type not to be registered globally.")
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/resources"
+	log "github.com/sirupsen/logrus"
+)
+
+// scpValues is a string or a list of strings of a policy document.
+type scpValues []string
+
+func (v *scpValues) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*v = scpValues{single}
+		return nil
+	}
+
+	var list []string
+	err := json.Unmarshal(data, &list)
+	*v = scpValues(list)
+	return err
+}
+
+type scpStatement struct {
+	Effect    string          `json:"Effect"`
+	Action    scpValues       `json:"Action"`
+	NotAction scpValues       `json:"NotAction"`
+	Resource  scpValues       `json:"Resource"`
+	Condition json.RawMessage `json:"Condition"`
+}
+
+// scpStatements is a single statement or a list of statements.
+type scpStatements []scpStatement
+
+func (s *scpStatements) UnmarshalJSON(data []byte) error {
+	var single scpStatement
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		err := json.Unmarshal(data, &single)
+		*s = scpStatements{single}
+		return err
+	}
+
+	var list []scpStatement
+	err := json.Unmarshal(data, &list)
+	*s = scpStatements(list)
+	return err
+}
+
+type scpDocument struct {
+	Statement scpStatements `json:"Statement"`
+}
+
+// unconditional returns true, if the statement denies the actions for all
+// resources without any condition. Only these statements can be evaluated
+// without knowing the request.
+func (s scpStatement) unconditional() bool {
+	if s.Effect != "Deny" || len(s.NotAction) > 0 || len(s.Condition) > 0 {
+		return false
+	}
+
+	for _, resource := range s.Resource {
+		if resource == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// denies returns true, if the statement denies the IAM action.
+func (s scpStatement) denies(action string) bool {
+	if !s.unconditional() {
+		return false
+	}
+
+	for _, pattern := range s.Action {
+		match, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action))
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// isReadAction returns true, if the IAM action only reads, like the actions of
+// the listers do.
+func isReadAction(action string) bool {
+	parts := strings.SplitN(action, ":", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	for _, prefix := range []string{"List", "Describe", "Get"} {
+		if strings.HasPrefix(parts[1], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// scpBlockedTypes returns the resource types, whose removal is denied by an
+// unconditional deny of the SCPs, mapped to the reason. Conditional statements
+// are ignored, since they cannot be evaluated in advance.
+func scpBlockedTypes(policies []awsutil.ServiceControlPolicy, resourceTypes []string) map[string]string {
+	blocked := map[string]string{}
+
+	for _, policy := range policies {
+		doc := new(scpDocument)
+		err := json.Unmarshal([]byte(policy.Content), doc)
+		if err != nil {
+			log.Debugf("Failed to parse the SCP %s: %v", policy.ID, err)
+			continue
+		}
+
+		for _, resourceType := range resourceTypes {
+			if blocked[resourceType] != "" {
+				continue
+			}
+
+			meta, ok := resources.GetMetadata(resourceType)
+			if !ok {
+				continue
+			}
+
+			for _, action := range meta.Actions {
+				if isReadAction(action) {
+					continue
+				}
+
+				for _, statement := range doc.Statement {
+					if statement.denies(action) {
+						blocked[resourceType] = fmt.Sprintf("blocked by SCP: %s (%s) denies %s",
+							policy.Name, policy.ID, action)
+						break
+					}
+				}
+
+				if blocked[resourceType] != "" {
+					break
+				}
+			}
+		}
+	}
+
+	return blocked
+}
+
+// isSCPDenied returns true, if a service control policy denied the request.
+func isSCPDenied(err error) bool {
+	return isAccessDenied(err) && strings.Contains(strings.ToLower(err.Error()), "service control policy")
+}
+
+// checkServiceControlPolicies marks the resource types as blocked, whose
+// removal is denied by the SCPs of the account. It only warns, if the
+// policies cannot be read, which is the normal case for member accounts.
+func (n *Nuke) checkServiceControlPolicies(resourceTypes []string) {
+	policies, err := n.Account.ServiceControlPolicies()
+	if err != nil {
+		n.output().Logger.Debugf("Failed to read the service control policies of the account %s: %v", n.Account.ID(), err)
+		return
+	}
+
+	for resourceType, reason := range scpBlockedTypes(policies, resourceTypes) {
+		n.blockSCP("", resourceType, reason)
+	}
+
+	if len(n.scpBlocked) > 0 {
+		n.output().Logger.Warnf("The service control policies deny the removal of %d resource types.", len(n.scpBlocked))
+	}
+}
+
+// blockSCP marks the resource type as blocked by an SCP. An empty region
+// blocks the type in all regions.
+func (n *Nuke) blockSCP(region, resourceType, reason string) {
+	if n.scpBlocked == nil {
+		n.scpBlocked = map[string]string{}
+	}
+	n.scpBlocked[region+"/"+resourceType] = reason
+}
+
+// scpReason returns the reason, why the removal of the item is blocked by an
+// SCP. It is empty, if the removal is not blocked.
+func (n *Nuke) scpReason(item *Item) string {
+	reason, ok := n.scpBlocked["/"+item.Type]
+	if ok {
+		return reason
+	}
+	return n.scpBlocked[item.Region.Name+"/"+item.Type]
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestSCPBlockedTypes(t *testing.T) {
+	policies := []awsutil.ServiceControlPolicy{
+		{ID: "p-full", Name: "FullAWSAccess", Content: `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`},
+		{ID: "p-protect", Name: "ProtectLogs", Content: `{
+			"Version": "2012-10-17",
+			"Statement": [
+				{"Effect": "Deny", "Action": ["s3:Delete*"], "Resource": "*"},
+				{"Effect": "Deny", "Action": "sns:DeleteTopic", "Resource": "*",
+				 "Condition": {"StringNotLike": {"aws:PrincipalArn": "arn:aws:iam::*:role/admin"}}},
+				{"Effect": "Deny", "Action": "sqs:*", "Resource": "arn:aws:sqs:*:*:audit-*"}
+			]
+		}`},
+		{ID: "p-broken", Name: "Broken", Content: `{`},
+	}
+
+	blocked := scpBlockedTypes(policies, []string{"S3Bucket", "SNSTopic", "SQSQueue", "Unknown"})
+
+	if len(blocked) != 1 {
+		t.Fatalf("Expected only S3Bucket to be blocked. Have: %v", blocked)
+	}
+	want := "blocked by SCP: ProtectLogs (p-protect) denies s3:DeleteBucket"
+	if blocked["S3Bucket"] != want {
+		t.Errorf("Wrong reason. Want: %q. Have: %q", want, blocked["S3Bucket"])
+	}
+}
+
+func TestIsSCPDenied(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{awserr.New("AccessDenied", "User: arn:aws:sts::123456789012:assumed-role/nuke/aws-nuke is not authorized to perform: "+
+			"s3:DeleteBucket on resource: arn:aws:s3:::logs with an explicit deny in a service control policy", nil), true},
+		{awserr.New("AccessDenied", "User is not authorized to perform: s3:DeleteBucket", nil), false},
+		{errors.New("service control policy"), false},
+		{nil, false},
+	}
+
+	for _, tc := range cases {
+		have := isSCPDenied(tc.err)
+		if have != tc.want {
+			t.Errorf("Wrong result for %v. Want: %t. Have: %t", tc.err, tc.want, have)
+		}
+	}
+}
+
+func TestHandleErrorBlocksSCP(t *testing.T) {
+	region := &Region{Name: "eu-west-1"}
+	newItem := func(regionName string) *Item {
+		return &Item{
+			Region:   &Region{Name: regionName},
+			Type:     "S3Bucket",
+			State:    ItemStateNew,
+			Resource: &testResource{props: types.NewProperties()},
+		}
+	}
+
+	n := &Nuke{}
+	denied := awserr.New("AccessDenied", "explicit deny in a service control policy", nil)
+
+	item := newItem(region.Name)
+	n.handleError(item, denied)
+	if item.State != ItemStateFiltered || !strings.HasPrefix(item.Reason, "blocked by SCP") {
+		t.Fatalf("Expected the item to be blocked. Have: %v %q", item.State, item.Reason)
+	}
+
+	other := newItem(region.Name)
+	other.State = ItemStateFailed
+	n.HandleRemove(other)
+	if other.State != ItemStateFiltered || other.Reason != item.Reason {
+		t.Errorf("Expected the other item of the type to be blocked. Have: %v %q", other.State, other.Reason)
+	}
+
+	elsewhere := newItem("us-east-1")
+	n.HandleRemove(elsewhere)
+	if elsewhere.State != ItemStatePending {
+		t.Errorf("Expected the item in another region to be removed. Have: %v %q", elsewhere.State, elsewhere.Reason)
+	}
+}
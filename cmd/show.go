@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// showStateNames maps the values of --show to the item states. Besides the
+// state names, it accepts the words of the printed lines and the summary.
+var showStateNames = map[string]ItemState{
+	"new":      ItemStateNew,
+	"pending":  ItemStatePending,
+	"waiting":  ItemStateWaiting,
+	"failed":   ItemStateFailed,
+	"filtered": ItemStateFiltered,
+	"skipped":  ItemStateFiltered,
+	"finished": ItemStateFinished,
+	"removed":  ItemStateFinished,
+}
+
+// ParseShowStates parses the values of --show. It returns nil, if no values
+// are given.
+func ParseShowStates(values []string) (map[ItemState]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	result := map[ItemState]bool{}
+	for _, value := range values {
+		state, ok := showStateNames[strings.ToLower(strings.TrimSpace(value))]
+		if !ok {
+			return nil, fmt.Errorf("Unsupported value '%s' for --show. "+
+				"Supported states are: new, pending, waiting, failed, filtered, finished.\n", value)
+		}
+		result[state] = true
+	}
+
+	return result, nil
+}
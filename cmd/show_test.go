@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseShowStates(t *testing.T) {
+	have, err := ParseShowStates([]string{"failed", " Removed", "skipped"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[ItemState]bool{
+		ItemStateFailed:   true,
+		ItemStateFinished: true,
+		ItemStateFiltered: true,
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("Wrong states. Want: %v. Have: %v", want, have)
+	}
+
+	have, err = ParseShowStates(nil)
+	if err != nil || have != nil {
+		t.Errorf("No values should show every state. Have: %v, %v", have, err)
+	}
+
+	_, err = ParseShowStates([]string{"deleted"})
+	if err == nil {
+		t.Errorf("Expected error for unknown state.")
+	}
+}
+
+func TestShown(t *testing.T) {
+	out := &Output{}
+	if !out.shown(ItemStateWaiting) {
+		t.Errorf("Every state should be shown by default.")
+	}
+
+	out = &Output{States: map[ItemState]bool{ItemStateFailed: true}}
+	if !out.shown(ItemStateFailed) {
+		t.Errorf("Failed items should be shown.")
+	}
+	if out.shown(ItemStateWaiting) {
+		t.Errorf("Waiting items should not be shown.")
+	}
+
+	// The outputs of concurrent runs do not affect each other.
+	if !defaultOutput.shown(ItemStateWaiting) {
+		t.Errorf("Every state should be shown by the default output.")
+	}
+}
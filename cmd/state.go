@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// RunState is the progress of a run, which is written to the --state file.
+// With --resume, an interrupted run continues from it.
+type RunState struct {
+	Version   string    `json:"version"`
+	AccountID string    `json:"account-id"`
+	Time      time.Time `json:"time"`
+
+	Resources []StateResource `json:"resources"`
+}
+
+// StateResource is a single resource of the state file. It is identified
+// like the resources of a plan.
+type StateResource struct {
+	PlanResource
+	State string `json:"state"`
+}
+
+// pending returns true, if the resource still has to be removed.
+func (r StateResource) pending() bool {
+	return r.State != report.StateFinished && r.State != report.StateFiltered
+}
+
+// State returns the current state of all items.
+func (n *Nuke) State() *RunState {
+	s := &RunState{
+		Version:   BuildVersion,
+		AccountID: n.Account.ID(),
+		Time:      time.Now(),
+		Resources: make([]StateResource, 0, len(n.items)),
+	}
+
+	for _, item := range n.items {
+		s.Resources = append(s.Resources, StateResource{
+			PlanResource: newPlanResource(item),
+			State:        item.State.String(),
+		})
+	}
+
+	return s
+}
+
+// SaveState writes the current state to the file specified by --state. It is
+// a no-op, if no file was specified. The file is replaced atomically, so it
+// stays intact if the process is killed while writing.
+func (n *Nuke) SaveState() error {
+	path := n.Parameters.StateFile
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(n.State(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(append(data, '\n'))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// saveState is like SaveState, but only warns about errors, since a missing
+// state file should not abort the run.
+func (n *Nuke) saveState() {
+	err := n.SaveState()
+	if err != nil {
+		n.output().Logger.Warnf("Failed to write the state file: %v", err)
+	}
+}
+
+// LoadRunState reads a state file, which was written by SaveState. It returns
+// nil, if the file does not exist.
+func LoadRunState(path string) (*RunState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(RunState)
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse state file %s: %v", path, err)
+	}
+
+	return s, nil
+}
+
+// PendingTypes returns the resource types of the region, which still have
+// resources to remove. Only these need to be scanned again. Resources of these
+// types, which are found again, are removed again, even if the interrupted run
+// already finished them, since they evidently still exist.
+func (s *RunState) PendingTypes(region string) types.Collection {
+	result := types.Collection{}
+	for _, r := range s.Resources {
+		if r.Region == region && r.pending() {
+			result = result.Union(types.Collection{r.Type})
+		}
+	}
+	return result
+}
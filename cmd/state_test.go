@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestRunStateRoundTrip(t *testing.T) {
+	region := &Region{Name: "eu-west-1"}
+	finished := &Item{Region: region, Type: "EC2Instance", State: ItemStateFinished,
+		Resource: &stringerTestResource{id: "i-01b489457a60298dd"}}
+	failed := &Item{Region: region, Type: "EC2Instance", State: ItemStateFailed,
+		Resource: &stringerTestResource{id: "i-0b0f4b4e8a0a7f3c1"}}
+	filtered := &Item{Region: region, Type: "S3Bucket", State: ItemStateFiltered,
+		Resource: &stringerTestResource{id: "s3://logs"}}
+	waiting := &Item{Region: &Region{Name: "global"}, Type: "IAMRole", State: ItemStateWaiting,
+		Resource: &stringerTestResource{id: "admin"}}
+
+	path := filepath.Join(t.TempDir(), "nuke.state")
+	n := &Nuke{
+		Parameters: NukeParameters{StateFile: path},
+		items:      Queue{finished, failed, filtered, waiting},
+	}
+
+	state, err := LoadRunState(path)
+	if err != nil || state != nil {
+		t.Fatalf("Expected no state for a missing file. Got %v, %v", state, err)
+	}
+
+	err = n.SaveState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = LoadRunState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]types.Collection{
+		"eu-west-1": {"EC2Instance"},
+		"global":    {"IAMRole"},
+		"us-east-1": {},
+	}
+	for region, want := range cases {
+		have := state.PendingTypes(region)
+		if len(have) != len(want) || (len(want) > 0 && have[0] != want[0]) {
+			t.Errorf("Wrong pending types for %s. Want: %v. Have: %v", region, want, have)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	log "github.com/sirupsen/logrus"
+)
+
+const statusRecentFailures = 20
+
+// Phases of a run as reported by the status API.
+const (
+	PhaseStarting = "starting"
+	PhaseScanning = "scanning"
+	PhaseRemoving = "removing"
+	PhaseDone     = "done"
+)
+
+// RunStatus is a snapshot of the state of a run.
+type RunStatus struct {
+	Phase          string                  `json:"phase"`
+	AccountID      string                  `json:"account-id"`
+	Iteration      int                     `json:"iteration"`
+	Counts         map[string]int          `json:"counts"`
+	RecentFailures []StatusFailure         `json:"recent-failures"`
+	Throttling     []awsutil.ThrottleCount `json:"throttling"`
+	Paused         bool                    `json:"paused"`
+	UpdatedAt      time.Time               `json:"updated-at"`
+}
+
+type StatusFailure struct {
+	Region string `json:"region"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// StatusServer serves the latest published RunStatus via HTTP. The run loop
+// publishes snapshots, so the handlers never access the queue concurrently.
+type StatusServer struct {
+	lock   sync.RWMutex
+	status RunStatus
+
+	// pause is controlled via POST /pause and POST /resume. These are only
+	// served, if the token is set, and require it as bearer token, since
+	// the status API has no other authentication.
+	pause *PauseControl
+	token string
+
+	server *http.Server
+}
+
+func NewStatusServer(pause *PauseControl, token string) *StatusServer {
+	s := &StatusServer{
+		pause: pause,
+		token: token,
+		status: RunStatus{
+			Phase:          PhaseStarting,
+			Counts:         map[string]int{},
+			RecentFailures: []StatusFailure{},
+			Throttling:     []awsutil.ThrottleCount{},
+			UpdatedAt:      time.Now(),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	if token != "" {
+		mux.HandleFunc("/pause", s.handlePause)
+		mux.HandleFunc("/resume", s.handleResume)
+	}
+	s.server = &http.Server{Handler: mux}
+
+	return s
+}
+
+// Start listens on the address and serves the status API in the background.
+func (s *StatusServer) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("serving status API on http://%s/status", listener.Addr())
+
+	go func() {
+		err := s.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("status API failed: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *StatusServer) Close() error {
+	return s.server.Close()
+}
+
+// Publish replaces the served status.
+func (s *StatusServer) Publish(status RunStatus) {
+	status.UpdatedAt = time.Now()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.status = status
+}
+
+// Status returns the latest published status.
+func (s *StatusServer) Status() RunStatus {
+	s.lock.RLock()
+	status := s.status
+	s.lock.RUnlock()
+
+	status.Paused = s.pause.Paused()
+	return status
+}
+
+func (s *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(s.Status())
+	if err != nil {
+		log.Debugf("failed to write status response: %v", err)
+	}
+}
+
+// handleMetrics serves the status in the Prometheus text format.
+func (s *StatusServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := s.Status()
+
+	states := make([]string, 0, len(status.Counts))
+	for state := range status.Counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP aws_nuke_iteration The current retry iteration of the removal.")
+	fmt.Fprintln(w, "# TYPE aws_nuke_iteration gauge")
+	fmt.Fprintf(w, "aws_nuke_iteration %d\n", status.Iteration)
+
+	paused := 0
+	if status.Paused {
+		paused = 1
+	}
+	fmt.Fprintln(w, "# HELP aws_nuke_paused Whether the removal is paused.")
+	fmt.Fprintln(w, "# TYPE aws_nuke_paused gauge")
+	fmt.Fprintf(w, "aws_nuke_paused %d\n", paused)
+
+	fmt.Fprintln(w, "# HELP aws_nuke_resources The number of resources per state.")
+	fmt.Fprintln(w, "# TYPE aws_nuke_resources gauge")
+	for _, state := range states {
+		fmt.Fprintf(w, "aws_nuke_resources{state=%q} %d\n", state, status.Counts[state])
+	}
+
+	fmt.Fprintln(w, "# HELP aws_nuke_throttled_requests_total The number of throttled AWS requests.")
+	fmt.Fprintln(w, "# TYPE aws_nuke_throttled_requests_total counter")
+	for _, c := range status.Throttling {
+		fmt.Fprintf(w, "aws_nuke_throttled_requests_total{service=%q,region=%q} %d\n",
+			c.Service, c.Region, c.Count)
+	}
+}
+
+// authorized checks the bearer token of a request, which controls the run.
+func (s *StatusServer) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	want := "Bearer " + s.token
+	have := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(have), []byte(want)) != 1 {
+		log.Warnf("Rejected an unauthorized request to %s of the status API from %s.", r.URL.Path, r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	return true
+}
+
+func (s *StatusServer) handlePause(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	s.pause.Pause()
+	log.Warn("Paused the removal via the status API. POST /resume to continue.")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("paused\n"))
+}
+
+func (s *StatusServer) handleResume(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	s.pause.Resume()
+	log.Warn("Resumed the removal via the status API.")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("resumed\n"))
+}
+
+func (s *StatusServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok\n"))
+}
+
+// publishStatus sends a snapshot of the current run state to the status
+// server. It is a no-op, if the status API is disabled.
+func (n *Nuke) publishStatus(phase string, iteration int) {
+	if n.status == nil {
+		return
+	}
+
+	status := RunStatus{
+		Phase:          phase,
+		AccountID:      n.Account.ID(),
+		Iteration:      iteration,
+		Counts:         map[string]int{},
+		RecentFailures: []StatusFailure{},
+		Throttling:     awsutil.Throttling.Counts(),
+	}
+
+	redactor := n.output().Redactor
+	for _, item := range n.items {
+		status.Counts[item.State.String()]++
+
+		if item.State == ItemStateFailed {
+			status.RecentFailures = append(status.RecentFailures, StatusFailure{
+				Region: item.Region.Name,
+				Type:   item.Type,
+				ID:     redactor.String(item.Identifier()),
+				Reason: redactor.String(item.Reason),
+			})
+		}
+	}
+
+	if len(status.RecentFailures) > statusRecentFailures {
+		status.RecentFailures = status.RecentFailures[len(status.RecentFailures)-statusRecentFailures:]
+	}
+
+	n.status.Publish(status)
+}
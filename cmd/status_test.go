@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+)
+
+func TestStatusServer(t *testing.T) {
+	s := NewStatusServer(NewPauseControl(), "")
+	s.Publish(RunStatus{
+		Phase:     PhaseRemoving,
+		Iteration: 3,
+		Counts:    map[string]int{"failed": 1, "finished": 2},
+	})
+
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wrong status code. Want: %d. Have: %d", http.StatusOK, rec.Code)
+	}
+
+	var status RunStatus
+	err := json.Unmarshal(rec.Body.Bytes(), &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Phase != PhaseRemoving || status.Iteration != 3 || status.Counts["finished"] != 2 {
+		t.Errorf("Wrong status: %#v", status)
+	}
+
+	rec = httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/status", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Wrong status code for POST. Want: %d. Have: %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func TestStatusServerMetrics(t *testing.T) {
+	s := NewStatusServer(NewPauseControl(), "")
+	s.Publish(RunStatus{
+		Phase:     PhaseRemoving,
+		Iteration: 2,
+		Counts:    map[string]int{"failed": 1, "finished": 2},
+		Throttling: []awsutil.ThrottleCount{
+			{Service: "ec2", Region: "eu-west-1", Count: 7},
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Wrong status code. Want: %d. Have: %d", http.StatusOK, rec.Code)
+	}
+
+	for _, want := range []string{
+		"aws_nuke_iteration 2\n",
+		`aws_nuke_resources{state="failed"} 1` + "\n",
+		`aws_nuke_resources{state="finished"} 2` + "\n",
+		`aws_nuke_throttled_requests_total{service="ec2",region="eu-west-1"} 7` + "\n",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("Metrics do not contain %q:\n%s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestStatusServerPause(t *testing.T) {
+	pause := NewPauseControl()
+	s := NewStatusServer(pause, "secret")
+
+	for _, tc := range []struct {
+		path   string
+		paused bool
+	}{
+		{"/pause", true},
+		{"/resume", false},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Wrong status code for %s. Want: %d. Have: %d", tc.path, http.StatusOK, rec.Code)
+		}
+
+		if pause.Paused() != tc.paused || s.Status().Paused != tc.paused {
+			t.Errorf("Wrong pause state after %s. Want: %t.", tc.path, tc.paused)
+		}
+	}
+
+	for _, header := range []string{"", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest(http.MethodPost, "/pause", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Wrong status code for %q. Want: %d. Have: %d", header, http.StatusUnauthorized, rec.Code)
+		}
+		if pause.Paused() {
+			t.Errorf("An unauthorized request with %q paused the run.", header)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pause", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Wrong status code for GET. Want: %d. Have: %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func TestStatusServerPauseDisabled(t *testing.T) {
+	pause := NewPauseControl()
+	s := NewStatusServer(pause, "")
+
+	for _, path := range []string{"/pause", "/resume"} {
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Wrong status code for %s. Want: %d. Have: %d", path, http.StatusNotFound, rec.Code)
+		}
+	}
+
+	if pause.Paused() {
+		t.Errorf("The run was paused without a control token.")
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReportStorage stores the report of a run, eg in a file or in S3.
+type ReportStorage interface {
+	Store(r *report.Report) error
+}
+
+// reportExtensions are the file extensions of the report formats, which
+// differ from the name of the format.
+var reportExtensions = map[string]string{
+	"markdown": "md",
+	"junit":    "xml",
+}
+
+func reportExtension(format string) string {
+	ext, ok := reportExtensions[format]
+	if ok {
+		return ext
+	}
+	return format
+}
+
+type fileReportStorage struct {
+	render reportRenderer
+	path   string
+}
+
+func (s *fileReportStorage) Store(r *report.Report) error {
+	return writeReportFile(s.render, s.path, r)
+}
+
+type stdoutReportStorage struct {
+	render reportRenderer
+	out    io.Writer
+}
+
+func (s *stdoutReportStorage) Store(r *report.Report) error {
+	return s.render(s.out, r)
+}
+
+type s3ReportStorage struct {
+	render     reportRenderer
+	ext        string
+	client     s3iface.S3API
+	bucket     string
+	prefix     string
+	encryption string
+	kmsKeyID   string
+}
+
+// key returns the key of the report, which is unique per account and run.
+func (s *s3ReportStorage) key(r *report.Report) string {
+	name := r.Started.UTC().Format("20060102T150405Z") + "." + s.ext
+	return path.Join(s.prefix, r.AccountID, name)
+}
+
+func (s *s3ReportStorage) Store(r *report.Report) error {
+	buf := new(bytes.Buffer)
+	err := s.render(buf, r)
+	if err != nil {
+		return err
+	}
+
+	key := s.key(r)
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(buf.Bytes()),
+	}
+	if s.encryption != "" {
+		input.ServerSideEncryption = aws.String(s.encryption)
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
+	}
+
+	_, err = s.client.PutObject(input)
+	if err != nil {
+		return fmt.Errorf("Failed to store the report s3://%s/%s: %v", s.bucket, key, err)
+	}
+
+	log.Infof("Stored the report in s3://%s/%s.", s.bucket, key)
+	return nil
+}
+
+// prepareReportStorage validates the format of the report storage of the
+// config.
+func prepareReportStorage(c config.ReportStorage) error {
+	if c.Format != "" && !report.Supports(c.Format) {
+		return fmt.Errorf("Unsupported format '%s' of the report storage. Supported formats are: %s.",
+			c.Format, strings.Join(report.Formats(), ", "))
+	}
+
+	return nil
+}
+
+// protectReportStorage prevents the removal of the bucket of the s3 report
+// storage and of its objects, since the report is only written at the end of
+// the run.
+func (n *Nuke) protectReportStorage() {
+	if n.Parameters.Output != "" || n.Config == nil {
+		return
+	}
+
+	c := n.Config.Report
+	if c.Backend != config.ReportBackendS3 || c.Bucket == "" {
+		return
+	}
+
+	n.protect("S3Bucket", config.Filter{Value: "s3://" + c.Bucket},
+		"the bucket of the report storage")
+	n.protect("S3Object", config.Filter{Property: "Bucket", Value: c.Bucket},
+		"stored in the bucket of the report storage")
+}
+
+// reportStorage returns the storage of the report. The --output flags take
+// precedence over the report storage of the config. It returns nil, if the
+// report should not be written at all.
+func (n *Nuke) reportStorage() (ReportStorage, error) {
+	if n.Parameters.Output != "" {
+		render := n.reportRenderer(n.Parameters.Output)
+		if n.Parameters.OutputFile == "" {
+			return &stdoutReportStorage{render: render, out: reportStdout}, nil
+		}
+		return &fileReportStorage{render: render, path: n.Parameters.OutputFile}, nil
+	}
+
+	if n.Config == nil {
+		return nil, nil
+	}
+
+	c := n.Config.Report
+	format := c.Format
+	if format == "" {
+		format = "json"
+	}
+
+	render := n.reportRenderer(format)
+
+	switch c.Backend {
+	case config.ReportBackendFile:
+		return &fileReportStorage{render: render, path: c.Path}, nil
+
+	case config.ReportBackendStdout:
+		return &stdoutReportStorage{render: render, out: reportStdout}, nil
+
+	case config.ReportBackendS3:
+		client, err := n.bucketClient(c.Bucket)
+		if err != nil {
+			return nil, err
+		}
+
+		ext := reportExtension(format)
+		if len(n.reportRecipients) > 0 {
+			ext += ".age"
+		}
+
+		return &s3ReportStorage{
+			render:     render,
+			ext:        ext,
+			client:     client,
+			bucket:     c.Bucket,
+			prefix:     c.Prefix,
+			encryption: c.Encryption,
+			kmsKeyID:   c.KMSKeyID,
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type storageTestS3 struct {
+	s3iface.S3API
+	inputs []*s3.PutObjectInput
+	bodies []string
+}
+
+func (s *storageTestS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.inputs = append(s.inputs, input)
+	s.bodies = append(s.bodies, string(data))
+	return &s3.PutObjectOutput{}, nil
+}
+
+func storageTestReport() *report.Report {
+	return &report.Report{
+		AccountID: "123456789012",
+		Started:   time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC),
+		Entries: []report.Entry{
+			{Region: "eu-west-1", Type: "EC2Instance", ID: "i-01b489457a60298dd", State: report.StateFinished},
+			{Region: "global", Type: "IAMRole", ID: "nuke", State: report.StateFiltered, Reason: "filtered by config"},
+		},
+	}
+}
+
+func TestS3ReportStorage(t *testing.T) {
+	client := &storageTestS3{}
+	storage := &s3ReportStorage{
+		render:     (&Nuke{}).reportRenderer("json"),
+		ext:        "json",
+		client:     client,
+		bucket:     "audit",
+		prefix:     "aws-nuke/reports",
+		encryption: config.ReportEncryptionKMS,
+		kmsKeyID:   "alias/aws-nuke",
+	}
+
+	r := storageTestReport()
+
+	err := storage.Store(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.inputs) != 1 {
+		t.Fatalf("Expected one object. Have: %d", len(client.inputs))
+	}
+
+	input := client.inputs[0]
+	wantKey := "aws-nuke/reports/" + r.AccountID + "/20240301T083000Z.json"
+	if aws.StringValue(input.Key) != wantKey {
+		t.Errorf("Wrong key. Want: %s. Have: %s", wantKey, aws.StringValue(input.Key))
+	}
+	if aws.StringValue(input.ServerSideEncryption) != "aws:kms" || aws.StringValue(input.SSEKMSKeyId) != "alias/aws-nuke" {
+		t.Errorf("Wrong encryption: %s %s", aws.StringValue(input.ServerSideEncryption), aws.StringValue(input.SSEKMSKeyId))
+	}
+
+	stored := new(report.Report)
+	err = json.Unmarshal([]byte(client.bodies[0]), stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Entries) != len(r.Entries) {
+		t.Errorf("Wrong number of entries. Want: %d. Have: %d", len(r.Entries), len(stored.Entries))
+	}
+}
+
+func TestReportStorageSelection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+
+	n := &Nuke{Config: &config.Nuke{Report: config.ReportStorage{
+		Backend: config.ReportBackendFile,
+		Format:  "csv",
+		Path:    path,
+	}}}
+
+	storage, err := n.reportStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = storage.Store(storageTestReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the report in the file of the config: %v", err)
+	}
+
+	n.Parameters.Output = "json"
+	storage, err = n.reportStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := storage.(*stdoutReportStorage); !ok {
+		t.Errorf("Expected --output to take precedence over the config. Have: %T", storage)
+	}
+
+	n = &Nuke{Config: &config.Nuke{}}
+	storage, err = n.reportStorage()
+	if err != nil || storage != nil {
+		t.Errorf("Expected no storage without --output and config. Have: %T %v", storage, err)
+	}
+}
+
+func TestProtectReportStorage(t *testing.T) {
+	n := &Nuke{Config: &config.Nuke{Report: config.ReportStorage{
+		Backend: config.ReportBackendS3,
+		Bucket:  "audit",
+	}}}
+	n.protectReportStorage()
+
+	object := func(bucket string) *Item {
+		return &Item{Type: "S3Object", Resource: &testResource{
+			props: types.NewProperties().Set("Bucket", bucket).Set("Key", "sandbox/report.json"),
+		}}
+	}
+
+	cases := []struct {
+		item      *Item
+		protected bool
+	}{
+		{&Item{Type: "S3Bucket", Resource: &stringerTestResource{id: "s3://audit"}}, true},
+		{&Item{Type: "S3Bucket", Resource: &stringerTestResource{id: "s3://audit-other"}}, false},
+		{object("audit"), true},
+		{object("logs"), false},
+	}
+
+	for _, tc := range cases {
+		reason := n.ownResourceReason(tc.item)
+		if (reason != "") != tc.protected {
+			t.Errorf("Wrong protection of %v: %q", tc.item.Resource, reason)
+		}
+	}
+
+	n = &Nuke{
+		Parameters: NukeParameters{Output: "json"},
+		Config:     &config.Nuke{Report: config.ReportStorage{Backend: config.ReportBackendS3, Bucket: "audit"}},
+	}
+	n.protectReportStorage()
+	if len(n.own) != 0 {
+		t.Errorf("Expected no protection, since --output takes precedence. Have: %v", n.own)
+	}
+}
+
+func TestReportExtension(t *testing.T) {
+	cases := map[string]string{
+		"json":     "json",
+		"markdown": "md",
+		"junit":    "xml",
+		"sarif":    "sarif",
+	}
+
+	for format, want := range cases {
+		if have := reportExtension(format); have != want {
+			t.Errorf("Wrong extension for %s. Want: %s. Have: %s", format, want, have)
+		}
+	}
+}
+
+func TestLogOutput(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+	cases := []struct {
+		params  NukeParameters
+		backend string
+		want    io.Writer
+	}{
+		{NukeParameters{}, "", os.Stdout},
+		{NukeParameters{Output: "json", OutputFile: "report.json"}, "", os.Stdout},
+		{NukeParameters{Output: "json"}, "", os.Stderr},
+		{NukeParameters{Summary: "markdown"}, "", os.Stderr},
+		{NukeParameters{EventsFile: "-"}, "", os.Stderr},
+		{NukeParameters{}, config.ReportBackendStdout, os.Stderr},
+		{NukeParameters{Output: "json", OutputFile: "report.json"}, config.ReportBackendStdout, os.Stdout},
+	}
+
+	for i, tc := range cases {
+		have := logOutput(&tc.params, config.ReportStorage{Backend: tc.backend})
+		if have != tc.want {
+			t.Errorf("Case %d: Wrong log output.", i)
+		}
+	}
+}
+
+func TestLogWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	region := &Region{Name: "eu-west-1", Output: &Output{Writer: buf}}
+
+	Log(region, "EC2Instance", &stringerTestResource{id: "i-01b489457a60298dd"}, ReasonSuccess, "removed")
+
+	if !strings.Contains(buf.String(), "i-01b489457a60298dd") {
+		t.Errorf("The resource was not written to the output of the region: %q", buf.String())
+	}
+}
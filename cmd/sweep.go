@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/resources"
+	log "github.com/sirupsen/logrus"
+)
+
+// TagSweep limits a run to resources, which carry a certain tag. This is
+// used to clean up ephemeral environments, like the ones of a pull request.
+type TagSweep struct {
+	Key   string
+	Value string
+
+	// AnyValue matches all resources with the tag key, regardless of the
+	// value.
+	AnyValue bool
+}
+
+// ParseTagSweep parses a tag in the form key=value or key. It returns nil
+// for an empty string.
+func ParseTagSweep(s string) (*TagSweep, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(s, "=", 2)
+	sweep := &TagSweep{Key: strings.TrimSpace(parts[0])}
+	if sweep.Key == "" {
+		return nil, fmt.Errorf("Invalid value '%s' for --sweep-by-tag. Expected key=value or key.", s)
+	}
+
+	if len(parts) == 1 {
+		sweep.AnyValue = true
+	} else {
+		sweep.Value = parts[1]
+	}
+
+	return sweep, nil
+}
+
+func (t *TagSweep) String() string {
+	if t.AnyValue {
+		return t.Key
+	}
+	return fmt.Sprintf("%s=%s", t.Key, t.Value)
+}
+
+// Match returns true, if the item has the tag. Resources without properties
+// never match, since their tags are not known.
+func (t *TagSweep) Match(item *Item) bool {
+	value, err := item.GetProperty("tag:" + t.Key)
+	if err != nil || value == "" {
+		return false
+	}
+
+	return t.AnyValue || value == t.Value
+}
+
+// Prefilter reduces the resource types to the ones of services, which have
+// tagged resources in the region according to the Resource Groups Tagging
+// API. The listers still have to resolve the actual resources. If the API is
+// not available, all resource types are returned.
+func (t *TagSweep) Prefilter(account *awsutil.Account, region string, resourceTypes []string) []string {
+	if region == awsutil.GlobalRegionID {
+		// The Tagging API does not cover global services like IAM.
+		return resourceTypes
+	}
+
+	services, err := t.services(account, region)
+	if err != nil {
+		log.Warnf("failed to look up tagged resources in %s, scanning all resource types: %v", region, err)
+		return resourceTypes
+	}
+
+	result := []string{}
+	for _, resourceType := range resourceTypes {
+		meta, ok := resources.GetMetadata(resourceType)
+		if !ok || resourceType == resources.CLOUDCONTROL_RESOURCE_TYPE || services[meta.IAMPrefix] {
+			result = append(result, resourceType)
+		}
+	}
+
+	log.Debugf("tagged resources of %d services found in %s, scanning %d resource types",
+		len(services), region, len(result))
+
+	return result
+}
+
+func (t *TagSweep) services(account *awsutil.Account, region string) (map[string]bool, error) {
+	svcType := account.ResourceTypeToServiceType(region, "ResourceGroupsTaggingAPI")
+	if svcType == "" {
+		return nil, fmt.Errorf("the Tagging API is not available in region '%s'", region)
+	}
+
+	sess, err := account.NewSession(region, svcType)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &resourcegroupstaggingapi.TagFilter{Key: aws.String(t.Key)}
+	if !t.AnyValue {
+		filter.Values = []*string{aws.String(t.Value)}
+	}
+
+	services := map[string]bool{}
+	err = resourcegroupstaggingapi.New(sess).GetResourcesPages(
+		&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{filter},
+		},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, mapping := range page.ResourceTagMappingList {
+				parsed, err := arn.Parse(aws.StringValue(mapping.ResourceARN))
+				if err != nil {
+					continue
+				}
+				services[parsed.Service] = true
+			}
+			return true
+		})
+
+	return services, err
+}
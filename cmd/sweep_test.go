@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestTagSweep(t *testing.T) {
+	tagged := &Item{Resource: &testResource{
+		props: types.NewProperties().Set("tag:environment", "pr-1234"),
+	}}
+	other := &Item{Resource: &testResource{
+		props: types.NewProperties().Set("tag:environment", "pr-42"),
+	}}
+	untagged := &Item{Resource: &testResource{
+		props: types.NewProperties().Set("Name", "foo"),
+	}}
+
+	cases := []struct {
+		tag   string
+		items map[*Item]bool
+	}{
+		{
+			tag:   "environment=pr-1234",
+			items: map[*Item]bool{tagged: true, other: false, untagged: false},
+		},
+		{
+			tag:   "environment",
+			items: map[*Item]bool{tagged: true, other: true, untagged: false},
+		},
+	}
+
+	for _, tc := range cases {
+		sweep, err := ParseTagSweep(tc.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sweep.String() != tc.tag {
+			t.Errorf("Wrong string for %s: %s", tc.tag, sweep)
+		}
+
+		for item, want := range tc.items {
+			have := sweep.Match(item)
+			if have != want {
+				t.Errorf("Wrong match of %s for %v. Want: %t. Have: %t",
+					tc.tag, item.Resource, want, have)
+			}
+		}
+	}
+}
+
+func TestParseTagSweepInvalid(t *testing.T) {
+	sweep, err := ParseTagSweep("")
+	if sweep != nil || err != nil {
+		t.Errorf("Expected no sweep for empty string. Got %v, %v.", sweep, err)
+	}
+
+	_, err = ParseTagSweep("=foo")
+	if err == nil {
+		t.Errorf("Expected error for missing tag key.")
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+)
+
+// throttleSummaryLimit is the number of services and regions shown in the
+// throttling summary.
+const throttleSummaryLimit = 10
+
+// printThrottling summarizes the throttled requests of the run, so the
+// concurrency can be tuned for the account. It prints nothing, if no request
+// was throttled.
+func printThrottling(w io.Writer, counts []awsutil.ThrottleCount) {
+	if len(counts) == 0 {
+		return
+	}
+
+	total := 0
+	for _, c := range counts {
+		total += c.Count
+	}
+
+	fmt.Fprintf(w, "AWS throttled %d requests:\n", total)
+	for i, c := range counts {
+		if i >= throttleSummaryLimit {
+			fmt.Fprintf(w, "  ... and %d more\n", len(counts)-throttleSummaryLimit)
+			break
+		}
+		fmt.Fprintf(w, "  %s in %s: %d\n", c.Service, c.Region, c.Count)
+	}
+
+	fmt.Fprintln(w, "Throttled requests are retried, but slow down the run. Consider lowering "+
+		"parallel-queries in the run profile, if the throttling persists.")
+	fmt.Fprintln(w)
+}
@@ -3,14 +3,15 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
-func Prompt(expect string) error {
-	fmt.Print("> ")
+func Prompt(w io.Writer, expect string) error {
+	fmt.Fprint(w, "> ")
 	reader := bufio.NewReader(os.Stdin)
 	text, err := reader.ReadString('\n')
 	if err != nil {
@@ -20,7 +21,7 @@ func Prompt(expect string) error {
 	if strings.TrimSpace(text) != expect {
 		return fmt.Errorf("aborted")
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	return nil
 }
@@ -8,6 +8,31 @@ import (
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
+// testResource is a resource with the given properties, which is shared by
+// the tests of the package.
+type testResource struct {
+	props types.Properties
+}
+
+func (r *testResource) Remove() error {
+	return nil
+}
+
+func (r *testResource) Properties() types.Properties {
+	return r.props
+}
+
+// stringerTestResource is a resource, which is identified by its legacy
+// string.
+type stringerTestResource struct {
+	testResource
+	id string
+}
+
+func (r *stringerTestResource) String() string {
+	return r.id
+}
+
 func TestResolveResourceTypes(t *testing.T) {
 	cases := []struct {
 		base    types.Collection
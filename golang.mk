@@ -73,7 +73,11 @@ compress: _build
 
 xc:
 	GOOS=linux GOARCH=amd64 make compress
+	GOOS=linux GOARCH=arm64 make compress
+	GOOS=linux GOARCH=arm GOARM=7 make compress
 	GOOS=darwin GOARCH=amd64 make compress
+	GOOS=darwin GOARCH=arm64 make compress
+	GOOS=windows GOARCH=amd64 make compress
 
 install: vendor test
 	$(foreach TARGET,$(TARGETS),go install \
@@ -22,7 +22,9 @@ type NukeParameters struct {
 }
 
 func main() {
-	if err := cmd.NewRootCommand().Execute(); err != nil {
+	err := cmd.NewRootCommand().Execute()
+	cmd.StopProfiling()
+	if err != nil {
 		os.Exit(-1)
 	}
 }
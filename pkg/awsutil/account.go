@@ -3,7 +3,10 @@ package awsutil
 import (
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
@@ -13,35 +16,43 @@ type Account struct {
 	Credentials
 
 	id      string
+	arn     string
 	aliases []string
 }
 
 func NewAccount(creds Credentials, endpoints config.CustomEndpoints) (*Account, error) {
 	creds.CustomEndpoints = endpoints
+	creds.pool = newSessionPool()
 	account := Account{
 		Credentials: creds,
 	}
 
 	customStackSupportSTSAndIAM := true
-	if endpoints.GetRegion(DefaultRegionID) != nil {
-		if endpoints.GetURL(DefaultRegionID, "sts") == "" {
+	if endpoints.GetRegion(account.DefaultRegionID()) != nil {
+		if endpoints.GetURL(account.DefaultRegionID(), "sts") == "" {
 			customStackSupportSTSAndIAM = false
-		} else if endpoints.GetURL(DefaultRegionID, "iam") == "" {
+		} else if endpoints.GetURL(account.DefaultRegionID(), "iam") == "" {
 			customStackSupportSTSAndIAM = false
 		}
 	}
 	if !customStackSupportSTSAndIAM {
-		account.id = "account-id-of-custom-region-" + DefaultRegionID
+		account.id = "account-id-of-custom-region-" + account.DefaultRegionID()
 		account.aliases = []string{account.id}
 		return &account, nil
 	}
 
-	defaultSession, err := account.NewSession(DefaultRegionID, "")
+	defaultSession, err := account.NewSession(account.DefaultRegionID(), "")
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create default session in %s", DefaultRegionID)
+		return nil, errors.Wrapf(err, "failed to create default session in %s", account.DefaultRegionID())
 	}
 
 	identityOutput, err := sts.New(defaultSession).GetCallerIdentity(nil)
+	if err != nil {
+		err = account.renewSSOSession(err)
+		if err == nil {
+			identityOutput, err = sts.New(defaultSession).GetCallerIdentity(nil)
+		}
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed get caller identity")
 	}
@@ -64,6 +75,7 @@ func NewAccount(creds Credentials, endpoints config.CustomEndpoints) (*Account,
 	}
 
 	account.id = *identityOutput.Account
+	account.arn = aws.StringValue(identityOutput.Arn)
 	account.aliases = aliases
 
 	return &account, nil
@@ -73,6 +85,12 @@ func (a *Account) ID() string {
 	return a.id
 }
 
+// ARN returns the ARN of the caller identity, which is used for the run. It
+// is empty, if the account uses custom endpoints without STS.
+func (a *Account) ARN() string {
+	return a.arn
+}
+
 func (a *Account) Alias() string {
 	return a.aliases[0]
 }
@@ -81,6 +99,36 @@ func (a *Account) Aliases() []string {
 	return a.aliases
 }
 
+// ManagementAccountID returns the ID of the management account of the
+// organization, which the account belongs to. It is empty, if the account is
+// not part of an organization or uses custom endpoints. Any other failure is
+// returned as error, so callers can refuse to run without knowing.
+func (a *Account) ManagementAccountID() (string, error) {
+	if a.CustomEndpoints.GetRegion(a.DefaultRegionID()) != nil {
+		return "", nil
+	}
+
+	sess, err := a.NewSession(GlobalRegionID, "")
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := organizations.New(sess).DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == organizations.ErrCodeAWSOrganizationsNotInUseException {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if resp.Organization == nil || resp.Organization.MasterAccountId == nil {
+		return "", nil
+	}
+
+	return *resp.Organization.MasterAccountId, nil
+}
+
 func (a *Account) ResourceTypeToServiceType(regionName, resourceType string) string {
 	customRegion := a.CustomEndpoints.GetRegion(regionName)
 	if customRegion == nil {
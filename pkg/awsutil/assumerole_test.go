@@ -0,0 +1,109 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+func TestAssumeRoleSessionAttributes(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			t.Error(err)
+		}
+		form = r.PostForm
+
+		fmt.Fprint(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>AKIANUKE</AccessKeyId><SecretAccessKey>secret</SecretAccessKey>`+
+			`<SessionToken>token</SessionToken><Expiration>2100-01-01T00:00:00Z</Expiration>`+
+			`</Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+	}))
+	defer server.Close()
+
+	base := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+
+	c := &Credentials{}
+	sess, err := c.assumeRole(base, config.AssumeRole{
+		RoleARN:           "arn:aws:iam::123456789012:role/nuke",
+		SourceIdentity:    "jenkins",
+		SessionTags:       map[string]string{"ticket": "OPS-1234", "initiator": "jenkins"},
+		TransitiveTagKeys: []string{"ticket"},
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := sess.Config.Credentials.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.AccessKeyID != "AKIANUKE" {
+		t.Errorf("Wrong access key: %s", value.AccessKeyID)
+	}
+
+	want := map[string]string{
+		"RoleArn":                    "arn:aws:iam::123456789012:role/nuke",
+		"RoleSessionName":            DefaultAssumeRoleSessionName,
+		"SourceIdentity":             "jenkins",
+		"Tags.member.1.Key":          "initiator",
+		"Tags.member.1.Value":        "jenkins",
+		"Tags.member.2.Key":          "ticket",
+		"Tags.member.2.Value":        "OPS-1234",
+		"TransitiveTagKeys.member.1": "ticket",
+	}
+	for key, value := range want {
+		if form.Get(key) != value {
+			t.Errorf("Wrong %s. Want: %q. Have: %q", key, value, form.Get(key))
+		}
+	}
+}
+
+func TestMFATokenProvider(t *testing.T) {
+	defer func(orig func() bool) { stdinIsTerminal = orig }(stdinIsTerminal)
+	stdinIsTerminal = func() bool { return false }
+
+	c := &Credentials{MFAToken: " 123456 "}
+	provider := c.mfaTokenProvider()
+
+	tokens := make(chan string, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := provider()
+			if err == nil {
+				tokens <- token
+			}
+		}()
+	}
+	wg.Wait()
+	close(tokens)
+
+	have := []string{}
+	for token := range tokens {
+		have = append(have, token)
+	}
+	if len(have) != 1 || have[0] != "123456" {
+		t.Errorf("Expected the token exactly once. Have: %v", have)
+	}
+
+	_, err := provider()
+	if err == nil {
+		t.Errorf("Expected an error without terminal.")
+	}
+}
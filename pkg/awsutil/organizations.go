@@ -0,0 +1,151 @@
+package awsutil
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/pkg/errors"
+)
+
+// OrganizationAccount is an active member account of an organization.
+type OrganizationAccount struct {
+	ID   string
+	Name string
+
+	// OUPath is the path of organizational unit names from the root to the
+	// account, eg Root/Sandbox/Team.
+	OUPath string
+
+	// Tags are only set, if they were requested.
+	Tags map[string]string
+}
+
+// OrganizationAccounts lists the active member accounts of the organization,
+// which the credentials belong to. The management account is never part of
+// the list. This is only permitted for the management account and delegated
+// administrators. If withTags is set, the tags of every account are read as
+// well.
+func (c *Credentials) OrganizationAccounts(withTags bool) ([]OrganizationAccount, error) {
+	sess, err := c.NewSession(GlobalRegionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationAccounts(organizations.New(sess), withTags)
+}
+
+func organizationAccounts(client organizationsiface.OrganizationsAPI, withTags bool) ([]OrganizationAccount, error) {
+	org, err := client.DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe the organization")
+	}
+
+	var managementAccountID string
+	if org.Organization != nil {
+		managementAccountID = aws.StringValue(org.Organization.MasterAccountId)
+	}
+
+	accounts := []OrganizationAccount{}
+	err = client.ListAccountsPages(&organizations.ListAccountsInput{},
+		func(page *organizations.ListAccountsOutput, lastPage bool) bool {
+			for _, account := range page.Accounts {
+				if aws.StringValue(account.Status) != organizations.AccountStatusActive {
+					continue
+				}
+				if aws.StringValue(account.Id) == managementAccountID {
+					continue
+				}
+				accounts = append(accounts, OrganizationAccount{
+					ID:   aws.StringValue(account.Id),
+					Name: aws.StringValue(account.Name),
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the accounts of the organization")
+	}
+
+	paths, err := organizationPaths(client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the organizational units")
+	}
+
+	for i := range accounts {
+		accounts[i].OUPath = paths[accounts[i].ID]
+
+		if !withTags {
+			continue
+		}
+
+		accounts[i].Tags = map[string]string{}
+		err := client.ListTagsForResourcePages(&organizations.ListTagsForResourceInput{
+			ResourceId: aws.String(accounts[i].ID),
+		}, func(page *organizations.ListTagsForResourceOutput, lastPage bool) bool {
+			for _, tag := range page.Tags {
+				accounts[i].Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the tags of the account %s", accounts[i].ID)
+		}
+	}
+
+	return accounts, nil
+}
+
+// organizationPaths walks the organizational units from the roots downwards
+// and returns the OU path of every account.
+func organizationPaths(client organizationsiface.OrganizationsAPI) (map[string]string, error) {
+	paths := map[string]string{}
+
+	var walk func(parentID, path string) error
+	walk = func(parentID, path string) error {
+		err := client.ListAccountsForParentPages(&organizations.ListAccountsForParentInput{
+			ParentId: aws.String(parentID),
+		}, func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
+			for _, account := range page.Accounts {
+				paths[aws.StringValue(account.Id)] = path
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		units := []*organizations.OrganizationalUnit{}
+		err = client.ListOrganizationalUnitsForParentPages(&organizations.ListOrganizationalUnitsForParentInput{
+			ParentId: aws.String(parentID),
+		}, func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+			units = append(units, page.OrganizationalUnits...)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, unit := range units {
+			err := walk(aws.StringValue(unit.Id), path+"/"+aws.StringValue(unit.Name))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	roots, err := client.ListRoots(&organizations.ListRootsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, root := range roots.Roots {
+		err := walk(aws.StringValue(root.Id), aws.StringValue(root.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,104 @@
+package awsutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+)
+
+type fakeOrganizationTree struct {
+	organizationsiface.OrganizationsAPI
+
+	management string
+	accounts   []*organizations.Account
+	units      map[string][]*organizations.OrganizationalUnit
+	members    map[string][]string
+	tags       map[string]map[string]string
+}
+
+func (f *fakeOrganizationTree) DescribeOrganization(input *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error) {
+	return &organizations.DescribeOrganizationOutput{Organization: &organizations.Organization{
+		MasterAccountId: aws.String(f.management),
+	}}, nil
+}
+
+func (f *fakeOrganizationTree) ListAccountsPages(input *organizations.ListAccountsInput,
+	fn func(*organizations.ListAccountsOutput, bool) bool) error {
+	fn(&organizations.ListAccountsOutput{Accounts: f.accounts}, true)
+	return nil
+}
+
+func (f *fakeOrganizationTree) ListRoots(input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error) {
+	return &organizations.ListRootsOutput{Roots: []*organizations.Root{
+		{Id: aws.String("r-root"), Name: aws.String("Root")},
+	}}, nil
+}
+
+func (f *fakeOrganizationTree) ListOrganizationalUnitsForParentPages(input *organizations.ListOrganizationalUnitsForParentInput,
+	fn func(*organizations.ListOrganizationalUnitsForParentOutput, bool) bool) error {
+	fn(&organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: f.units[*input.ParentId]}, true)
+	return nil
+}
+
+func (f *fakeOrganizationTree) ListAccountsForParentPages(input *organizations.ListAccountsForParentInput,
+	fn func(*organizations.ListAccountsForParentOutput, bool) bool) error {
+	page := &organizations.ListAccountsForParentOutput{}
+	for _, id := range f.members[*input.ParentId] {
+		page.Accounts = append(page.Accounts, &organizations.Account{Id: aws.String(id)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeOrganizationTree) ListTagsForResourcePages(input *organizations.ListTagsForResourceInput,
+	fn func(*organizations.ListTagsForResourceOutput, bool) bool) error {
+	page := &organizations.ListTagsForResourceOutput{}
+	for key, value := range f.tags[*input.ResourceId] {
+		page.Tags = append(page.Tags, &organizations.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func TestOrganizationAccounts(t *testing.T) {
+	account := func(id, status string) *organizations.Account {
+		return &organizations.Account{Id: aws.String(id), Name: aws.String("name-" + id), Status: aws.String(status)}
+	}
+
+	client := &fakeOrganizationTree{
+		management: "444444444444",
+		accounts: []*organizations.Account{
+			account("444444444444", organizations.AccountStatusActive),
+			account("111111111111", organizations.AccountStatusActive),
+			account("222222222222", organizations.AccountStatusActive),
+			account("333333333333", organizations.AccountStatusSuspended),
+		},
+		units: map[string][]*organizations.OrganizationalUnit{
+			"r-root":     {{Id: aws.String("ou-sandbox"), Name: aws.String("Sandbox")}},
+			"ou-sandbox": {{Id: aws.String("ou-team"), Name: aws.String("Team")}},
+		},
+		members: map[string][]string{
+			"r-root":  {"444444444444", "111111111111"},
+			"ou-team": {"222222222222", "333333333333"},
+		},
+		tags: map[string]map[string]string{
+			"222222222222": {"purpose": "sandbox"},
+		},
+	}
+
+	accounts, err := organizationAccounts(client, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []OrganizationAccount{
+		{ID: "111111111111", Name: "name-111111111111", OUPath: "Root", Tags: map[string]string{}},
+		{ID: "222222222222", Name: "name-222222222222", OUPath: "Root/Sandbox/Team", Tags: map[string]string{"purpose": "sandbox"}},
+	}
+	if !reflect.DeepEqual(want, accounts) {
+		t.Errorf("Wrong accounts.\nWant: %+v\nHave: %+v", want, accounts)
+	}
+}
@@ -0,0 +1,151 @@
+package awsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// defaultMaxIdleConnsPerHost replaces the default of Go, which only keeps two
+// idle connections per host. The listers of a region query the same
+// endpoints in parallel, so most connections would be closed after each
+// request and need a new TLS handshake.
+const defaultMaxIdleConnsPerHost = 16
+
+// sessionPool caches the sessions of an account per region and service type,
+// so all clients share the same sessions and connections. It is safe for
+// concurrent use.
+type sessionPool struct {
+	lock     sync.Mutex
+	sessions map[sessionKey]*session.Session
+}
+
+type sessionKey struct {
+	region      string
+	serviceType string
+}
+
+func newSessionPool() *sessionPool {
+	return &sessionPool{
+		sessions: map[sessionKey]*session.Session{},
+	}
+}
+
+// get returns the cached session or creates it with create. Sessions are
+// created while holding the lock, so concurrent callers do not create the
+// same session twice.
+func (p *sessionPool) get(key sessionKey, create func() (*session.Session, error)) (*session.Session, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	sess, ok := p.sessions[key]
+	if ok {
+		return sess, nil
+	}
+
+	sess, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	p.sessions[key] = sess
+	return sess, nil
+}
+
+// httpClient returns the HTTP client, which is shared by all sessions of the
+// credentials. It is built from the http-client settings and the CA bundle and
+// then passed to CustomizeHTTPClient, if set.
+func (c *Credentials) httpClient() (*http.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	settings := c.HTTPClient
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if settings.KeepAlive != 0 {
+		dialer.KeepAlive = settings.KeepAlive
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.DisableKeepAlives = settings.DisableKeepAlives
+	if settings.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = settings.MaxIdleConnsPerHost
+	}
+	if settings.IdleConnTimeout != 0 {
+		transport.IdleConnTimeout = settings.IdleConnTimeout
+	}
+
+	if strings.TrimSpace(c.CABundle) != "" {
+		bundle, err := ioutil.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %v", err)
+		}
+
+		// The bundle adds to the system roots instead of replacing them, so
+		// endpoints with public certificates stay reachable.
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("failed to load CA bundle: %s contains no certificates", c.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   settings.Timeout,
+	}
+	if c.CustomizeHTTPClient != nil {
+		c.CustomizeHTTPClient(client)
+	}
+
+	c.client = client
+	return client, nil
+}
+
+// insecureHTTPClient is like httpClient, but does not verify the TLS
+// certificates. It is used for custom endpoints with tls_insecure_skip_verify.
+func (c *Credentials) insecureHTTPClient() (*http.Client, error) {
+	if c.insecureClient != nil {
+		return c.insecureClient, nil
+	}
+
+	base, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Transport:     base.Transport,
+		CheckRedirect: base.CheckRedirect,
+		Jar:           base.Jar,
+		Timeout:       base.Timeout,
+	}
+
+	if t, ok := base.Transport.(*http.Transport); ok {
+		transport := t.Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		client.Transport = transport
+	}
+
+	c.insecureClient = client
+	return client, nil
+}
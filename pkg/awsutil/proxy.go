@@ -0,0 +1,152 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+// proxyRoute describes how a request is sent. An empty URL without direct
+// means that the proxy is taken from the environment.
+type proxyRoute struct {
+	url    string
+	direct bool
+}
+
+// ProxySelector chooses the HTTP proxy per request, based on the service and
+// region of the request.
+type ProxySelector struct {
+	config config.Proxy
+
+	lock    sync.Mutex
+	clients map[proxyClientKey]*http.Client
+}
+
+type proxyClientKey struct {
+	base  *http.Client
+	route proxyRoute
+}
+
+func NewProxySelector(conf config.Proxy) (*ProxySelector, error) {
+	urls := []string{conf.URL}
+	for _, o := range conf.Overrides {
+		if o.URL != "" && o.Direct {
+			return nil, fmt.Errorf("proxy override for %v in %v cannot have an URL and be direct",
+				o.Services, o.Regions)
+		}
+		urls = append(urls, o.URL)
+	}
+
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		_, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL '%s': %v", u, err)
+		}
+	}
+
+	return &ProxySelector{
+		config:  conf,
+		clients: map[proxyClientKey]*http.Client{},
+	}, nil
+}
+
+func (p *ProxySelector) route(service, region string) proxyRoute {
+	for _, o := range p.config.Overrides {
+		if !matchesAny(o.Services, service) || !matchesAny(o.Regions, region) {
+			continue
+		}
+		return proxyRoute{url: o.URL, direct: o.Direct}
+	}
+
+	return proxyRoute{url: p.config.URL}
+}
+
+// Handler replaces the HTTP client of the request with one that uses the
+// selected proxy. It has to be added to the Send handlers.
+func (p *ProxySelector) Handler(r *request.Request) {
+	route := p.route(r.ClientInfo.ServiceName, aws.StringValue(r.Config.Region))
+	r.Config.HTTPClient = p.client(r.Config.HTTPClient, route)
+}
+
+func (p *ProxySelector) client(base *http.Client, route proxyRoute) *http.Client {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	key := proxyClientKey{base: base, route: route}
+	client, ok := p.clients[key]
+	if ok {
+		return client
+	}
+
+	// Clone the existing transport to keep TLS settings like custom CA
+	// bundles.
+	var transport *http.Transport
+	if base != nil {
+		if t, ok := base.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport.Proxy = p.proxyFunc(route)
+
+	client = &http.Client{Transport: transport}
+	if base != nil {
+		client.CheckRedirect = base.CheckRedirect
+		client.Jar = base.Jar
+		client.Timeout = base.Timeout
+	}
+
+	p.clients[key] = client
+	return client
+}
+
+func (p *ProxySelector) proxyFunc(route proxyRoute) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if route.direct || p.bypass(req.URL.Hostname()) {
+			return nil, nil
+		}
+
+		if route.url == "" {
+			return http.ProxyFromEnvironment(req)
+		}
+
+		return url.Parse(route.url)
+	}
+}
+
+func (p *ProxySelector) bypass(host string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range p.config.NoProxy {
+		entry = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry), "*"))
+		if entry == "" {
+			continue
+		}
+		if host == strings.TrimPrefix(entry, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
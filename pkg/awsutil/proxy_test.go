@@ -0,0 +1,63 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+func TestProxySelector(t *testing.T) {
+	p, err := NewProxySelector(config.Proxy{
+		URL:     "http://proxy.corp:3128",
+		NoProxy: []string{".internal.corp", "sts.amazonaws.com"},
+		Overrides: []config.ProxyOverride{
+			{Services: []string{"s3"}, Regions: []string{"eu-central-1"}, Direct: true},
+			{Services: []string{"s3", "ec2"}, URL: "http://s3-proxy.corp:3128"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		service, region, host string
+		want                  string
+	}{
+		{service: "iam", region: "us-east-1", host: "iam.amazonaws.com", want: "http://proxy.corp:3128"},
+		{service: "s3", region: "eu-central-1", host: "s3.eu-central-1.amazonaws.com", want: "<nil>"},
+		{service: "s3", region: "eu-west-1", host: "s3.eu-west-1.amazonaws.com", want: "http://s3-proxy.corp:3128"},
+		{service: "ec2", region: "eu-west-1", host: "ec2.eu-west-1.amazonaws.com", want: "http://s3-proxy.corp:3128"},
+		{service: "sts", region: "us-east-1", host: "sts.amazonaws.com", want: "<nil>"},
+		{service: "ec2", region: "stratoscale", host: "api.internal.corp", want: "<nil>"},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			client := p.client(nil, p.route(tc.service, tc.region))
+			req, _ := http.NewRequest("GET", "https://"+tc.host+"/", nil)
+			proxy, err := client.Transport.(*http.Transport).Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have := "<nil>"
+			if proxy != nil {
+				have = proxy.String()
+			}
+			if have != tc.want {
+				t.Errorf("Wrong proxy. Want: %s. Have: %s", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestProxySelectorInvalidOverride(t *testing.T) {
+	_, err := NewProxySelector(config.Proxy{
+		Overrides: []config.ProxyOverride{{URL: "http://proxy.corp:3128", Direct: true}},
+	})
+	if err == nil {
+		t.Errorf("expected error for override with URL and direct")
+	}
+}
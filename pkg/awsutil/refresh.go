@@ -0,0 +1,34 @@
+package awsutil
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	log "github.com/sirupsen/logrus"
+)
+
+// refreshExpiredCredentialsHandler retries requests which failed because of
+// expired credentials. The SDK expires the cached credentials before the
+// retry, so it is sent with refreshed credentials. This keeps runs working
+// which take longer than the lifetime of assumed roles or SSO sessions.
+// Static credentials cannot be refreshed, so their requests are not retried
+// and fail right away instead of after the backoff of all retries.
+func refreshExpiredCredentialsHandler(r *request.Request) {
+	if r.Error == nil || !r.IsErrorExpired() || r.Config.Credentials == nil {
+		return
+	}
+
+	value, err := r.Config.Credentials.Get()
+	if err != nil {
+		return
+	}
+
+	if value.ProviderName == credentials.StaticProviderName {
+		log.Debugf("static credentials expired; they cannot be refreshed")
+		r.Retryable = aws.Bool(false)
+		return
+	}
+
+	log.Debugf("credentials of %s provider expired; refreshing them", value.ProviderName)
+	r.Retryable = aws.Bool(true)
+}
@@ -0,0 +1,161 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+type refreshableProvider struct {
+	retrieved int
+}
+
+func (p *refreshableProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		SessionToken:    "TOKEN",
+		ProviderName:    "TestProvider",
+	}, nil
+}
+
+func (p *refreshableProvider) IsExpired() bool {
+	return false
+}
+
+func TestRefreshExpiredCredentialsHandler(t *testing.T) {
+	expired := awserr.New("ExpiredToken", "The security token included in the request is expired", nil)
+	other := awserr.New("AccessDenied", "denied", nil)
+
+	cases := []struct {
+		name      string
+		creds     *credentials.Credentials
+		err       error
+		retryable bool
+	}{
+		{
+			name:      "refreshable",
+			creds:     credentials.NewCredentials(&refreshableProvider{}),
+			err:       expired,
+			retryable: true,
+		},
+		{
+			name:  "static",
+			creds: credentials.NewStaticCredentials("AKID", "SECRET", "TOKEN"),
+			err:   expired,
+		},
+		{
+			name:  "other-error",
+			creds: credentials.NewCredentials(&refreshableProvider{}),
+			err:   other,
+		},
+		{
+			name:  "no-error",
+			creds: credentials.NewCredentials(&refreshableProvider{}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &request.Request{
+				Config: aws.Config{Credentials: tc.creds},
+				Error:  tc.err,
+			}
+
+			refreshExpiredCredentialsHandler(r)
+
+			retryable := r.Retryable != nil && *r.Retryable
+			if retryable != tc.retryable {
+				t.Errorf("want retryable=%t, got %t", tc.retryable, retryable)
+			}
+		})
+	}
+}
+
+// rotatingProvider returns new credentials on every retrieval, like an
+// assumed role whose session expired.
+type rotatingProvider struct {
+	retrieved int
+}
+
+func (p *rotatingProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{
+		AccessKeyID:     fmt.Sprintf("AKID%d", p.retrieved),
+		SecretAccessKey: "SECRET",
+		SessionToken:    "TOKEN",
+		ProviderName:    "TestProvider",
+	}, nil
+}
+
+func (p *rotatingProvider) IsExpired() bool {
+	return false
+}
+
+func TestRefreshExpiredCredentialsMidRun(t *testing.T) {
+	cases := []struct {
+		name  string
+		creds *credentials.Credentials
+		keys  []string
+	}{
+		{
+			name:  "refreshable",
+			creds: credentials.NewCredentials(&rotatingProvider{}),
+			keys:  []string{"AKID1", "AKID2"},
+		},
+		{
+			name:  "static",
+			creds: credentials.NewStaticCredentials("AKID", "SECRET", "TOKEN"),
+			keys:  []string{"AKID"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth := r.Header.Get("Authorization")
+				keys = append(keys, auth[strings.Index(auth, "Credential=")+len("Credential="):strings.Index(auth, "/")])
+
+				if len(keys) == 1 {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>ExpiredToken</Code>`+
+						`<Message>The security token included in the request is expired</Message></Error></ErrorResponse>`)
+					return
+				}
+
+				fmt.Fprint(w, `<GetCallerIdentityResponse><GetCallerIdentityResult>`+
+					`<Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`)
+			}))
+			defer server.Close()
+
+			sess := session.Must(session.NewSession(&aws.Config{
+				Region:      aws.String("us-east-1"),
+				Endpoint:    aws.String(server.URL),
+				Credentials: tc.creds,
+				SleepDelay:  func(time.Duration) {},
+			}))
+			sess.Handlers.Retry.PushBack(refreshExpiredCredentialsHandler)
+
+			_, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+			if (err == nil) != (len(tc.keys) > 1) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if strings.Join(keys, ",") != strings.Join(tc.keys, ",") {
+				t.Errorf("Wrong credentials of the requests. Want: %v. Have: %v", tc.keys, keys)
+			}
+		})
+	}
+}
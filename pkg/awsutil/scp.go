@@ -0,0 +1,105 @@
+package awsutil
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+)
+
+// ServiceControlPolicy is an SCP of AWS Organizations, which applies to the
+// account.
+type ServiceControlPolicy struct {
+	ID      string
+	Name    string
+	Content string
+}
+
+// ServiceControlPolicies returns the SCPs, which are attached to the account
+// or to one of its parents. Reading them is usually only permitted for the
+// management account and delegated administrators, so callers should treat
+// errors as unknown policies. It returns nil, if the account is not part of an
+// organization or uses custom endpoints.
+func (a *Account) ServiceControlPolicies() ([]ServiceControlPolicy, error) {
+	if a.CustomEndpoints.GetRegion(a.DefaultRegionID()) != nil {
+		return nil, nil
+	}
+
+	sess, err := a.NewSession(GlobalRegionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := serviceControlPolicies(organizations.New(sess), a.ID())
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == organizations.ErrCodeAWSOrganizationsNotInUseException {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func serviceControlPolicies(client organizationsiface.OrganizationsAPI, accountID string) ([]ServiceControlPolicy, error) {
+	seen := map[string]bool{}
+	isRoot := map[string]bool{}
+	policies := []ServiceControlPolicy{}
+
+	// The policies of all parents up to the root apply, so the hierarchy is
+	// walked upwards until the root was listed.
+	target := accountID
+	for target != "" {
+		err := client.ListPoliciesForTargetPages(&organizations.ListPoliciesForTargetInput{
+			TargetId: aws.String(target),
+			Filter:   aws.String(organizations.PolicyTypeServiceControlPolicy),
+		}, func(page *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+			for _, summary := range page.Policies {
+				id := aws.StringValue(summary.Id)
+				if !seen[id] {
+					seen[id] = true
+					policies = append(policies, ServiceControlPolicy{
+						ID:   id,
+						Name: aws.StringValue(summary.Name),
+					})
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if isRoot[target] {
+			break
+		}
+
+		parents, err := client.ListParents(&organizations.ListParentsInput{
+			ChildId: aws.String(target),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		target = ""
+		if len(parents.Parents) > 0 {
+			target = aws.StringValue(parents.Parents[0].Id)
+			isRoot[target] = aws.StringValue(parents.Parents[0].Type) == organizations.ParentTypeRoot
+		}
+	}
+
+	for i := range policies {
+		resp, err := client.DescribePolicy(&organizations.DescribePolicyInput{
+			PolicyId: aws.String(policies[i].ID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Policy != nil {
+			policies[i].Content = aws.StringValue(resp.Policy.Content)
+		}
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,69 @@
+package awsutil
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+)
+
+type fakeOrganizations struct {
+	organizationsiface.OrganizationsAPI
+
+	parents  map[string]*organizations.Parent
+	policies map[string][]string
+}
+
+func (f *fakeOrganizations) ListPoliciesForTargetPages(input *organizations.ListPoliciesForTargetInput,
+	fn func(*organizations.ListPoliciesForTargetOutput, bool) bool) error {
+	page := &organizations.ListPoliciesForTargetOutput{}
+	for _, id := range f.policies[*input.TargetId] {
+		page.Policies = append(page.Policies, &organizations.PolicySummary{Id: aws.String(id), Name: aws.String("name-" + id)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeOrganizations) ListParents(input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	out := &organizations.ListParentsOutput{}
+	if parent, ok := f.parents[*input.ChildId]; ok {
+		out.Parents = []*organizations.Parent{parent}
+	}
+	return out, nil
+}
+
+func (f *fakeOrganizations) DescribePolicy(input *organizations.DescribePolicyInput) (*organizations.DescribePolicyOutput, error) {
+	return &organizations.DescribePolicyOutput{Policy: &organizations.Policy{
+		Content: aws.String("content-" + *input.PolicyId),
+	}}, nil
+}
+
+func TestServiceControlPolicies(t *testing.T) {
+	client := &fakeOrganizations{
+		parents: map[string]*organizations.Parent{
+			"123456789012": {Id: aws.String("ou-a"), Type: aws.String(organizations.ParentTypeOrganizationalUnit)},
+			"ou-a":         {Id: aws.String("r-root"), Type: aws.String(organizations.ParentTypeRoot)},
+		},
+		policies: map[string][]string{
+			"123456789012": {"p-account"},
+			"ou-a":         {"p-ou", "p-full"},
+			"r-root":       {"p-full", "p-root"},
+		},
+	}
+
+	policies, err := serviceControlPolicies(client, "123456789012")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"p-account", "p-ou", "p-full", "p-root"}
+	if len(policies) != len(want) {
+		t.Fatalf("Wrong policies. Want: %v. Have: %v", want, policies)
+	}
+	for i, id := range want {
+		if policies[i].ID != id || policies[i].Content != "content-"+id {
+			t.Errorf("Wrong policy %d. Want: %s. Have: %+v", i, id, policies[i])
+		}
+	}
+}
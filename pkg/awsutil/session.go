@@ -1,28 +1,46 @@
 package awsutil
 
 import (
-	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
 	GlobalRegionID = "global"
+
+	// CredentialsExpiryWindow is the time before the expiration of temporary
+	// credentials, when they get refreshed. This avoids that requests are
+	// signed with credentials, which expire before the request is handled.
+	CredentialsExpiryWindow = 5 * time.Minute
+
+	// DefaultAssumeRoleSessionName is the session name of assumed roles, so
+	// the removals can be identified in CloudTrail.
+	DefaultAssumeRoleSessionName = "aws-nuke"
 )
 
 var (
-	// DefaultRegionID The default region. Can be customized for non AWS implementations
+	// DefaultRegionID is the default region of credentials without a
+	// DefaultRegion.
 	DefaultRegionID = endpoints.UsEast1RegionID
 )
 
@@ -33,8 +51,63 @@ type Credentials struct {
 	SecretAccessKey string
 	SessionToken    string
 
+	// CredentialCommand is executed to retrieve the credentials. It has to
+	// print them in the format of the credential_process setting of the
+	// shared config file.
+	CredentialCommand string
+
+	// AssumeRole is assumed with the other credentials, so they only need
+	// the permission to assume the role in the target account.
+	AssumeRole config.AssumeRole
+
+	// RoleChain are assumed one after another before the AssumeRole, eg via
+	// a jump account, if the credentials cannot assume the role directly.
+	RoleChain []config.AssumeRole
+
+	// MFASerial is the MFA device, which is used to assume the first role of
+	// the role chain or the AssumeRole. MFAToken is the code for the first
+	// request. Afterwards, or if it is empty, the code is read from stdin.
+	// Profiles with mfa_serial use MFAToken as well.
+	MFASerial string
+	MFAToken  string
+
+	// UseFIPSEndpoints makes all clients use the FIPS 140-2 validated
+	// endpoints of the services.
+	UseFIPSEndpoints bool
+
+	// CABundle is the path to a PEM file with additional certificate
+	// authorities, eg of a TLS intercepting proxy.
+	CABundle string
+
+	// DefaultRegion is used for global services and the account
+	// information. It defaults to DefaultRegionID and can be customized for
+	// non AWS implementations.
+	DefaultRegion string
+
 	CustomEndpoints config.CustomEndpoints
-	session         *session.Session
+	Proxy           config.Proxy
+	HTTPClient      config.HTTPClient
+
+	// CustomizeHTTPClient is called once with the HTTP client, which is
+	// shared by all sessions, eg to tune its transport.
+	CustomizeHTTPClient func(*http.Client)
+
+	session *session.Session
+	custom  *credentials.Credentials
+	proxy   *ProxySelector
+
+	pool           *sessionPool
+	client         *http.Client
+	insecureClient *http.Client
+}
+
+// DefaultRegionID returns the DefaultRegion of the credentials or the
+// package wide DefaultRegionID, if it is not set.
+func (c *Credentials) DefaultRegionID() string {
+	if c.DefaultRegion != "" {
+		return c.DefaultRegion
+	}
+	return DefaultRegionID
 }
 
 func (c *Credentials) HasProfile() bool {
@@ -47,11 +120,70 @@ func (c *Credentials) HasKeys() bool {
 		strings.TrimSpace(c.SessionToken) != ""
 }
 
+func (c *Credentials) HasCredentialCommand() bool {
+	return strings.TrimSpace(c.CredentialCommand) != ""
+}
+
+// HasWebIdentity returns true, if the environment provides a web identity
+// token, eg via IAM roles for service accounts (IRSA) in Kubernetes. The SDK
+// exchanges it for the credentials of AWS_ROLE_ARN, if no other credentials
+// are specified.
+func (c *Credentials) HasWebIdentity() bool {
+	return strings.TrimSpace(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")) != "" &&
+		strings.TrimSpace(os.Getenv("AWS_ROLE_ARN")) != ""
+}
+
+func (c *Credentials) HasAssumeRole() bool {
+	return strings.TrimSpace(c.AssumeRole.RoleARN) != ""
+}
+
 func (c *Credentials) Validate() error {
-	if c.HasProfile() && c.HasKeys() {
-		return fmt.Errorf("You have to specify either the --profile flag or " +
-			"--access-key-id with --secret-access-key and optionally " +
-			"--session-token.\n")
+	sources := 0
+	for _, ok := range []bool{c.HasProfile(), c.HasKeys(), c.HasCredentialCommand()} {
+		if ok {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return fmt.Errorf("You have to specify either the --profile flag, " +
+			"--credential-command or --access-key-id with --secret-access-key " +
+			"and optionally --session-token.\n")
+	}
+
+	if !c.HasAssumeRole() && (c.AssumeRole.ExternalID != "" || c.AssumeRole.SessionName != "" ||
+		c.AssumeRole.SourceIdentity != "" || len(c.AssumeRole.SessionTags) > 0) {
+		return fmt.Errorf("You have to specify --assume-role-arn to use --assume-role-external-id, " +
+			"--assume-role-session-name, --assume-role-source-identity or --assume-role-tag.\n")
+	}
+
+	if c.HasAssumeRole() {
+		_, err := arn.Parse(strings.TrimSpace(c.AssumeRole.RoleARN))
+		if err != nil {
+			return fmt.Errorf("Invalid role ARN '%s' for --assume-role-arn: %v\n", c.AssumeRole.RoleARN, err)
+		}
+
+		err = validateSessionAttributes(c.AssumeRole)
+		if err != nil {
+			return fmt.Errorf("Invalid session of --assume-role-arn: %v\n", err)
+		}
+	}
+
+	if c.MFASerial != "" && !c.HasAssumeRole() && len(c.RoleChain) == 0 {
+		return fmt.Errorf("You have to specify --assume-role-arn or a role-chain to use --mfa-serial. " +
+			"Profiles specify the MFA device with mfa_serial instead.\n")
+	}
+
+	for i, hop := range c.RoleChain {
+		_, err := arn.Parse(strings.TrimSpace(hop.RoleARN))
+		if err != nil {
+			return fmt.Errorf("Invalid role ARN '%s' in step %d of the role-chain: %v\n", hop.RoleARN, i+1, err)
+		}
+
+		err = validateSessionAttributes(hop)
+		if err != nil {
+			return fmt.Errorf("Invalid session in step %d of the role-chain: %v\n", i+1, err)
+		}
 	}
 
 	return nil
@@ -61,13 +193,20 @@ func (c *Credentials) rootSession() (*session.Session, error) {
 	if c.session == nil {
 		var opts session.Options
 
-		region := DefaultRegionID
+		region := c.DefaultRegionID()
 		log.Debugf("creating new root session in %s", region)
 
 		switch {
 		case c.HasProfile() && c.HasKeys():
 			return nil, fmt.Errorf("You have to specify a profile or credentials for at least one region.")
 
+		case c.HasCredentialCommand():
+			opts = session.Options{
+				Config: aws.Config{
+					Credentials: c.awsNewProcessCredentials(),
+				},
+			}
+
 		case c.HasKeys():
 			opts = session.Options{
 				Config: aws.Config{
@@ -79,28 +218,275 @@ func (c *Credentials) rootSession() (*session.Session, error) {
 			fallthrough
 
 		default:
+			if !c.HasProfile() && c.HasWebIdentity() {
+				log.Debugf("using the web identity token of %s", os.Getenv("AWS_ROLE_ARN"))
+			} else if !c.HasProfile() {
+				log.Debugf("using the default credential chain, including ECS task roles and EC2 instance profiles")
+			}
+
 			opts = session.Options{
 				SharedConfigState:       session.SharedConfigEnable,
 				Profile:                 c.Profile,
-				AssumeRoleTokenProvider: stscreds.StdinTokenProvider,
+				AssumeRoleTokenProvider: c.mfaTokenProvider(),
 			}
 
 		}
 
 		opts.Config.Region = aws.String(region)
 		opts.Config.DisableRestProtocolURICleaning = aws.Bool(true)
+		opts.CredentialsProviderOptions = &session.CredentialsProviderOptions{
+			WebIdentityRoleProviderOptions: func(p *stscreds.WebIdentityRoleProvider) {
+				p.ExpiryWindow = CredentialsExpiryWindow
+			},
+			ProcessProviderOptions: func(p *processcreds.ProcessProvider) {
+				p.ExpiryWindow = CredentialsExpiryWindow
+			},
+		}
+
+		err := c.applyEndpointOptions(&opts)
+		if err != nil {
+			return nil, err
+		}
 
 		sess, err := session.NewSessionWithOptions(opts)
 		if err != nil {
 			return nil, err
 		}
 
+		hops := append([]config.AssumeRole{}, c.RoleChain...)
+		if c.HasAssumeRole() {
+			hops = append(hops, c.AssumeRole)
+		}
+
+		for i, hop := range hops {
+			sess, err = c.assumeRole(sess, hop, i == 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		c.session = sess
 	}
 
 	return c.session, nil
 }
 
+// sourceIdentityPattern are the values STS accepts as source identity.
+var sourceIdentityPattern = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// validateSessionAttributes checks the source identity and the session tags
+// against the limits of STS, so a typo does not fail the run only once the
+// role is assumed.
+func validateSessionAttributes(role config.AssumeRole) error {
+	if role.SourceIdentity != "" && !sourceIdentityPattern.MatchString(role.SourceIdentity) {
+		return fmt.Errorf("the source identity '%s' must have 2 to 64 characters of "+
+			"letters, digits and +=,.@-_", role.SourceIdentity)
+	}
+
+	if len(role.SessionTags) > 50 {
+		return fmt.Errorf("%d session tags exceed the limit of 50", len(role.SessionTags))
+	}
+
+	for key, value := range role.SessionTags {
+		if key == "" || len(key) > 128 {
+			return fmt.Errorf("the key of the session tag '%s' must have 1 to 128 characters", key)
+		}
+		if len(value) > 256 {
+			return fmt.Errorf("the value of the session tag '%s' exceeds 256 characters", key)
+		}
+	}
+
+	for _, key := range role.TransitiveTagKeys {
+		if _, ok := role.SessionTags[key]; !ok {
+			return fmt.Errorf("the transitive tag key '%s' is not a session tag", key)
+		}
+	}
+
+	return nil
+}
+
+// assumeRole returns a copy of the session, which uses the credentials of the
+// assumed role. The credentials of the given session are only used to assume
+// the role and to refresh its credentials, so the hops of a role chain are
+// refreshed one after another.
+func (c *Credentials) assumeRole(base *session.Session, role config.AssumeRole, first bool) (*session.Session, error) {
+	log.Debugf("assuming role %s", role.RoleARN)
+
+	stsSess := base.Copy()
+	if !c.Proxy.IsEmpty() {
+		selector, err := c.proxySelector()
+		if err != nil {
+			return nil, err
+		}
+		stsSess.Handlers.Send.PushFront(selector.Handler)
+	}
+
+	creds := stscreds.NewCredentials(stsSess, strings.TrimSpace(role.RoleARN), func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = DefaultAssumeRoleSessionName
+		if role.SessionName != "" {
+			p.RoleSessionName = role.SessionName
+		}
+		if role.ExternalID != "" {
+			p.ExternalID = aws.String(role.ExternalID)
+		}
+		if role.SourceIdentity != "" {
+			p.SourceIdentity = aws.String(role.SourceIdentity)
+		}
+		p.Tags = sessionTags(role.SessionTags)
+		p.TransitiveTagKeys = aws.StringSlice(role.TransitiveTagKeys)
+		if first && c.MFASerial != "" {
+			p.SerialNumber = aws.String(strings.TrimSpace(c.MFASerial))
+			p.TokenProvider = c.mfaTokenProvider()
+		}
+		p.ExpiryWindow = CredentialsExpiryWindow
+	})
+
+	return base.Copy(&aws.Config{Credentials: creds}), nil
+}
+
+// sessionTags converts the tags in the order of their keys, so the requests
+// are reproducible.
+func sessionTags(tags map[string]string) []*sts.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*sts.Tag, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, &sts.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	return result
+}
+
+// mfaTokenProvider returns the MFAToken for the first request and prompts for
+// the following ones, since a code cannot be used twice. The sessions of all
+// regions share the provider, so the lock makes sure that the token is used
+// only once and that only one prompt is shown at a time. Without a terminal,
+// it fails instead of waiting for a code nobody can enter.
+func (c *Credentials) mfaTokenProvider() func() (string, error) {
+	var lock sync.Mutex
+	token := strings.TrimSpace(c.MFAToken)
+	return func() (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if token != "" {
+			result := token
+			token = ""
+			return result, nil
+		}
+
+		if !stdinIsTerminal() {
+			return "", fmt.Errorf("another MFA code is needed, but stdin is not a terminal to prompt for it; " +
+				"use credentials without MFA for non-interactive runs")
+		}
+		return stscreds.StdinTokenProvider()
+	}
+}
+
+// stdinIsTerminal reports whether stdin is a terminal, so the user can be
+// prompted. It is a variable to be replaced in tests.
+var stdinIsTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (c *Credentials) proxySelector() (*ProxySelector, error) {
+	if c.proxy == nil {
+		selector, err := NewProxySelector(c.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		c.proxy = selector
+	}
+
+	return c.proxy, nil
+}
+
+func (c *Credentials) applyEndpointOptions(opts *session.Options) error {
+	if c.UseFIPSEndpoints {
+		opts.Config.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+
+	if opts.Config.HTTPClient == nil {
+		client, err := c.httpClient()
+		if err != nil {
+			return err
+		}
+		opts.Config.HTTPClient = client
+	}
+
+	return nil
+}
+
+func (c *Credentials) awsNewProcessCredentials() *credentials.Credentials {
+	return processcreds.NewCredentials(c.CredentialCommand, func(p *processcreds.ProcessProvider) {
+		p.ExpiryWindow = CredentialsExpiryWindow
+	})
+}
+
+// awsNewCustomCredentials returns the credentials for custom endpoints. They
+// are shared by all sessions, so a credential_process or credential command
+// is not executed for every session.
+func (c *Credentials) awsNewCustomCredentials() (*credentials.Credentials, error) {
+	if c.custom != nil {
+		return c.custom, nil
+	}
+
+	switch {
+	case c.HasCredentialCommand():
+		c.custom = c.awsNewProcessCredentials()
+
+	case c.HasProfile():
+		creds, err := c.awsNewProfileCredentials()
+		if err != nil {
+			return nil, err
+		}
+		c.custom = creds
+
+	case c.HasKeys():
+		c.custom = c.awsNewStaticCredentials()
+
+	default:
+		c.custom = c.awsNewDefaultCredentials()
+	}
+
+	return c.custom, nil
+}
+
+// awsNewProfileCredentials resolves the credentials of the profile from the
+// shared config files, including static keys and credential_process.
+func (c *Credentials) awsNewProfileCredentials() (*credentials.Credentials, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState:       session.SharedConfigEnable,
+		Profile:                 c.Profile,
+		AssumeRoleTokenProvider: c.mfaTokenProvider(),
+		CredentialsProviderOptions: &session.CredentialsProviderOptions{
+			ProcessProviderOptions: func(p *processcreds.ProcessProvider) {
+				p.ExpiryWindow = CredentialsExpiryWindow
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile %s: %v", c.Profile, err)
+	}
+
+	return sess.Config.Credentials, nil
+}
+
+// awsNewDefaultCredentials returns the default credential chain of the SDK.
+// Besides the environment and the shared credentials file, it contains the
+// ECS container credentials endpoint and the instance profile of EC2.
+func (c *Credentials) awsNewDefaultCredentials() *credentials.Credentials {
+	return defaults.CredChain(defaults.Config(), defaults.Handlers())
+}
+
 func (c *Credentials) awsNewStaticCredentials() *credentials.Credentials {
 	if !c.HasKeys() {
 		return credentials.NewEnvCredentials()
@@ -112,13 +498,26 @@ func (c *Credentials) awsNewStaticCredentials() *credentials.Credentials {
 	)
 }
 
+// NewSession returns a session for the service type in the region. If the
+// credentials belong to an account, the sessions are cached, so they are
+// shared by all clients of the same region and service type.
 func (c *Credentials) NewSession(region, serviceType string) (*session.Session, error) {
+	if c.pool == nil {
+		return c.newSession(region, serviceType)
+	}
+
+	return c.pool.get(sessionKey{region: region, serviceType: serviceType}, func() (*session.Session, error) {
+		return c.newSession(region, serviceType)
+	})
+}
+
+func (c *Credentials) newSession(region, serviceType string) (*session.Session, error) {
 	log.Debugf("creating new session in %s for %s", region, serviceType)
 
 	global := false
 
 	if region == GlobalRegionID {
-		region = DefaultRegionID
+		region = c.DefaultRegionID()
 		global = true
 	}
 
@@ -131,20 +530,30 @@ func (c *Credentials) NewSession(region, serviceType string) (*session.Session,
 				".service '%s' is not available in region '%s'",
 				serviceType, region))
 		}
+		creds, err := c.awsNewCustomCredentials()
+		if err != nil {
+			return nil, err
+		}
 		conf := &aws.Config{
 			Region:      &region,
 			Endpoint:    &customService.URL,
-			Credentials: c.awsNewStaticCredentials(),
+			Credentials: creds,
 		}
 		if customService.TLSInsecureSkipVerify {
-			conf.HTTPClient = &http.Client{Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			}}
+			conf.HTTPClient, err = c.insecureHTTPClient()
+			if err != nil {
+				return nil, err
+			}
 		}
 		// ll := aws.LogDebugWithEventStreamBody
 		// conf.LogLevel = &ll
-		var err error
-		sess, err = session.NewSession(conf)
+		opts := session.Options{Config: *conf}
+		err = c.applyEndpointOptions(&opts)
+		if err != nil {
+			return nil, err
+		}
+
+		sess, err = session.NewSessionWithOptions(opts)
 		if err != nil {
 			return nil, err
 		}
@@ -166,10 +575,21 @@ func (c *Credentials) NewSession(region, serviceType string) (*session.Session,
 		log.Debugf("sending AWS request:\n%s", DumpRequest(r.HTTPRequest))
 	})
 
+	if !c.Proxy.IsEmpty() {
+		selector, err := c.proxySelector()
+		if err != nil {
+			return nil, err
+		}
+		sess.Handlers.Send.PushFront(selector.Handler)
+	}
+
 	sess.Handlers.ValidateResponse.PushFront(func(r *request.Request) {
 		log.Debugf("received AWS response:\n%s", DumpResponse(r.HTTPResponse))
 	})
 
+	sess.Handlers.Retry.PushFront(Throttling.Handler)
+	sess.Handlers.Retry.PushBack(refreshExpiredCredentialsHandler)
+
 	if !isCustom {
 		sess.Handlers.Validate.PushFront(skipMissingServiceInRegionHandler)
 		sess.Handlers.Validate.PushFront(skipGlobalHandler(global))
@@ -181,7 +601,14 @@ func skipMissingServiceInRegionHandler(r *request.Request) {
 	region := *r.Config.Region
 	service := r.ClientInfo.ServiceName
 
-	rs, ok := endpoints.RegionsForService(endpoints.DefaultPartitions(), endpoints.AwsPartitionID, service)
+	// The regions of GovCloud and China are only part of their own partition.
+	partitionID := endpoints.AwsPartitionID
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if ok {
+		partitionID = partition.ID()
+	}
+
+	rs, ok := endpoints.RegionsForService(endpoints.DefaultPartitions(), partitionID, service)
 	if !ok {
 		// This means that the service does not exist and this shouldn't be handled here.
 		return
@@ -0,0 +1,183 @@
+package awsutil_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/awsutil"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+func TestCredentialsValidate(t *testing.T) {
+	cases := []struct {
+		creds   awsutil.Credentials
+		wantErr bool
+	}{
+		{creds: awsutil.Credentials{}},
+		{creds: awsutil.Credentials{Profile: "foo"}},
+		{creds: awsutil.Credentials{AccessKeyID: "foo", SecretAccessKey: "bar"}},
+		{creds: awsutil.Credentials{CredentialCommand: "vault-aws-creds sandbox"}},
+		{creds: awsutil.Credentials{Profile: "foo", AccessKeyID: "foo"}, wantErr: true},
+		{creds: awsutil.Credentials{Profile: "foo", CredentialCommand: "foo"}, wantErr: true},
+		{creds: awsutil.Credentials{AccessKeyID: "foo", CredentialCommand: "foo"}, wantErr: true},
+		{creds: awsutil.Credentials{Profile: "foo", AssumeRole: config.AssumeRole{
+			RoleARN: "arn:aws:iam::123456789012:role/nuke", ExternalID: "4711"}}},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{RoleARN: "nuke"}}, wantErr: true},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{ExternalID: "4711"}}, wantErr: true},
+		{creds: awsutil.Credentials{RoleChain: []config.AssumeRole{
+			{RoleARN: "arn:aws:iam::111111111111:role/jump"},
+			{RoleARN: "arn:aws:iam::222222222222:role/security", ExternalID: "4711"}}}},
+		{creds: awsutil.Credentials{RoleChain: []config.AssumeRole{{RoleARN: "jump"}}}, wantErr: true},
+		{creds: awsutil.Credentials{MFASerial: "arn:aws:iam::123456789012:mfa/jane",
+			AssumeRole: config.AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/nuke"}}},
+		{creds: awsutil.Credentials{Profile: "foo", MFAToken: "123456"}},
+		{creds: awsutil.Credentials{MFASerial: "arn:aws:iam::123456789012:mfa/jane"}, wantErr: true},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{
+			RoleARN:           "arn:aws:iam::123456789012:role/nuke",
+			SourceIdentity:    "jenkins",
+			SessionTags:       map[string]string{"initiator": "jenkins", "ticket": "OPS-1234"},
+			TransitiveTagKeys: []string{"ticket"}}}},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{SourceIdentity: "jenkins"}}, wantErr: true},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{SessionTags: map[string]string{"ticket": "OPS-1234"}}}, wantErr: true},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{
+			RoleARN: "arn:aws:iam::123456789012:role/nuke", SourceIdentity: "jenkins/build 42"}}, wantErr: true},
+		{creds: awsutil.Credentials{AssumeRole: config.AssumeRole{
+			RoleARN: "arn:aws:iam::123456789012:role/nuke", TransitiveTagKeys: []string{"ticket"}}}, wantErr: true},
+		{creds: awsutil.Credentials{RoleChain: []config.AssumeRole{{
+			RoleARN: "arn:aws:iam::111111111111:role/jump", SessionTags: map[string]string{"": "empty"}}}}, wantErr: true},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			err := tc.creds.Validate()
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected error for %#v.", tc.creds)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCredentialsWebIdentity(t *testing.T) {
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/aws-nuke")
+
+	creds := awsutil.Credentials{}
+	if !creds.HasWebIdentity() {
+		t.Errorf("Expected web identity from the environment.")
+	}
+
+	err := creds.Validate()
+	if err != nil {
+		t.Errorf("Web identity without other credentials should be valid: %v", err)
+	}
+
+	t.Setenv("AWS_ROLE_ARN", "")
+	if creds.HasWebIdentity() {
+		t.Errorf("Web identity needs AWS_ROLE_ARN.")
+	}
+}
+
+func TestCustomEndpointsProfileCredentialProcess(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "vault")
+	err := os.WriteFile(script, []byte(`#!/bin/sh
+echo '{"Version":1,"AccessKeyId":"AKIAVAULT","SecretAccessKey":"secret"}'
+`), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "config")
+	err = os.WriteFile(path, []byte("[profile vault]\ncredential_process = "+script+"\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("AWS_CONFIG_FILE", path)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	creds := awsutil.Credentials{
+		Profile: "vault",
+		CustomEndpoints: config.CustomEndpoints{{
+			Region: "stratoscale",
+			Services: config.CustomServices{{
+				Service: "ec2",
+				URL:     "https://stratoscale.example.com/api/v2/aws/ec2",
+			}},
+		}},
+	}
+
+	sess, err := creds.NewSession("stratoscale", "ec2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := sess.Config.Credentials.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.AccessKeyID != "AKIAVAULT" {
+		t.Errorf("Wrong access key. Want: AKIAVAULT. Have: %s", value.AccessKeyID)
+	}
+}
+
+func TestContainerCredentialsFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"AccessKeyId":"AKIATASK","SecretAccessKey":"secret","Token":"token","Expiration":"2100-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", server.URL)
+
+	cases := []struct {
+		name   string
+		region string
+		creds  awsutil.Credentials
+	}{
+		{name: "default", region: "eu-west-1"},
+		{name: "custom", region: "stratoscale", creds: awsutil.Credentials{
+			CustomEndpoints: config.CustomEndpoints{{
+				Region: "stratoscale",
+				Services: config.CustomServices{{
+					Service: "ec2",
+					URL:     "https://stratoscale.example.com/api/v2/aws/ec2",
+				}},
+			}},
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.creds.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sess, err := tc.creds.NewSession(tc.region, "ec2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value, err := sess.Config.Credentials.Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value.AccessKeyID != "AKIATASK" {
+				t.Errorf("Wrong access key. Want: AKIATASK. Have: %s", value.AccessKeyID)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package awsutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/ssocreds"
+	log "github.com/sirupsen/logrus"
+)
+
+// ssoLogin renews the SSO session of the profile with the AWS CLI. The browser
+// based login cannot be done without it. It is a variable, so tests can
+// replace it.
+var ssoLogin = func(profile string) error {
+	cmd := exec.Command("aws", "sso", "login", "--profile", profile)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// isSSOTokenError returns true, if the error was caused by a missing or
+// expired SSO token in the cache of the AWS CLI.
+func isSSOTokenError(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	if ok && aerr.Code() == ssocreds.ErrCodeSSOProviderInvalidToken {
+		return true
+	}
+
+	return err != nil && strings.Contains(err.Error(), "SSO token")
+}
+
+// renewSSOSession runs the SSO login for the profile, if the error was caused
+// by an expired SSO session and the login can be done interactively.
+// Otherwise, it returns the error with a hint how to login.
+func (c *Credentials) renewSSOSession(err error) error {
+	if !c.HasProfile() || !isSSOTokenError(err) {
+		return err
+	}
+
+	if !stdinIsTerminal() {
+		return fmt.Errorf("the SSO session of the profile %s is missing or expired; "+
+			"run 'aws sso login --profile %s': %v", c.Profile, c.Profile, err)
+	}
+
+	log.Infof("The SSO session of the profile %s is missing or expired. Running 'aws sso login'.", c.Profile)
+	lerr := ssoLogin(c.Profile)
+	if lerr != nil {
+		return fmt.Errorf("failed to login with 'aws sso login --profile %s': %v", c.Profile, lerr)
+	}
+
+	return nil
+}
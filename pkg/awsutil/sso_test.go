@@ -0,0 +1,46 @@
+package awsutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/ssocreds"
+)
+
+func TestIsSSOTokenError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{err: awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "the SSO session has expired or is invalid", nil), want: true},
+		{err: fmt.Errorf("cached SSO token is expired, or not present, and cannot be refreshed"), want: true},
+		{err: awserr.New("AccessDenied", "not allowed", nil), want: false},
+		{err: errors.New("connection refused"), want: false},
+		{err: nil, want: false},
+	}
+
+	for _, tc := range cases {
+		have := isSSOTokenError(tc.err)
+		if have != tc.want {
+			t.Errorf("Wrong result for %v. Want: %t. Have: %t", tc.err, tc.want, have)
+		}
+	}
+}
+
+func TestRenewSSOSessionWithoutProfile(t *testing.T) {
+	called := false
+	login := ssoLogin
+	defer func() { ssoLogin = login }()
+	ssoLogin = func(profile string) error {
+		called = true
+		return nil
+	}
+
+	err := awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "the SSO session has expired or is invalid", nil)
+	c := &Credentials{AccessKeyID: "foo", SecretAccessKey: "bar"}
+	if c.renewSSOSession(err) != err || called {
+		t.Errorf("Credentials without profile must not login.")
+	}
+}
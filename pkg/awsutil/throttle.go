@@ -0,0 +1,76 @@
+package awsutil
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Throttling counts the throttled requests of all sessions created by
+// Credentials.NewSession.
+var Throttling = NewThrottleStats()
+
+// ThrottleCount is the number of throttled requests of a service in a region.
+type ThrottleCount struct {
+	Service string `json:"service"`
+	Region  string `json:"region"`
+	Count   int    `json:"count"`
+}
+
+// ThrottleStats counts throttling responses per service and region. It is safe
+// for concurrent use, since the regions are scanned in parallel.
+type ThrottleStats struct {
+	lock   sync.Mutex
+	counts map[[2]string]int
+}
+
+func NewThrottleStats() *ThrottleStats {
+	return &ThrottleStats{
+		counts: map[[2]string]int{},
+	}
+}
+
+// Handler counts the request, if it failed because of throttling. Every
+// attempt is counted, since the SDK retries throttled requests.
+func (t *ThrottleStats) Handler(r *request.Request) {
+	if r.Error == nil || !r.IsErrorThrottle() {
+		return
+	}
+
+	t.Add(r.ClientInfo.ServiceName, aws.StringValue(r.Config.Region))
+}
+
+func (t *ThrottleStats) Add(service, region string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.counts[[2]string{service, region}]++
+}
+
+// Counts returns the throttled requests, sorted by count in descending order.
+func (t *ThrottleStats) Counts() []ThrottleCount {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := make([]ThrottleCount, 0, len(t.counts))
+	for key, count := range t.counts {
+		result = append(result, ThrottleCount{
+			Service: key[0],
+			Region:  key[1],
+			Count:   count,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Service != result[j].Service {
+			return result[i].Service < result[j].Service
+		}
+		return result[i].Region < result[j].Region
+	})
+
+	return result
+}
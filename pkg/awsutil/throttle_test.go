@@ -0,0 +1,42 @@
+package awsutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestThrottleStatsHandler(t *testing.T) {
+	stats := NewThrottleStats()
+
+	newRequest := func(service, region string, err error) *request.Request {
+		return &request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceName: service},
+			Config:     aws.Config{Region: aws.String(region)},
+			Error:      err,
+		}
+	}
+
+	throttled := awserr.New("ThrottlingException", "Rate exceeded", nil)
+	other := awserr.New("AccessDenied", "denied", nil)
+
+	stats.Handler(newRequest("ec2", "eu-west-1", throttled))
+	stats.Handler(newRequest("ec2", "eu-west-1", throttled))
+	stats.Handler(newRequest("s3", "us-east-1", throttled))
+	stats.Handler(newRequest("s3", "us-east-1", other))
+	stats.Handler(newRequest("iam", "us-east-1", nil))
+
+	want := []ThrottleCount{
+		{Service: "ec2", Region: "eu-west-1", Count: 2},
+		{Service: "s3", Region: "us-east-1", Count: 1},
+	}
+
+	have := stats.Counts()
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Wrong counts. Want: %#v. Have: %#v", want, have)
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// AccountIDPlaceholder is replaced by the account ID in the role-arn of the
+// account-role. It predates the template syntax and is still supported.
+const AccountIDPlaceholder = "{account-id}"
+
+// AccountRoleData is passed to the role-arn template of the account-role, eg
+// "arn:aws:iam::{{.AccountID}}:role/aws-nuke".
+type AccountRoleData struct {
+	AccountID string
+}
+
+func (c *Nuke) validateAccountRole() error {
+	r := c.AccountRole
+	if r.RoleARN == "" {
+		if r.ExternalID != "" || r.SessionName != "" || r.SourceIdentity != "" ||
+			len(r.SessionTags) > 0 || len(r.TransitiveTagKeys) > 0 {
+			return fmt.Errorf("The account-role does not specify a role-arn.")
+		}
+		return nil
+	}
+
+	// The role-arn has to depend on the account, otherwise every run would
+	// assume the same role.
+	first, err := r.ForAccount("111111111111")
+	if err != nil {
+		return err
+	}
+	second, err := r.ForAccount("222222222222")
+	if err != nil {
+		return err
+	}
+	if first.RoleARN == second.RoleARN {
+		return fmt.Errorf("The role-arn '%s' of the account-role does not contain "+
+			"the account ID as {{.AccountID}} or %s.", r.RoleARN, AccountIDPlaceholder)
+	}
+
+	return nil
+}
+
+// AccountIDs returns the sorted IDs of the accounts section, which are not
+// blacklisted. These are the accounts of a run with --all-accounts.
+func (c *Nuke) AccountIDs() []string {
+	ids := []string{}
+	for id := range c.Accounts {
+		if !c.InBlacklist(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ForAccount returns the role with its role-arn rendered as template for the
+// account.
+func (r AssumeRole) ForAccount(accountID string) (AssumeRole, error) {
+	tmpl, err := template.New("role-arn").Option("missingkey=error").Parse(r.RoleARN)
+	if err != nil {
+		return r, fmt.Errorf("Failed to parse the role-arn '%s' of the account-role: %v", r.RoleARN, err)
+	}
+
+	b := new(strings.Builder)
+	err = tmpl.Execute(b, AccountRoleData{AccountID: accountID})
+	if err != nil {
+		return r, fmt.Errorf("Failed to render the role-arn '%s' of the account-role: %v", r.RoleARN, err)
+	}
+
+	r.RoleARN = strings.ReplaceAll(b.String(), AccountIDPlaceholder, accountID)
+	return r, nil
+}
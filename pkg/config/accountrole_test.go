@@ -0,0 +1,64 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAccountRole(t *testing.T) {
+	cases := []struct {
+		role  AssumeRole
+		valid bool
+	}{
+		{AssumeRole{}, true},
+		{AssumeRole{RoleARN: "arn:aws:iam::{account-id}:role/aws-nuke"}, true},
+		{AssumeRole{RoleARN: "arn:aws:iam::{account-id}:role/aws-nuke", ExternalID: "nuke"}, true},
+		{AssumeRole{RoleARN: "arn:aws:iam::{{.AccountID}}:role/aws-nuke"}, true},
+		{AssumeRole{RoleARN: "arn:aws:iam::012345678901:role/aws-nuke"}, false},
+		{AssumeRole{RoleARN: "arn:aws:iam::{{.AccountID}:role/aws-nuke"}, false},
+		{AssumeRole{RoleARN: "arn:aws:iam::{{.Account}}:role/aws-nuke"}, false},
+		{AssumeRole{SessionName: "aws-nuke"}, false},
+	}
+
+	for i, tc := range cases {
+		c := &Nuke{AccountRole: tc.role}
+		err := c.validateAccountRole()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d: wrong validation result for %+v: %v", i, tc.role, err)
+		}
+	}
+}
+
+func TestAccountIDs(t *testing.T) {
+	c := &Nuke{
+		AccountBlacklist: []string{"999999999999"},
+		Accounts: map[string]Account{
+			"222222222222": {},
+			"999999999999": {},
+			"111111111111": {},
+		},
+	}
+
+	want := []string{"111111111111", "222222222222"}
+	have := c.AccountIDs()
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Wrong account IDs. Want: %v. Have: %v", want, have)
+	}
+
+}
+
+func TestAssumeRoleForAccount(t *testing.T) {
+	for _, arn := range []string{
+		"arn:aws:iam::{account-id}:role/aws-nuke",
+		"arn:aws:iam::{{.AccountID}}:role/aws-nuke",
+		"arn:aws:iam::{{ .AccountID }}:role/aws-nuke",
+	} {
+		role, err := AssumeRole{RoleARN: arn, ExternalID: "nuke"}.ForAccount("111111111111")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if role.RoleARN != "arn:aws:iam::111111111111:role/aws-nuke" || role.ExternalID != "nuke" {
+			t.Errorf("Wrong role for %s: %+v", arn, role)
+		}
+	}
+}
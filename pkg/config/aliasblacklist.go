@@ -0,0 +1,38 @@
+package config
+
+import "fmt"
+
+func (c *Nuke) validateAccountAliasBlacklist() error {
+	for i, f := range c.AccountAliasBlacklist {
+		switch f.Type {
+		case FilterTypeEmpty, FilterTypeExact, FilterTypeGlob, FilterTypeExtendedGlob, FilterTypeRegex, FilterTypeContains:
+		default:
+			return fmt.Errorf("The entry %d of the account-alias-blacklist has the unsupported type '%s'. "+
+				"Supported types are exact, glob, extendedGlob, regex and contains.", i, f.Type)
+		}
+
+		if f.Property != "" || f.File != "" {
+			return fmt.Errorf("The entry %d of the account-alias-blacklist must not specify a property or file.", i)
+		}
+
+		err := f.Validate()
+		if err != nil {
+			return fmt.Errorf("The entry %d of the account-alias-blacklist is invalid: %v.", i, err)
+		}
+	}
+
+	return nil
+}
+
+// InAliasBlacklist returns the entry of the account-alias-blacklist, which
+// matches the alias. The second return value is false, if none matches.
+func (c *Nuke) InAliasBlacklist(alias string) (Filter, bool) {
+	for _, f := range c.AccountAliasBlacklist {
+		match, err := f.Match(alias)
+		if err == nil && isTrue(f.Invert) != match {
+			return f, true
+		}
+	}
+
+	return Filter{}, false
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccountAliasBlacklist(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := os.WriteFile(path, []byte(`
+account-blacklist: [ "999999999999" ]
+account-alias-blacklist:
+- shared-services
+- { type: regex, value: ".*-prd.*" }
+- { type: glob, value: "payments-*", case-insensitive: "true" }
+accounts:
+  "111111111111": {}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		alias string
+		valid bool
+	}{
+		{"sandbox-dev", true},
+		{"shared-services", false},
+		{"shared-services-dev", true},
+		{"data-prd-eu", false},
+		{"Payments-Sandbox", false},
+	}
+
+	for _, tc := range cases {
+		err := c.ValidateAccount("111111111111", []string{tc.alias})
+		if (err == nil) != tc.valid {
+			t.Errorf("Wrong validation result for the alias %s: %v", tc.alias, err)
+		}
+	}
+}
+
+func TestValidateAccountAliasBlacklist(t *testing.T) {
+	cases := []struct {
+		filter Filter
+		valid  bool
+	}{
+		{Filter{Type: FilterTypeRegex, Value: ".*-prd.*"}, true},
+		{Filter{Type: FilterTypeRegex, Value: "(prd"}, false},
+		{Filter{Type: FilterTypeDateOlderThan, Value: "24h"}, false},
+		{Filter{Type: FilterTypeExact, Value: "prd", Property: "Name"}, false},
+	}
+
+	for i, tc := range cases {
+		c := &Nuke{AccountAliasBlacklist: []Filter{tc.filter}}
+		err := c.validateAccountAliasBlacklist()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d: wrong validation result for %+v: %v", i, tc.filter, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// builtinPresetFiles contains filter presets for resources of well-known
+// tools and services, which can be used without defining them in the config.
+//
+//go:embed presets/*.yaml
+var builtinPresetFiles embed.FS
+
+var (
+	builtinPresetsOnce sync.Once
+	builtinPresets     map[string]PresetDefinitions
+	builtinPresetsErr  error
+)
+
+func loadBuiltinPresets() (map[string]PresetDefinitions, error) {
+	builtinPresetsOnce.Do(func() {
+		builtinPresets = map[string]PresetDefinitions{}
+
+		entries, err := builtinPresetFiles.ReadDir("presets")
+		if err != nil {
+			builtinPresetsErr = err
+			return
+		}
+
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), ".yaml")
+
+			raw, err := builtinPresetFiles.ReadFile("presets/" + entry.Name())
+			if err != nil {
+				builtinPresetsErr = err
+				return
+			}
+
+			var preset PresetDefinitions
+			err = yaml.UnmarshalStrict(raw, &preset)
+			if err != nil {
+				builtinPresetsErr = fmt.Errorf("Failed to parse built-in preset '%s': %v", name, err)
+				return
+			}
+
+			builtinPresets[name] = preset
+		}
+	})
+
+	return builtinPresets, builtinPresetsErr
+}
+
+// BuiltinPreset returns the built-in preset with the given name.
+func BuiltinPreset(name string) (PresetDefinitions, bool, error) {
+	presets, err := loadBuiltinPresets()
+	if err != nil {
+		return PresetDefinitions{}, false, err
+	}
+
+	preset, ok := presets[name]
+	return preset, ok, nil
+}
+
+// BuiltinPresetNames returns the names of all built-in presets.
+func BuiltinPresetNames() []string {
+	presets, _ := loadBuiltinPresets()
+
+	names := []string{}
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// preset returns the preset with the given name. Presets of the config take
+// precedence over the built-in ones.
+func (c *Nuke) preset(name string) (PresetDefinitions, error) {
+	preset, ok := c.Presets[name]
+	if ok {
+		return preset, nil
+	}
+
+	preset, ok, err := BuiltinPreset(name)
+	if err != nil {
+		return preset, err
+	}
+	if !ok {
+		return preset, fmt.Errorf("Could not find filter preset '%s'", name)
+	}
+
+	return preset, nil
+}
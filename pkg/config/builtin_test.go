@@ -0,0 +1,123 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestBuiltinPresetsAreValid(t *testing.T) {
+	names := BuiltinPresetNames()
+	if len(names) == 0 {
+		t.Fatal("no built-in presets found")
+	}
+
+	for _, name := range names {
+		preset, ok, err := BuiltinPreset(name)
+		if err != nil || !ok {
+			t.Fatalf("failed to load built-in preset %s: %t, %v", name, ok, err)
+		}
+
+		for resourceType, filters := range preset.Filters {
+			for i, filter := range filters {
+				err := filter.Validate()
+				if err != nil {
+					t.Errorf("invalid filter %s.%s[%d]: %v", name, resourceType, i, err)
+				}
+				if filter.matchesEverything() {
+					t.Errorf("filter %s.%s[%d] matches everything", name, resourceType, i)
+				}
+			}
+		}
+	}
+}
+
+func TestBuiltinPresetOverride(t *testing.T) {
+	config := Nuke{
+		Accounts: map[string]Account{
+			"555133742": Account{Presets: []string{"control-tower"}},
+			"555421337": Account{Presets: []string{"control-tower"}},
+		},
+		Presets: map[string]PresetDefinitions{},
+	}
+
+	filters, err := config.Filters("555133742")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters["IAMRole"]) == 0 {
+		t.Errorf("expected IAMRole filters from the built-in control-tower preset")
+	}
+
+	config.Presets["control-tower"] = PresetDefinitions{
+		Filters: Filters{"S3Bucket": {NewExactFilter("foo")}},
+	}
+
+	filters, err = config.Filters("555421337")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters["IAMRole"]) != 0 || len(filters["S3Bucket"]) != 1 {
+		t.Errorf("expected the preset of the config to override the built-in one, got %#v", filters)
+	}
+
+	config.Accounts["555133742"] = Account{Presets: []string{"missing"}}
+	_, err = config.Filters("555133742")
+	if err == nil {
+		t.Errorf("expected error for missing preset")
+	}
+}
+
+func TestBuiltinPresetMatches(t *testing.T) {
+	cases := []struct {
+		preset       string
+		resourceType string
+		value        string
+		match        bool
+	}{
+		{"control-tower", "IAMRole", "AWSControlTowerExecution", true},
+		{"control-tower", "IAMRole", "aws-controltower-ConfigRecorderRole", true},
+		{"control-tower", "IAMRole", "AWSAFTExecution", true},
+		{"control-tower", "IAMRole", "stacksets-exec-0123456789abcdef", true},
+		{"control-tower", "IAMRole", "my-app-role", false},
+		{"control-tower", "CloudFormationStack", "StackSet-AWSControlTowerBP-BASELINE-CONFIG-1234", true},
+		{"control-tower", "CloudFormationStack", "my-app", false},
+		{"control-tower", "CloudTrailTrail", "aws-controltower-BaselineCloudTrail", true},
+		{"control-tower", "CloudWatchEventsRule", "Rule: aws-controltower-ConfigComplianceChangeEventRule", true},
+		{"control-tower", "CloudWatchLogsLogGroup", "/aws/lambda/aws-controltower-NotificationForwarder", true},
+		{"control-tower", "SNSTopic", "TopicARN: arn:aws:sns:eu-west-1:012345678901:aws-controltower-SecurityNotifications", true},
+		{"control-tower", "SNSTopic", "TopicARN: arn:aws:sns:eu-west-1:012345678901:my-topic", false},
+		{"control-tower", "SSMParameter", "/aft/account-request/custom-fields/foo", true},
+		{"control-tower", "S3Bucket", "aws-controltower-logs-012345678901-eu-west-1", true},
+		{"cdk-bootstrap", "CloudFormationStack", "CDKToolkit", true},
+		{"cdk-bootstrap", "IAMRole", "cdk-hnb659fds-deploy-role-012345678901-eu-west-1", true},
+		{"cdk-bootstrap", "IAMRole", "cdk-app-lambda-role", false},
+		{"cdk-bootstrap", "S3Bucket", "cdk-hnb659fds-assets-012345678901-eu-west-1", true},
+		{"cdk-bootstrap", "ECRRepository", "Repository: cdk-hnb659fds-container-assets-012345678901-eu-west-1", true},
+		{"cdk-bootstrap", "SSMParameter", "/cdk-bootstrap/hnb659fds/version", true},
+		{"iam-identity-center", "IAMRole", "AWSReservedSSO_AdministratorAccess_0123456789abcdef", true},
+		{"iam-identity-center", "IAMRole", "Admin", false},
+		{"iam-identity-center", "IAMSAMLProvider", "arn:aws:iam::012345678901:saml-provider/AWSSSO_0123456789abcdef_DO_NOT_DELETE", true},
+		{"organization-trails", "CloudTrailTrail", "true", true},
+		{"organization-trails", "CloudTrailTrail", "false", false},
+		{"organization-trails", "ConfigServiceConfigRule", "OrgConfigRule-s3-bucket-public-read-prohibited-abcdef", true},
+	}
+
+	for _, tc := range cases {
+		preset, ok, err := BuiltinPreset(tc.preset)
+		if err != nil || !ok {
+			t.Fatalf("failed to load built-in preset %s: %t, %v", tc.preset, ok, err)
+		}
+
+		match := false
+		for _, filter := range preset.Filters[tc.resourceType] {
+			m, err := filter.Match(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			match = match || m
+		}
+
+		if match != tc.match {
+			t.Errorf("Wrong match of %s %s '%s'. Want: %t. Have: %t", tc.preset, tc.resourceType, tc.value, tc.match, match)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 
@@ -12,50 +15,231 @@ import (
 )
 
 type ResourceTypes struct {
-	Targets  types.Collection `yaml:"targets"`
-	Excludes types.Collection `yaml:"excludes"`
+	Targets  types.Collection `yaml:"targets,omitempty"`
+	Excludes types.Collection `yaml:"excludes,omitempty"`
+
+	// ListOnly are resource types, which are scanned and reported, but never
+	// removed.
+	ListOnly types.Collection `yaml:"list-only,omitempty"`
+
+	// CloudControl are CloudFormation types (eg AWS::ECR::PublicRepository),
+	// which are listed and removed via the Cloud Control API. They can be
+	// used like other resource types with their CloudFormation name.
+	CloudControl types.Collection `yaml:"cloud-control,omitempty"`
 }
 
 type Account struct {
-	Filters       Filters       `yaml:"filters"`
-	ResourceTypes ResourceTypes `yaml:"resource-types"`
-	Presets       []string      `yaml:"presets"`
+	Regions       []string      `yaml:"regions,omitempty"`
+	Filters       Filters       `yaml:"filters,omitempty"`
+	ResourceTypes ResourceTypes `yaml:"resource-types,omitempty"`
+	Presets       []string      `yaml:"presets,omitempty"`
+
+	// InheritDefaults set to false ignores the defaults section of the
+	// config for the account.
+	InheritDefaults *bool `yaml:"inherit-defaults,omitempty"`
 }
 
 type Nuke struct {
-	AccountBlacklist []string                     `yaml:"account-blacklist"`
-	Regions          []string                     `yaml:"regions"`
-	Accounts         map[string]Account           `yaml:"accounts"`
-	ResourceTypes    ResourceTypes                `yaml:"resource-types"`
-	Presets          map[string]PresetDefinitions `yaml:"presets"`
-	FeatureFlags     FeatureFlags                 `yaml:"feature-flags"`
-	CustomEndpoints  CustomEndpoints              `yaml:"endpoints"`
+	SchemaVersion    int                          `yaml:"schema-version,omitempty"`
+	MinVersion       string                       `yaml:"min-version,omitempty"`
+	AccountBlacklist []string                     `yaml:"account-blacklist,omitempty"`
+	Regions          []string                     `yaml:"regions,omitempty"`
+	Accounts         map[string]Account           `yaml:"accounts,omitempty"`
+	Defaults         *Account                     `yaml:"defaults,omitempty"`
+	ResourceTypes    ResourceTypes                `yaml:"resource-types,omitempty"`
+	Presets          map[string]PresetDefinitions `yaml:"presets,omitempty"`
+	FeatureFlags     FeatureFlags                 `yaml:"feature-flags,omitempty"`
+	CustomEndpoints  CustomEndpoints              `yaml:"endpoints,omitempty"`
+	Redaction        Redaction                    `yaml:"redaction,omitempty"`
+	Proxy            Proxy                        `yaml:"proxy,omitempty"`
+	HTTPClient       HTTPClient                   `yaml:"http-client,omitempty"`
+	AssumeRole       AssumeRole                   `yaml:"assume-role,omitempty"`
+	RoleChain        []AssumeRole                 `yaml:"role-chain,omitempty"`
+	RunProfiles      map[string]RunProfile        `yaml:"profiles,omitempty"`
+	ErrorPolicies    []ErrorPolicy                `yaml:"error-policies,omitempty"`
+
+	// AccountRole is assumed into every account of the accounts section, if
+	// aws-nuke runs with --all-accounts. Its role-arn is a template, which is
+	// rendered with the AccountRoleData of the account.
+	AccountRole AssumeRole `yaml:"account-role,omitempty"`
+
+	// AccountAliasBlacklist protects accounts by their aliases. Its entries
+	// are filters without a property, eg a regex like .*-prod.*, so new
+	// production accounts are protected without listing their IDs.
+	AccountAliasBlacklist []Filter `yaml:"account-alias-blacklist,omitempty"`
+
+	// OrgDiscovery selects the accounts of the organization, which are
+	// nuked with --org-discover instead of the accounts section.
+	OrgDiscovery OrgDiscovery `yaml:"org-discovery,omitempty"`
+
+	// MaxDeletions caps the number of resources per resource type, which a
+	// run may remove. Exceeding it usually means that a filter got broken.
+	MaxDeletions map[string]int `yaml:"max-deletions,omitempty"`
+
+	// AllowedRunWindows restrict runs with --no-dry-run to certain times of
+	// the week, unless --override-window is specified.
+	AllowedRunWindows []string `yaml:"allowed-run-windows,omitempty"`
+
+	// Report is the storage of the report, if --output is not specified.
+	Report ReportStorage `yaml:"report,omitempty"`
+}
+
+// RunProfile bundles the settings for a certain kind of run (eg a nightly
+// cleanup of cheap resources or a full teardown). It is selected with
+// --run-profile.
+type RunProfile struct {
+	// ResourceTypes further limit the resource types of the global and
+	// account config.
+	ResourceTypes ResourceTypes `yaml:"resource-types,omitempty"`
+
+	// MaxWaitRetries is used, if --max-wait-retries is not specified.
+	MaxWaitRetries int `yaml:"max-wait-retries,omitempty"`
+
+	// ParallelQueries is the number of concurrent list requests per region.
+	ParallelQueries int `yaml:"parallel-queries,omitempty"`
 }
 
 type FeatureFlags struct {
 	DisableDeletionProtection struct {
-		RDSInstance         bool `yaml:"RDSInstance"`
-		EC2Instance         bool `yaml:"EC2Instance"`
-		CloudformationStack bool `yaml:"CloudformationStack"`
-	} `yaml:"disable-deletion-protection"`
+		RDSInstance         bool `yaml:"RDSInstance,omitempty"`
+		EC2Instance         bool `yaml:"EC2Instance,omitempty"`
+		CloudformationStack bool `yaml:"CloudformationStack,omitempty"`
+	} `yaml:"disable-deletion-protection,omitempty"`
+
+	// DeletionProtectionExceptions are glob patterns of resource names,
+	// whose deletion protection is never disabled.
+	DeletionProtectionExceptions struct {
+		CloudformationStack []string `yaml:"CloudformationStack,omitempty"`
+	} `yaml:"deletion-protection-exceptions,omitempty"`
+
+	// RemoveCDKBootstrapLast delays the removal of the CDK bootstrap
+	// resources until all other CloudFormation stacks are removed, since the
+	// stacks of CDK apps use its roles for their deletion.
+	RemoveCDKBootstrapLast bool `yaml:"remove-cdk-bootstrap-last,omitempty"`
+
+	// RetainFailedStackResources deletes CloudFormation stacks in
+	// DELETE_FAILED again, while retaining the resources which could not be
+	// deleted.
+	RetainFailedStackResources bool `yaml:"retain-failed-stack-resources,omitempty"`
+
+	// DisassociateEC2Addresses disassociates elastic IPs from their instances
+	// and network interfaces before releasing them.
+	DisassociateEC2Addresses bool `yaml:"disassociate-ec2-addresses,omitempty"`
+
+	// EnableCloudControlFallback enables the CloudControlResource type, which
+	// deletes tagged resources of otherwise unsupported services via the
+	// Cloud Control API.
+	EnableCloudControlFallback bool `yaml:"enable-cloud-control-fallback,omitempty"`
+
+	// UseS3Inventory removes the objects of S3 buckets with an S3 Inventory
+	// based on its latest report, before listing the remaining objects.
+	UseS3Inventory bool `yaml:"use-s3-inventory,omitempty"`
+}
+
+// Redaction specifies sensitive values, which are hidden in the log output and
+// in reports.
+type Redaction struct {
+	// Properties are glob patterns of property names, whose values are always
+	// hidden.
+	Properties []string `yaml:"properties,omitempty"`
+
+	// Patterns are regular expressions. All matching parts of property
+	// values, resource IDs and error messages are hidden.
+	Patterns []string `yaml:"patterns,omitempty"`
+}
+
+// Proxy configures the HTTP proxy used for the AWS API. Without it, the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are used.
+type Proxy struct {
+	// URL is the proxy for all requests, which are not matched by an
+	// override.
+	URL string `yaml:"url,omitempty"`
+
+	// NoProxy are host names or domain suffixes, which are always accessed
+	// directly.
+	NoProxy []string `yaml:"no-proxy,omitempty"`
+
+	// Overrides select a different proxy for specific services and regions.
+	// The first matching override is used.
+	Overrides []ProxyOverride `yaml:"overrides,omitempty"`
+}
+
+// AssumeRole is the role, which is assumed in the target account with the
+// given credentials. The --assume-role-* flags take precedence. It is also a
+// single hop of a role chain.
+type AssumeRole struct {
+	RoleARN     string `yaml:"role-arn,omitempty"`
+	ExternalID  string `yaml:"external-id,omitempty"`
+	SessionName string `yaml:"session-name,omitempty"`
+
+	// SourceIdentity and SessionTags show up in the CloudTrail events of the
+	// assumed session, eg to attribute the deletions to a pipeline. Setting
+	// them needs the sts:SetSourceIdentity and sts:TagSession permissions in
+	// the trust policy of the role.
+	SourceIdentity string            `yaml:"source-identity,omitempty"`
+	SessionTags    map[string]string `yaml:"session-tags,omitempty"`
+
+	// TransitiveTagKeys are the session tags, which are passed on to the
+	// following roles of a role chain.
+	TransitiveTagKeys []string `yaml:"transitive-tag-keys,omitempty"`
+}
+
+// HTTPClient tunes the HTTP client of the AWS API, which is shared by all
+// sessions. Zero values keep the defaults of Go.
+type HTTPClient struct {
+	// Timeout limits a single request including reading the response.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// KeepAlive is the interval of TCP keep-alive probes.
+	KeepAlive time.Duration `yaml:"keep-alive,omitempty"`
+
+	// IdleConnTimeout closes connections, which were idle for that long.
+	IdleConnTimeout time.Duration `yaml:"idle-conn-timeout,omitempty"`
+
+	// MaxIdleConnsPerHost is the number of idle connections per host, which
+	// are kept for reuse. It defaults to 16, since the listers of a region
+	// query the same endpoints in parallel.
+	MaxIdleConnsPerHost int `yaml:"max-idle-conns-per-host,omitempty"`
+
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool `yaml:"disable-keep-alives,omitempty"`
+}
+
+type ProxyOverride struct {
+	// Services are the endpoint names of the services (eg s3, ec2, logs). An
+	// empty list matches all services.
+	Services []string `yaml:"services,omitempty"`
+
+	// Regions are the matched regions. An empty list matches all regions.
+	Regions []string `yaml:"regions,omitempty"`
+
+	// URL is the proxy for the matching requests.
+	URL string `yaml:"url,omitempty"`
+
+	// Direct disables the proxy for the matching requests.
+	Direct bool `yaml:"direct,omitempty"`
+}
+
+func (p Proxy) IsEmpty() bool {
+	return p.URL == "" && len(p.NoProxy) == 0 && len(p.Overrides) == 0
 }
 
 type PresetDefinitions struct {
-	Filters Filters `yaml:"filters"`
+	Filters Filters `yaml:"filters,omitempty"`
 }
 
 type CustomService struct {
-	Service               string `yaml:"service"`
-	URL                   string `yaml:"url"`
-	TLSInsecureSkipVerify bool   `yaml:"tls_insecure_skip_verify"`
+	Service               string `yaml:"service,omitempty"`
+	URL                   string `yaml:"url,omitempty"`
+	TLSInsecureSkipVerify bool   `yaml:"tls_insecure_skip_verify,omitempty"`
 }
 
 type CustomServices []*CustomService
 
 type CustomRegion struct {
-	Region                string         `yaml:"region"`
-	Services              CustomServices `yaml:"services"`
-	TLSInsecureSkipVerify bool           `yaml:"tls_insecure_skip_verify"`
+	Region                string         `yaml:"region,omitempty"`
+	Services              CustomServices `yaml:"services,omitempty"`
+	TLSInsecureSkipVerify bool           `yaml:"tls_insecure_skip_verify,omitempty"`
 }
 
 type CustomEndpoints []*CustomRegion
@@ -74,10 +258,50 @@ func Load(path string) (*Nuke, error) {
 		return nil, err
 	}
 
+	// The deprecations are resolved first, so deprecated types of the
+	// defaults are merged with their replacements in the accounts.
 	if err := config.resolveDeprecations(); err != nil {
 		return nil, err
 	}
 
+	config.applyDefaults()
+
+	if err := config.validateErrorPolicies(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateMaxDeletions(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateRunWindows(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateReportStorage(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateAccountRole(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateAccountAliasBlacklist(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateOrgDiscovery(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateFilters(); err != nil {
+		return nil, err
+	}
+
+	if err := config.loadFilterFiles(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -118,6 +342,11 @@ func (c *Nuke) ValidateAccount(accountID string, aliases []string) error {
 			return fmt.Errorf("You are trying to nuke an account with the alias '%s', "+
 				"but it has the substring 'prod' in it. Aborting.", alias)
 		}
+
+		if f, ok := c.InAliasBlacklist(alias); ok {
+			return fmt.Errorf("You are trying to nuke an account with the alias '%s', "+
+				"but it matches the %s '%s' of the account-alias-blacklist. Aborting.", alias, f.Type, f.Value)
+		}
 	}
 
 	if _, ok := c.Accounts[accountID]; !ok {
@@ -128,27 +357,68 @@ func (c *Nuke) ValidateAccount(accountID string, aliases []string) error {
 	return nil
 }
 
-func (c *Nuke) Filters(accountID string) (Filters, error) {
-	account := c.Accounts[accountID]
-	filters := account.Filters
+// RunProfile returns the run profile with the given name. An empty name
+// returns an empty profile, which does not change any settings.
+func (c *Nuke) RunProfile(name string) (RunProfile, error) {
+	if name == "" {
+		return RunProfile{}, nil
+	}
 
-	if filters == nil {
-		filters = Filters{}
+	profile, ok := c.RunProfiles[name]
+	if !ok {
+		return RunProfile{}, fmt.Errorf("The run profile '%s' isn't defined in the config.", name)
 	}
 
-	if account.Presets == nil {
-		return filters, nil
+	if profile.ParallelQueries < 0 {
+		return RunProfile{}, fmt.Errorf("The run profile '%s' has an invalid value for parallel-queries.", name)
 	}
 
-	for _, presetName := range account.Presets {
-		notFound := fmt.Errorf("Could not find filter preset '%s'", presetName)
-		if c.Presets == nil {
-			return nil, notFound
+	return profile, nil
+}
+
+// CloudControlTypes returns the CloudFormation types, which are listed via
+// the Cloud Control API for the account. Unlike targets, the global, account
+// specific and run profile types are combined.
+func (c *Nuke) CloudControlTypes(accountID string, profile RunProfile) types.Collection {
+	return types.Collection{}.
+		Union(c.ResourceTypes.CloudControl).
+		Union(c.Accounts[accountID].ResourceTypes.CloudControl).
+		Union(profile.ResourceTypes.CloudControl)
+}
+
+func (c *Nuke) validateMaxDeletions() error {
+	for resourceType, max := range c.MaxDeletions {
+		if max < 0 {
+			return fmt.Errorf("The max-deletions of %s must not be negative.", resourceType)
 		}
+	}
 
-		preset, ok := c.Presets[presetName]
-		if !ok {
-			return nil, notFound
+	return nil
+}
+
+// AccountRegions returns the regions, which should be scanned for the given
+// account. An account specific region list overrides the global one.
+func (c *Nuke) AccountRegions(accountID string) []string {
+	account := c.Accounts[accountID]
+	if len(account.Regions) > 0 {
+		return account.Regions
+	}
+
+	return c.Regions
+}
+
+// Filters returns the filters of the account including the ones of its
+// presets. The result is a new map, so the config is not changed by merging
+// the presets.
+func (c *Nuke) Filters(accountID string) (Filters, error) {
+	account := c.Accounts[accountID]
+	filters := Filters{}
+	filters.Merge(account.Filters)
+
+	for _, presetName := range account.Presets {
+		preset, err := c.preset(presetName)
+		if err != nil {
+			return nil, err
 		}
 
 		filters.Merge(preset.Filters)
@@ -157,6 +427,97 @@ func (c *Nuke) Filters(accountID string) (Filters, error) {
 	return filters, nil
 }
 
+// validateFilters fails for filters, which cannot be evaluated as intended,
+// eg a glob filter with a file, which would silently match nothing.
+func (c *Nuke) validateFilters() error {
+	validate := func(path string, filters Filters) error {
+		for _, resourceType := range sortedFilterTypes(filters) {
+			for i, filter := range filters[resourceType] {
+				err := filter.Validate()
+				if err != nil {
+					return fmt.Errorf("The filter %s.%s[%d] is invalid: %v.", path, resourceType, i, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, accountID := range sortedAccountIDs(c.Accounts) {
+		if err := validate("accounts."+accountID+".filters", c.Accounts[accountID].Filters); err != nil {
+			return err
+		}
+	}
+
+	presetNames := []string{}
+	for name := range c.Presets {
+		presetNames = append(presetNames, name)
+	}
+	sort.Strings(presetNames)
+
+	for _, name := range presetNames {
+		if err := validate("presets."+name+".filters", c.Presets[name].Filters); err != nil {
+			return err
+		}
+	}
+
+	if c.Defaults != nil {
+		if err := validate("defaults.filters", c.Defaults.Filters); err != nil {
+			return err
+		}
+	}
+
+	if defaults := c.OrgDiscovery.AccountDefaults; defaults != nil {
+		if err := validate("org-discovery.account-defaults.filters", defaults.Filters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFilterFiles reads the values of all filters with a file.
+func (c *Nuke) loadFilterFiles(baseDir string) error {
+	load := func(filters Filters) error {
+		for resourceType, list := range filters {
+			for i := range list {
+				err := list[i].loadValues(baseDir)
+				if err != nil {
+					return fmt.Errorf("Failed to read the values of a %s filter: %v", resourceType, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, account := range c.Accounts {
+		if err := load(account.Filters); err != nil {
+			return err
+		}
+	}
+
+	for _, preset := range c.Presets {
+		if err := load(preset.Filters); err != nil {
+			return err
+		}
+	}
+
+	if c.Defaults != nil {
+		if err := load(c.Defaults.Filters); err != nil {
+			return err
+		}
+	}
+
+	if defaults := c.OrgDiscovery.AccountDefaults; defaults != nil {
+		if err := load(defaults.Filters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveDeprecations renames deprecated resource types in all filters of the
+// config.
 func (c *Nuke) resolveDeprecations() error {
 	deprecations := map[string]string{
 		"EC2DhcpOptions":                "EC2DHCPOptions",
@@ -183,22 +544,48 @@ func (c *Nuke) resolveDeprecations() error {
 		"RDSCluster":                    "RDSDBCluster",
 	}
 
-	for _, a := range c.Accounts {
-		for resourceType, resources := range a.Filters {
+	resolve := func(filters Filters) error {
+		for resourceType, resources := range filters {
 			replacement, ok := deprecations[resourceType]
 			if !ok {
 				continue
 			}
 			log.Warnf("deprecated resource type '%s' - converting to '%s'\n", resourceType, replacement)
 
-			if _, ok := a.Filters[replacement]; ok {
+			if _, ok := filters[replacement]; ok {
 				return fmt.Errorf("using deprecated resource type and replacement: '%s','%s'", resourceType, replacement)
 			}
 
-			a.Filters[replacement] = resources
-			delete(a.Filters, resourceType)
+			filters[replacement] = resources
+			delete(filters, resourceType)
 		}
+		return nil
 	}
+
+	for _, a := range c.Accounts {
+		if err := resolve(a.Filters); err != nil {
+			return err
+		}
+	}
+
+	for _, preset := range c.Presets {
+		if err := resolve(preset.Filters); err != nil {
+			return err
+		}
+	}
+
+	if c.Defaults != nil {
+		if err := resolve(c.Defaults.Filters); err != nil {
+			return err
+		}
+	}
+
+	if defaults := c.OrgDiscovery.AccountDefaults; defaults != nil {
+		if err := resolve(defaults.Filters); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -56,6 +56,7 @@ func TestLoadExampleConfig(t *testing.T) {
 		Regions:          []string{"eu-west-1", "stratoscale"},
 		Accounts: map[string]Account{
 			"555133742": Account{
+				Regions: []string{"us-east-1"},
 				Presets: []string{"terraform"},
 				Filters: Filters{
 					"IAMRole": {
@@ -66,8 +67,7 @@ func TestLoadExampleConfig(t *testing.T) {
 					},
 				},
 				ResourceTypes: ResourceTypes{
-					types.Collection{"S3Bucket"},
-					nil,
+					Targets: types.Collection{"S3Bucket"},
 				},
 			},
 		},
@@ -113,6 +113,65 @@ func TestLoadExampleConfig(t *testing.T) {
 	}
 }
 
+func TestAccountRegions(t *testing.T) {
+	config := Nuke{
+		Regions: []string{"eu-west-1", "eu-central-1"},
+		Accounts: map[string]Account{
+			"555133742": Account{
+				Regions: []string{"us-east-1"},
+			},
+			"555421337": Account{},
+		},
+	}
+
+	cases := map[string][]string{
+		"555133742": []string{"us-east-1"},
+		"555421337": []string{"eu-west-1", "eu-central-1"},
+		"unknown":   []string{"eu-west-1", "eu-central-1"},
+	}
+
+	for accountID, expect := range cases {
+		got := config.AccountRegions(accountID)
+		if !reflect.DeepEqual(got, expect) {
+			t.Errorf("Wrong regions for account %s. Got %v, expected %v.", accountID, got, expect)
+		}
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	config := Nuke{
+		RunProfiles: map[string]RunProfile{
+			"nightly-cheap": RunProfile{
+				ResourceTypes:   ResourceTypes{Targets: types.Collection{"EC2Instance"}},
+				ParallelQueries: 4,
+			},
+			"broken": RunProfile{
+				ParallelQueries: -1,
+			},
+		},
+	}
+
+	profile, err := config.RunProfile("")
+	if err != nil || !reflect.DeepEqual(profile, RunProfile{}) {
+		t.Errorf("Expected empty profile for empty name. Got %#v, %v.", profile, err)
+	}
+
+	profile, err = config.RunProfile("nightly-cheap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(profile, config.RunProfiles["nightly-cheap"]) {
+		t.Errorf("Wrong profile. Got %#v.", profile)
+	}
+
+	for _, name := range []string{"missing", "broken"} {
+		_, err = config.RunProfile(name)
+		if err == nil {
+			t.Errorf("Expected error for run profile '%s'.", name)
+		}
+	}
+}
+
 func TestResolveDeprecations(t *testing.T) {
 	config := Nuke{
 		AccountBlacklist: []string{"1234567890"},
@@ -268,6 +327,15 @@ func TestFilterMerge(t *testing.T) {
 		t.Errorf("  Got:      %#v", filters)
 		t.Errorf("  Expected: %#v", expect)
 	}
+
+	// Merging the presets must not change the filters of the account.
+	filters, err = config.Filters("555133742")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(filters, expect) {
+		t.Errorf("Filters changed by a repeated merge: %#v", filters)
+	}
 }
 
 func TestGetCustomRegion(t *testing.T) {
@@ -296,3 +364,56 @@ func TestGetCustomRegion(t *testing.T) {
 
 	})
 }
+
+func TestLoadFilterFiles(t *testing.T) {
+	config, err := Load("test-fixtures/filter-files.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters := config.Accounts["555133742"].Filters
+	for _, resourceType := range []string{"EC2Instance", "IAMRole"} {
+		filter := filters[resourceType][0]
+
+		for _, value := range []string{"i-01b489457a60298dd", "i-0b0f4b4e8a0a7f3c1"} {
+			match, err := filter.Match(value)
+			if err != nil || !match {
+				t.Errorf("%s filter does not match %s: %v", resourceType, value, err)
+			}
+		}
+
+		for _, value := range []string{"", "i-0123", "# instances of the shared environment"} {
+			match, _ := filter.Match(value)
+			if match {
+				t.Errorf("%s filter matches %#v", resourceType, value)
+			}
+		}
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	cases := []struct {
+		filter Filter
+		valid  bool
+	}{
+		{Filter{Type: FilterTypeGlob, Value: "prod-*"}, true},
+		{Filter{Type: FilterTypeExact, File: "ids.txt"}, true},
+		{Filter{Type: FilterTypeGlob, File: "ids.txt"}, false},
+		{Filter{Type: FilterTypeExact, Value: "i-0123", File: "ids.txt"}, false},
+		{Filter{Type: FilterTypeRegex, Value: "(prod"}, false},
+	}
+
+	for i, tc := range cases {
+		filters := Filters{"EC2Instance": []Filter{tc.filter}}
+		for _, c := range []*Nuke{
+			{Accounts: map[string]Account{"555133742": {Filters: filters}}},
+			{Presets: map[string]PresetDefinitions{"shared": {Filters: filters}}},
+			{Defaults: &Account{Filters: filters}},
+		} {
+			err := c.validateFilters()
+			if (err == nil) != tc.valid {
+				t.Errorf("Case %d: wrong validation result for %+v: %v", i, tc.filter, err)
+			}
+		}
+	}
+}
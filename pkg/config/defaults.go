@@ -0,0 +1,46 @@
+package config
+
+import "github.com/rebuy-de/aws-nuke/pkg/types"
+
+// applyDefaults extends every account of the accounts section by the defaults
+// section of the config.
+func (c *Nuke) applyDefaults() {
+	for id, account := range c.Accounts {
+		c.Accounts[id] = c.withDefaults(account)
+	}
+}
+
+// withDefaults returns the account extended by the defaults section. The
+// filters, presets, excludes, list-only and cloud-control types of both are
+// combined, while the regions and targets of the account replace the ones of
+// the defaults. An account with inherit-defaults set to false is returned
+// unchanged.
+func (c *Nuke) withDefaults(account Account) Account {
+	d := c.Defaults
+	if d == nil || (account.InheritDefaults != nil && !*account.InheritDefaults) {
+		return account
+	}
+
+	if len(account.Regions) == 0 {
+		account.Regions = d.Regions
+	}
+
+	filters := Filters{}
+	filters.Merge(d.Filters)
+	filters.Merge(account.Filters)
+	account.Filters = filters
+
+	// The unions start with an empty collection, so they do not share the
+	// backing arrays of the defaults.
+	account.Presets = types.Collection{}.Union(d.Presets).Union(account.Presets)
+
+	rt := &account.ResourceTypes
+	if len(rt.Targets) == 0 {
+		rt.Targets = d.ResourceTypes.Targets
+	}
+	rt.Excludes = types.Collection{}.Union(d.ResourceTypes.Excludes).Union(rt.Excludes)
+	rt.ListOnly = types.Collection{}.Union(d.ResourceTypes.ListOnly).Union(rt.ListOnly)
+	rt.CloudControl = types.Collection{}.Union(d.ResourceTypes.CloudControl).Union(rt.CloudControl)
+
+	return account
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+func TestDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := os.WriteFile(path, []byte(`
+regions: [ global ]
+account-blacklist: [ "999999999999" ]
+presets:
+  sso:
+    filters:
+      IAMRole:
+      - type: glob
+        value: AWSReservedSSO_*
+defaults:
+  regions: [ eu-west-1 ]
+  presets: [ sso ]
+  filters:
+    IAMRole:
+    - OrganizationAccountAccessRole
+  resource-types:
+    excludes: [ S3Object ]
+org-discovery:
+  ou-paths: [ Root/Sandbox ]
+  account-defaults: {}
+accounts:
+  "111111111111": {}
+  "222222222222":
+    regions: [ us-east-1 ]
+    filters:
+      IAMRole:
+      - ci
+    resource-types:
+      excludes: [ EC2Image ]
+  "333333333333":
+    inherit-defaults: false
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filterValues := func(accountID string) []string {
+		filters, err := c.Filters(accountID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values := []string{}
+		for _, f := range filters["IAMRole"] {
+			values = append(values, f.Value)
+		}
+		return values
+	}
+
+	cases := []struct {
+		accountID string
+		regions   []string
+		filters   []string
+		excludes  types.Collection
+	}{
+		{"111111111111", []string{"eu-west-1"},
+			[]string{"OrganizationAccountAccessRole", "AWSReservedSSO_*"}, types.Collection{"S3Object"}},
+		{"222222222222", []string{"us-east-1"},
+			[]string{"OrganizationAccountAccessRole", "ci", "AWSReservedSSO_*"}, types.Collection{"S3Object", "EC2Image"}},
+		{"333333333333", []string{"global"}, []string{}, nil},
+		{"444444444444", []string{"eu-west-1"},
+			[]string{"OrganizationAccountAccessRole", "AWSReservedSSO_*"}, types.Collection{"S3Object"}},
+	}
+
+	if !c.UseAccountDefaults("444444444444") {
+		t.Fatal("Expected the account-defaults to be used.")
+	}
+
+	for _, tc := range cases {
+		if have := c.AccountRegions(tc.accountID); !reflect.DeepEqual(tc.regions, have) {
+			t.Errorf("Wrong regions of %s. Want: %v. Have: %v", tc.accountID, tc.regions, have)
+		}
+		if have := filterValues(tc.accountID); !reflect.DeepEqual(tc.filters, have) {
+			t.Errorf("Wrong filters of %s. Want: %v. Have: %v", tc.accountID, tc.filters, have)
+		}
+		if have := c.Accounts[tc.accountID].ResourceTypes.Excludes; !reflect.DeepEqual(tc.excludes, have) {
+			t.Errorf("Wrong excludes of %s. Want: %v. Have: %v", tc.accountID, tc.excludes, have)
+		}
+	}
+
+	if len(c.Defaults.Filters["IAMRole"]) != 1 || len(c.Defaults.ResourceTypes.Excludes) != 1 {
+		t.Errorf("The defaults were changed by the accounts: %+v", c.Defaults)
+	}
+}
+
+func TestDefaultsDeprecatedType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+regions: [ eu-west-1 ]
+account-blacklist: [ "999999999999" ]
+defaults:
+  filters:
+    IamRole:
+    - OrganizationAccountAccessRole
+accounts:
+  "111111111111":
+    filters:
+      IAMRole:
+      - ci
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters, err := c.Filters("111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{}
+	for _, f := range filters["IAMRole"] {
+		values = append(values, f.Value)
+	}
+	want := []string{"OrganizationAccountAccessRole", "ci"}
+	if !reflect.DeepEqual(want, values) {
+		t.Errorf("Wrong IAMRole filters. Want: %v. Have: %v", want, values)
+	}
+	if _, ok := filters["IamRole"]; ok {
+		t.Errorf("The deprecated resource type was not resolved.")
+	}
+}
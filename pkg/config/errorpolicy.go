@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mb0/glob"
+)
+
+// Actions of error policies.
+const (
+	// ErrorActionSkip shows the resource as filtered, instead of retrying it.
+	ErrorActionSkip = "skip"
+
+	// ErrorActionRetry keeps the default behavior, which retries failed
+	// removals in the next iteration. It can be used to exclude errors from a
+	// broader policy, since the first matching policy wins.
+	ErrorActionRetry = "retry"
+
+	// ErrorActionFail aborts the run.
+	ErrorActionFail = "fail"
+)
+
+// ErrorPolicy decides how failed requests of certain resource types are
+// handled, eg to skip resources which are intentionally not accessible.
+type ErrorPolicy struct {
+	// ResourceTypes are glob patterns of resource types (eg Organizations*).
+	// An empty list matches all resource types.
+	ResourceTypes []string `yaml:"resource-types,omitempty"`
+
+	// ErrorCodes are glob patterns of AWS error codes (eg
+	// AccessDeniedException).
+	ErrorCodes []string `yaml:"error-codes,omitempty"`
+
+	Action string `yaml:"action,omitempty"`
+}
+
+func (p ErrorPolicy) matches(resourceType, code string) bool {
+	if len(p.ResourceTypes) > 0 && !matchesAnyGlob(p.ResourceTypes, resourceType) {
+		return false
+	}
+	return matchesAnyGlob(p.ErrorCodes, code)
+}
+
+func matchesAnyGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		match, err := glob.Match(pattern, value)
+		if err == nil && match {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorAction returns the action of the first error policy, which matches the
+// resource type and the AWS error code. It returns an empty string, if no
+// policy matches.
+func (c *Nuke) ErrorAction(resourceType, code string) string {
+	if code == "" {
+		return ""
+	}
+
+	for _, policy := range c.ErrorPolicies {
+		if policy.matches(resourceType, code) {
+			return policy.Action
+		}
+	}
+
+	return ""
+}
+
+func (c *Nuke) validateErrorPolicies() error {
+	for i, policy := range c.ErrorPolicies {
+		switch policy.Action {
+		case ErrorActionSkip, ErrorActionRetry, ErrorActionFail:
+		default:
+			return fmt.Errorf("The error policy %d has the invalid action '%s'. "+
+				"Supported actions are: %s, %s, %s.",
+				i, policy.Action, ErrorActionSkip, ErrorActionRetry, ErrorActionFail)
+		}
+
+		if len(policy.ErrorCodes) == 0 {
+			return fmt.Errorf("The error policy %d does not specify any error-codes.", i)
+		}
+	}
+
+	return nil
+}
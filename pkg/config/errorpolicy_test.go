@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestErrorAction(t *testing.T) {
+	config := Nuke{
+		ErrorPolicies: []ErrorPolicy{
+			{
+				ResourceTypes: []string{"OrganizationsAccount"},
+				ErrorCodes:    []string{"AccessDeniedException"},
+				Action:        ErrorActionRetry,
+			},
+			{
+				ResourceTypes: []string{"Organizations*"},
+				ErrorCodes:    []string{"AccessDenied*"},
+				Action:        ErrorActionSkip,
+			},
+			{
+				ErrorCodes: []string{"InvalidClientTokenId"},
+				Action:     ErrorActionFail,
+			},
+		},
+	}
+
+	cases := []struct {
+		resourceType string
+		code         string
+		want         string
+	}{
+		{"OrganizationsAccount", "AccessDeniedException", ErrorActionRetry},
+		{"OrganizationsPolicy", "AccessDeniedException", ErrorActionSkip},
+		{"OrganizationsPolicy", "ThrottlingException", ""},
+		{"S3Bucket", "AccessDeniedException", ""},
+		{"S3Bucket", "InvalidClientTokenId", ErrorActionFail},
+		{"S3Bucket", "", ""},
+	}
+
+	for _, tc := range cases {
+		have := config.ErrorAction(tc.resourceType, tc.code)
+		if have != tc.want {
+			t.Errorf("Wrong action for %s and %s. Want: %#v. Have: %#v",
+				tc.resourceType, tc.code, tc.want, have)
+		}
+	}
+}
+
+func TestValidateErrorPolicies(t *testing.T) {
+	cases := []struct {
+		policy ErrorPolicy
+		valid  bool
+	}{
+		{ErrorPolicy{ErrorCodes: []string{"AccessDenied"}, Action: ErrorActionSkip}, true},
+		{ErrorPolicy{ErrorCodes: []string{"AccessDenied"}, Action: "ignore"}, false},
+		{ErrorPolicy{Action: ErrorActionSkip}, false},
+	}
+
+	for _, tc := range cases {
+		config := Nuke{ErrorPolicies: []ErrorPolicy{tc.policy}}
+		err := config.validateErrorPolicies()
+		if (err == nil) != tc.valid {
+			t.Errorf("Wrong validation result for %#v: %v", tc.policy, err)
+		}
+	}
+}
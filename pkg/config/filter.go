@@ -2,12 +2,13 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/mb0/glob"
 )
 
 type FilterType string
@@ -16,9 +17,11 @@ const (
 	FilterTypeEmpty         FilterType = ""
 	FilterTypeExact                    = "exact"
 	FilterTypeGlob                     = "glob"
+	FilterTypeExtendedGlob             = "extendedGlob"
 	FilterTypeRegex                    = "regex"
 	FilterTypeContains                 = "contains"
 	FilterTypeDateOlderThan            = "dateOlderThan"
+	FilterTypeIn                       = "in"
 )
 
 type Filters map[string][]Filter
@@ -34,6 +37,58 @@ type Filter struct {
 	Type     FilterType
 	Value    string
 	Invert   string
+
+	// CaseInsensitive ignores the case of glob and extendedGlob filters, if
+	// it is "true".
+	CaseInsensitive string
+
+	// File is the path of a file with one value per line, which is used by
+	// exact and in filters instead of the value. Relative paths are resolved
+	// against the directory of the config file.
+	File string
+
+	values map[string]bool
+}
+
+// compiledFilters caches the parsed values of the regex, glob and
+// dateOlderThan filters, since a filter is matched against every resource of
+// its type. Glob patterns are translated into regular expressions. It is
+// keyed by filterKey.
+var compiledFilters sync.Map
+
+type filterKey struct {
+	Type            FilterType
+	Value           string
+	CaseInsensitive bool
+}
+
+type compiledFilter struct {
+	regex    *regexp.Regexp
+	duration time.Duration
+	err      error
+}
+
+// compiled returns the parsed value of the filter. Invalid values are cached
+// as well, so they fail every match like before.
+func (f Filter) compiled() *compiledFilter {
+	key := filterKey{Type: f.Type, Value: f.Value, CaseInsensitive: isTrue(f.CaseInsensitive)}
+	cached, ok := compiledFilters.Load(key)
+	if ok {
+		return cached.(*compiledFilter)
+	}
+
+	c := new(compiledFilter)
+	switch f.Type {
+	case FilterTypeRegex:
+		c.regex, c.err = regexp.Compile(f.Value)
+	case FilterTypeGlob, FilterTypeExtendedGlob:
+		c.regex, c.err = compileGlob(f.Value, f.Type == FilterTypeExtendedGlob, key.CaseInsensitive)
+	case FilterTypeDateOlderThan:
+		c.duration, c.err = time.ParseDuration(f.Value)
+	}
+
+	cached, _ = compiledFilters.LoadOrStore(key, c)
+	return cached.(*compiledFilter)
 }
 
 func (f Filter) Match(o string) (bool, error) {
@@ -42,34 +97,37 @@ func (f Filter) Match(o string) (bool, error) {
 		fallthrough
 
 	case FilterTypeExact:
+		if f.File != "" {
+			return f.values[o], nil
+		}
 		return f.Value == o, nil
 
+	case FilterTypeIn:
+		return f.values[o], nil
+
 	case FilterTypeContains:
 		return strings.Contains(o, f.Value), nil
 
-	case FilterTypeGlob:
-		return glob.Match(f.Value, o)
-
-	case FilterTypeRegex:
-		re, err := regexp.Compile(f.Value)
-		if err != nil {
-			return false, err
+	case FilterTypeGlob, FilterTypeExtendedGlob, FilterTypeRegex:
+		c := f.compiled()
+		if c.err != nil {
+			return false, c.err
 		}
-		return re.MatchString(o), nil
+		return c.regex.MatchString(o), nil
 
 	case FilterTypeDateOlderThan:
 		if o == "" {
 			return false, nil
 		}
-		duration, err := time.ParseDuration(f.Value)
-		if err != nil {
-			return false, err
+		c := f.compiled()
+		if c.err != nil {
+			return false, c.err
 		}
 		fieldTime, err := parseDate(o)
 		if err != nil {
 			return false, err
 		}
-		fieldTimeWithOffset := fieldTime.Add(duration)
+		fieldTimeWithOffset := fieldTime.Add(c.duration)
 
 		return fieldTimeWithOffset.After(time.Now()), nil
 
@@ -118,6 +176,61 @@ func (f *Filter) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	f.Value = m["value"]
 	f.Property = m["property"]
 	f.Invert = m["invert"]
+	f.CaseInsensitive = m["case-insensitive"]
+	f.File = m["file"]
+	return nil
+}
+
+// MarshalYAML writes the filter in the shortest form, which UnmarshalYAML
+// accepts. Exact filters of the name are plain strings.
+func (f Filter) MarshalYAML() (interface{}, error) {
+	if f.Type == FilterTypeExact && f.Property == "" && f.Invert == "" &&
+		f.CaseInsensitive == "" && f.File == "" {
+		return f.Value, nil
+	}
+
+	m := map[string]string{}
+	for key, value := range map[string]string{
+		"type":             string(f.Type),
+		"value":            f.Value,
+		"property":         f.Property,
+		"invert":           f.Invert,
+		"case-insensitive": f.CaseInsensitive,
+		"file":             f.File,
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	return m, nil
+}
+
+// loadValues reads the values of the filter file. Empty lines and lines
+// starting with # are ignored.
+func (f *Filter) loadValues(baseDir string) error {
+	if f.File == "" {
+		return nil
+	}
+
+	path := f.File
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	f.values = map[string]bool{}
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f.values[line] = true
+	}
+
 	return nil
 }
 
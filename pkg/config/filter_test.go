@@ -36,6 +36,31 @@ func TestUnmarshalFilter(t *testing.T) {
 			match:    []string{"bish", "bash", "bosh", "bush"},
 			mismatch: []string{"woooosh", "fooo", "o", "fo", "boooooosh", "bsh"},
 		},
+		{
+			yaml:     `{"type":"glob","value":"{dev,test}-*"}`,
+			match:    []string{"{dev,test}-foo"},
+			mismatch: []string{"dev-foo", "test-bar"},
+		},
+		{
+			yaml:     `{"type":"extendedGlob","value":"{dev,test}-*"}`,
+			match:    []string{"dev-foo", "test-bar", "dev-"},
+			mismatch: []string{"prod-foo", "dev", "dev-foo/bar", "Dev-foo"},
+		},
+		{
+			yaml:     `{"type":"extendedGlob","value":"{dev,test-{a,b}}/**"}`,
+			match:    []string{"dev/foo", "test-a/foo/bar", "test-b/"},
+			mismatch: []string{"test/foo", "test-c/foo", "prod/foo"},
+		},
+		{
+			yaml:     `{"type":"glob","value":"role/**/admin"}`,
+			match:    []string{"role/a/admin", "role/a/b/admin", "role//admin"},
+			mismatch: []string{"role/a/user", "role/admin", "admin"},
+		},
+		{
+			yaml:     `{"type":"glob","value":"Dev-*","case-insensitive":"true"}`,
+			match:    []string{"dev-foo", "DEV-Foo", "Dev-foo"},
+			mismatch: []string{"test-foo"},
+		},
 		{
 			yaml:     `{"type":"regex","value":"b[iao]sh"}`,
 			match:    []string{"bish", "bash", "bosh"},
@@ -100,3 +125,29 @@ func TestUnmarshalFilter(t *testing.T) {
 	}
 
 }
+
+func BenchmarkFilterMatch(b *testing.B) {
+	filters := []config.Filter{
+		{Type: config.FilterTypeExact, Value: "OrganizationAccountAccessRole"},
+		{Type: config.FilterTypeGlob, Value: "*-role", CaseInsensitive: "true"},
+		{Type: config.FilterTypeExtendedGlob, Value: "{dev,test}-**-role", CaseInsensitive: "true"},
+		{Type: config.FilterTypeRegex, Value: `^AWSReservedSSO_[A-Za-z]+_[0-9a-f]{16}$`},
+		{Type: config.FilterTypeDateOlderThan, Value: "24h"},
+	}
+	values := []string{
+		"OrganizationAccountAccessRole",
+		"Test-eu-west-1/app-role",
+		"AWSReservedSSO_AdministratorAccess_0123456789abcdef",
+		"2024-01-02T15:04:05Z",
+	}
+
+	for _, filter := range filters {
+		b.Run(string(filter.Type), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, value := range values {
+					filter.Match(value)
+				}
+			}
+		})
+	}
+}
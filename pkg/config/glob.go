@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mb0/glob"
+)
+
+// compileGlob translates a glob pattern into a regular expression, so it is
+// parsed only once instead of for every matched value. It has the syntax of
+// github.com/mb0/glob. A single * does not match the separator /, while **
+// does. With braces, brace sets like {dev,test}-* are expanded as well.
+// Otherwise braces match literally.
+func compileGlob(pattern string, braces, caseInsensitive bool) (*regexp.Regexp, error) {
+	patterns := []string{pattern}
+	if braces {
+		patterns = expandBraces(pattern)
+	}
+
+	alternatives := []string{}
+	for _, alternative := range patterns {
+		expr, err := globRegexp(alternative)
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, expr)
+	}
+
+	flags := "(?s)"
+	if caseInsensitive {
+		flags = "(?is)"
+	}
+
+	return regexp.Compile(flags + "^(?:" + strings.Join(alternatives, "|") + ")$")
+}
+
+// globRegexp translates a glob pattern without brace sets into a regular
+// expression.
+func globRegexp(pattern string) (string, error) {
+	expr := new(strings.Builder)
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			stars := len(pattern) - len(strings.TrimLeft(pattern, "*"))
+			pattern = pattern[stars:]
+			if stars > 1 {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("[^/]*")
+			}
+			continue
+
+		case '?':
+			expr.WriteString("[^/]")
+			pattern = pattern[1:]
+			continue
+
+		case '[':
+			class, rest, err := globRange(pattern[1:])
+			if err != nil {
+				return "", err
+			}
+			expr.WriteString(class)
+			pattern = rest
+			continue
+
+		case '\\':
+			pattern = pattern[1:]
+			if pattern == "" {
+				return "", glob.ErrBadPattern
+			}
+		}
+
+		r, n := utf8.DecodeRuneInString(pattern)
+		expr.WriteString(regexp.QuoteMeta(string(r)))
+		pattern = pattern[n:]
+	}
+
+	return expr.String(), nil
+}
+
+// globRange translates the character range at the start of the pattern,
+// whose [ was already consumed, into a character class. It returns the rest
+// of the pattern after the closing ].
+func globRange(pattern string) (string, string, error) {
+	class := new(strings.Builder)
+	class.WriteString("[")
+	if strings.HasPrefix(pattern, "^") {
+		class.WriteString("^")
+		pattern = pattern[1:]
+	}
+
+	for n := 0; ; n++ {
+		if strings.HasPrefix(pattern, "]") && n > 0 {
+			class.WriteString("]")
+			return class.String(), pattern[1:], nil
+		}
+
+		lo, rest, err := globRangeChar(pattern)
+		if err != nil {
+			return "", "", err
+		}
+		pattern = rest
+		fmt.Fprintf(class, `\x{%x}`, lo)
+
+		if strings.HasPrefix(pattern, "-") {
+			hi, rest, err := globRangeChar(pattern[1:])
+			if err != nil {
+				return "", "", err
+			}
+			pattern = rest
+			fmt.Fprintf(class, `-\x{%x}`, hi)
+		}
+	}
+}
+
+// globRangeChar returns the possibly escaped character at the start of a
+// character range.
+func globRangeChar(pattern string) (rune, string, error) {
+	if pattern == "" || pattern[0] == '-' || pattern[0] == ']' {
+		return 0, "", glob.ErrBadPattern
+	}
+
+	if pattern[0] == '\\' {
+		pattern = pattern[1:]
+		if pattern == "" {
+			return 0, "", glob.ErrBadPattern
+		}
+	}
+
+	r, n := utf8.DecodeRuneInString(pattern)
+	return r, pattern[n:], nil
+}
+
+// expandBraces returns all patterns described by the brace sets of the
+// pattern, eg {dev,test}-* results in dev-* and test-*. Brace sets can be
+// nested. Unbalanced braces are kept as they are.
+func expandBraces(pattern string) []string {
+	start := strings.Index(pattern, "{")
+	if start < 0 {
+		return []string{pattern}
+	}
+
+	depth := 0
+	options := []string{}
+	last := start + 1
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				options = append(options, pattern[last:i])
+				last = i + 1
+			}
+		case '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			options = append(options, pattern[last:i])
+			prefix, suffix := pattern[:start], pattern[i+1:]
+
+			result := []string{}
+			for _, option := range options {
+				result = append(result, expandBraces(prefix+option+suffix)...)
+			}
+			return result
+		}
+	}
+
+	return []string{pattern}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/mb0/glob"
+)
+
+func TestCompileGlob(t *testing.T) {
+	// Without brace sets, the compiled patterns match like
+	// github.com/mb0/glob.
+	patterns := []string{"{dev,test}-*", "b*sh", "b?sh", "role/**/admin", "role/*", "**", "[a-c]*", "[^a]?", `\*x`, "a[\\]]b", "ü*"}
+	values := []string{"", "{dev,test}-a", "dev-a", "bash", "bsh", "b/sh", "role/a/admin", "role//admin", "role/a/b", "a-b", "cat", "*x", "a]b", "über", "x/y"}
+
+	for _, pattern := range patterns {
+		re, err := compileGlob(pattern, false, false)
+		if err != nil {
+			t.Fatalf("Failed to compile %s: %v", pattern, err)
+		}
+
+		for _, value := range values {
+			want, err := glob.Match(pattern, value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := re.MatchString(value); have != want {
+				t.Errorf("Wrong match of %q against %q. Want: %t. Have: %t", value, pattern, want, have)
+			}
+		}
+	}
+
+	for _, pattern := range []string{"[abc", `abc\`, "[]", "[a-]"} {
+		_, err := compileGlob(pattern, false, false)
+		if err == nil {
+			t.Errorf("Expected an error for the invalid pattern %q.", pattern)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+	LintSeverityInfo    LintSeverity = "info"
+)
+
+// Codes of the lint rules. They are stable, so they can be used to suppress
+// or track specific findings in automation.
+const (
+	LintEmptyBlacklist      = "NUKE001"
+	LintUnknownResourceType = "NUKE002"
+	LintUnknownProperty     = "NUKE003"
+	LintUnusedPreset        = "NUKE004"
+	LintUndefinedPreset     = "NUKE005"
+	LintAccountNoFilters    = "NUKE006"
+	LintMatchesEverything   = "NUKE007"
+	LintInvalidFilter       = "NUKE008"
+	LintNoRegions           = "NUKE009"
+)
+
+// LintIssue is a single finding of Lint.
+type LintIssue struct {
+	Code     string       `json:"code"`
+	Severity LintSeverity `json:"severity"`
+	Path     string       `json:"path"`
+	Message  string       `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%-7s %s %s: %s", i.Severity, i.Code, i.Path, i.Message)
+}
+
+// LintOptions provides the knowledge about resources, which the config
+// package does not have on its own.
+type LintOptions struct {
+	// ResourceTypes are the names of all supported resource types.
+	ResourceTypes []string
+
+	// Properties returns the known filter properties of a resource type. The
+	// second return value is false, if the properties of the type are not
+	// known. The property check is skipped, if this is nil.
+	Properties func(resourceType string) ([]string, bool)
+}
+
+// lintSamples are used to detect filters that match every value.
+var lintSamples = []string{"", "aws-nuke", "arn:aws:iam::012345678901:role/Example", "2006-01-02T15:04:05Z"}
+
+// Lint checks the config for common mistakes and violations of best practices.
+func (c *Nuke) Lint(opts LintOptions) []LintIssue {
+	issues := []LintIssue{}
+	add := func(code string, severity LintSeverity, path, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{
+			Code:     code,
+			Severity: severity,
+			Path:     path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if !c.HasBlacklist() {
+		add(LintEmptyBlacklist, LintSeverityError, "account-blacklist",
+			"the blacklist is empty, aws-nuke will refuse to run")
+	}
+
+	knownTypes := map[string]bool{}
+	for _, t := range opts.ResourceTypes {
+		knownTypes[t] = true
+	}
+	if len(knownTypes) > 0 {
+		sources := []ResourceTypes{c.ResourceTypes}
+		for _, account := range c.Accounts {
+			sources = append(sources, account.ResourceTypes)
+		}
+		for _, profile := range c.RunProfiles {
+			sources = append(sources, profile.ResourceTypes)
+		}
+
+		for _, resourceTypes := range sources {
+			for _, t := range resourceTypes.CloudControl {
+				knownTypes[t] = true
+			}
+		}
+	}
+
+	lintResourceTypes := func(path string, resourceTypes ResourceTypes) {
+		if len(knownTypes) == 0 {
+			return
+		}
+
+		lists := []struct {
+			name  string
+			types []string
+		}{
+			{"targets", resourceTypes.Targets},
+			{"excludes", resourceTypes.Excludes},
+			{"list-only", resourceTypes.ListOnly},
+		}
+		for _, list := range lists {
+			for i, resourceType := range list.types {
+				if !knownTypes[resourceType] {
+					add(LintUnknownResourceType, LintSeverityWarning,
+						fmt.Sprintf("%s.%s[%d]", path, list.name, i),
+						"resource type '%s' does not exist", resourceType)
+				}
+			}
+		}
+	}
+
+	lintResourceTypes("resource-types", c.ResourceTypes)
+
+	if len(knownTypes) > 0 {
+		capped := []string{}
+		for resourceType := range c.MaxDeletions {
+			capped = append(capped, resourceType)
+		}
+		sort.Strings(capped)
+
+		for _, resourceType := range capped {
+			if !knownTypes[resourceType] {
+				add(LintUnknownResourceType, LintSeverityWarning, "max-deletions."+resourceType,
+					"resource type '%s' does not exist", resourceType)
+			}
+		}
+	}
+
+	// lintFilters checks the filters at the path. The filters of accounts
+	// start with the inherited filters of the defaults section, which are
+	// skipped, since they are checked with the defaults.
+	lintFilters := func(path string, filters, inherited Filters) {
+		for _, resourceType := range sortedFilterTypes(filters) {
+			skip := len(inherited[resourceType])
+			if len(filters[resourceType]) <= skip {
+				continue
+			}
+
+			typePath := fmt.Sprintf("%s.%s", path, resourceType)
+			if len(knownTypes) > 0 && !knownTypes[resourceType] {
+				add(LintUnknownResourceType, LintSeverityWarning, typePath,
+					"resource type '%s' does not exist", resourceType)
+				continue
+			}
+
+			var properties map[string]bool
+			if opts.Properties != nil {
+				props, ok := opts.Properties(resourceType)
+				if ok {
+					properties = map[string]bool{}
+					for _, p := range props {
+						properties[p] = true
+					}
+				}
+			}
+
+			for i, filter := range filters[resourceType][skip:] {
+				filterPath := fmt.Sprintf("%s[%d]", typePath, i)
+
+				err := filter.Validate()
+				if err != nil {
+					add(LintInvalidFilter, LintSeverityError, filterPath, "%v", err)
+					continue
+				}
+
+				if properties != nil && filter.Property != "" && !properties[propertyRoot(filter.Property)] &&
+					!isDynamicProperty(filter.Property) {
+					add(LintUnknownProperty, LintSeverityWarning, filterPath,
+						"resource type '%s' does not have the property '%s'", resourceType, filter.Property)
+				}
+
+				if filter.matchesEverything() {
+					if isTrue(filter.Invert) {
+						add(LintMatchesEverything, LintSeverityWarning, filterPath,
+							"inverted filter never matches, it has no effect")
+					} else {
+						add(LintMatchesEverything, LintSeverityWarning, filterPath,
+							"filter matches every value, all resources of type '%s' are filtered", resourceType)
+					}
+				}
+			}
+		}
+	}
+
+	usedPresets := map[string]bool{}
+	for _, accountID := range sortedAccountIDs(c.Accounts) {
+		account := c.Accounts[accountID]
+		path := fmt.Sprintf("accounts.%s", accountID)
+
+		for i, preset := range account.Presets {
+			usedPresets[preset] = true
+			if _, err := c.preset(preset); err != nil {
+				add(LintUndefinedPreset, LintSeverityError, fmt.Sprintf("%s.presets[%d]", path, i),
+					"preset '%s' is not defined", preset)
+			}
+		}
+
+		if len(c.AccountRegions(accountID)) == 0 {
+			add(LintNoRegions, LintSeverityWarning, path,
+				"no regions are specified, nothing will be scanned")
+		}
+
+		if len(account.Filters) == 0 && len(account.Presets) == 0 {
+			add(LintAccountNoFilters, LintSeverityWarning, path,
+				"account has no filters, every resource including the credentials used by aws-nuke will be removed")
+		}
+
+		var inherited Filters
+		if c.Defaults != nil && (account.InheritDefaults == nil || *account.InheritDefaults) {
+			inherited = c.Defaults.Filters
+		}
+
+		lintResourceTypes(path+".resource-types", account.ResourceTypes)
+		lintFilters(path+".filters", account.Filters, inherited)
+	}
+
+	if c.Defaults != nil {
+		lintFilters("defaults.filters", c.Defaults.Filters, nil)
+	}
+
+	if defaults := c.OrgDiscovery.AccountDefaults; defaults != nil {
+		lintFilters("org-discovery.account-defaults.filters", defaults.Filters, nil)
+	}
+
+	presetNames := []string{}
+	for name := range c.Presets {
+		presetNames = append(presetNames, name)
+	}
+	sort.Strings(presetNames)
+
+	for _, name := range presetNames {
+		path := fmt.Sprintf("presets.%s", name)
+		if !usedPresets[name] {
+			add(LintUnusedPreset, LintSeverityInfo, path,
+				"preset '%s' is not used by any account", name)
+		}
+
+		lintFilters(path+".filters", c.Presets[name].Filters, nil)
+	}
+
+	profileNames := []string{}
+	for name := range c.RunProfiles {
+		profileNames = append(profileNames, name)
+	}
+	sort.Strings(profileNames)
+
+	for _, name := range profileNames {
+		lintResourceTypes(fmt.Sprintf("profiles.%s.resource-types", name), c.RunProfiles[name].ResourceTypes)
+	}
+
+	return issues
+}
+
+// Validate checks whether the filter can be evaluated at all.
+func (f Filter) Validate() error {
+	if isTrue(f.CaseInsensitive) && f.Type != FilterTypeGlob && f.Type != FilterTypeExtendedGlob {
+		return fmt.Errorf("case-insensitive can only be used by glob and extendedGlob filters")
+	}
+
+	if f.File != "" && f.Type != FilterTypeEmpty && f.Type != FilterTypeExact && f.Type != FilterTypeIn {
+		return fmt.Errorf("a file can only be used by exact and in filters")
+	}
+
+	if f.File != "" && f.Value != "" {
+		return fmt.Errorf("a filter can either have a value or a file")
+	}
+
+	switch f.Type {
+	case FilterTypeEmpty, FilterTypeExact, FilterTypeContains:
+		return nil
+	case FilterTypeIn:
+		if f.File == "" {
+			return fmt.Errorf("in filters need a file")
+		}
+		return nil
+	case FilterTypeGlob, FilterTypeExtendedGlob:
+		_, err := compileGlob(f.Value, f.Type == FilterTypeExtendedGlob, isTrue(f.CaseInsensitive))
+		if err != nil {
+			return fmt.Errorf("invalid glob '%s': %v", f.Value, err)
+		}
+		return nil
+	case FilterTypeRegex:
+		_, err := regexp.Compile(f.Value)
+		if err != nil {
+			return fmt.Errorf("invalid regex '%s': %v", f.Value, err)
+		}
+		return nil
+	case FilterTypeDateOlderThan:
+		_, err := time.ParseDuration(f.Value)
+		if err != nil {
+			return fmt.Errorf("invalid duration '%s': %v", f.Value, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown filter type '%s'", f.Type)
+	}
+}
+
+func (f Filter) matchesEverything() bool {
+	if f.Type == FilterTypeDateOlderThan {
+		return false
+	}
+
+	for _, sample := range lintSamples {
+		match, err := f.Match(sample)
+		if err != nil || !match {
+			return false
+		}
+	}
+	return true
+}
+
+// propertyRoot strips the path of properties like _raw.State.Name, which
+// access a field of a JSON property.
+func propertyRoot(property string) string {
+	if strings.HasPrefix(property, "_") {
+		return strings.SplitN(property, ".", 2)[0]
+	}
+	return property
+}
+
+// isDynamicProperty returns true for properties, which depend on the resource
+// itself and therefore cannot be known in advance, like tags.
+func isDynamicProperty(property string) bool {
+	return strings.HasPrefix(property, "tag:")
+}
+
+func isTrue(s string) bool {
+	return strings.TrimSpace(strings.ToLower(s)) == "true"
+}
+
+func sortedFilterTypes(filters Filters) []string {
+	types := make([]string, 0, len(filters))
+	for t := range filters {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func sortedAccountIDs(accounts map[string]Account) []string {
+	ids := make([]string, 0, len(accounts))
+	for id := range accounts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
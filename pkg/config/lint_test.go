@@ -0,0 +1,164 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	config := Nuke{
+		Regions: []string{"eu-west-1"},
+		ResourceTypes: ResourceTypes{
+			Excludes: []string{"IAMRole", "S3Buckit"},
+			ListOnly: []string{"IAMRoles"},
+		},
+		MaxDeletions: map[string]int{"IAMRole": 50, "S3Buckets": 10},
+		Accounts: map[string]Account{
+			"555133742": {
+				ResourceTypes: ResourceTypes{
+					Targets:      []string{"S3Bucket", "IAMRoll"},
+					CloudControl: []string{"AWS::ECR::PublicRepository"},
+				},
+				Presets: []string{"terraform", "missing"},
+				Filters: Filters{
+					"IAMRole": {
+						NewExactFilter("uber.admin"),
+						{Type: FilterTypeRegex, Value: ".*"},
+						{Type: FilterTypeRegex, Value: "("},
+					},
+					"IAMRoll": {
+						NewExactFilter("uber.admin"),
+					},
+					"AWS::ECR::PublicRepository": {
+						{Property: "RepositoryName", Value: "foo"},
+					},
+					"S3Bucket": {
+						{Property: "Nmae", Value: "foo"},
+						{Property: "tag:Name", Value: "foo"},
+					},
+				},
+			},
+			"666133742": {},
+		},
+		Presets: map[string]PresetDefinitions{
+			"terraform": {
+				Filters: Filters{
+					"S3Bucket": {{Type: FilterTypeGlob, Value: "my-statebucket-*"}},
+				},
+			},
+			"unused": {},
+		},
+		RunProfiles: map[string]RunProfile{
+			"nightly": {
+				ResourceTypes: ResourceTypes{Targets: []string{"S3Bucket", "EC2Instance"}},
+			},
+		},
+	}
+
+	issues := config.Lint(LintOptions{
+		ResourceTypes: []string{"IAMRole", "S3Bucket"},
+		Properties: func(resourceType string) ([]string, bool) {
+			if resourceType == "S3Bucket" {
+				return []string{"Name", "CreationDate"}, true
+			}
+			return nil, false
+		},
+	})
+
+	have := []string{}
+	for _, issue := range issues {
+		have = append(have, issue.Code+" "+issue.Path)
+	}
+
+	want := []string{
+		"NUKE001 account-blacklist",
+		"NUKE002 resource-types.excludes[1]",
+		"NUKE002 resource-types.list-only[0]",
+		"NUKE002 max-deletions.S3Buckets",
+		"NUKE005 accounts.555133742.presets[1]",
+		"NUKE002 accounts.555133742.resource-types.targets[1]",
+		"NUKE007 accounts.555133742.filters.IAMRole[1]",
+		"NUKE008 accounts.555133742.filters.IAMRole[2]",
+		"NUKE002 accounts.555133742.filters.IAMRoll",
+		"NUKE003 accounts.555133742.filters.S3Bucket[0]",
+		"NUKE006 accounts.666133742",
+		"NUKE004 presets.unused",
+		"NUKE002 profiles.nightly.resource-types.targets[1]",
+	}
+
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Wrong lint issues:")
+		t.Errorf("  Want: %#v", want)
+		t.Errorf("  Have: %#v", have)
+	}
+}
+
+func TestLintDefaults(t *testing.T) {
+	broken := Filter{Type: FilterTypeRegex, Value: "("}
+	config := Nuke{
+		Regions:          []string{"eu-west-1"},
+		AccountBlacklist: []string{"1234567890"},
+		Defaults: &Account{
+			Filters: Filters{"IAMRole": {broken}},
+		},
+		OrgDiscovery: OrgDiscovery{
+			AccountDefaults: &Account{
+				Filters: Filters{"IAMRoll": {NewExactFilter("uber.admin")}},
+			},
+		},
+		Accounts: map[string]Account{
+			// The filters of the accounts are already merged with the
+			// defaults, like they are after loading the config.
+			"555133742": {
+				Filters: Filters{"IAMRole": {broken, {Type: FilterTypeRegex, Value: ".*"}}},
+			},
+			"666133742": {
+				Filters: Filters{"IAMRole": {broken}},
+			},
+		},
+	}
+
+	issues := config.Lint(LintOptions{
+		ResourceTypes: []string{"IAMRole"},
+	})
+
+	have := []string{}
+	for _, issue := range issues {
+		have = append(have, issue.Code+" "+issue.Path)
+	}
+
+	want := []string{
+		"NUKE007 accounts.555133742.filters.IAMRole[0]",
+		"NUKE008 defaults.filters.IAMRole[0]",
+		"NUKE002 org-discovery.account-defaults.filters.IAMRoll",
+	}
+
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Wrong lint issues:")
+		t.Errorf("  Want: %#v", want)
+		t.Errorf("  Have: %#v", have)
+	}
+}
+
+func TestLintMatchesEverything(t *testing.T) {
+	cases := []struct {
+		filter Filter
+		want   bool
+	}{
+		{filter: Filter{Type: FilterTypeRegex, Value: ".*"}, want: true},
+		{filter: Filter{Type: FilterTypeRegex, Value: ""}, want: true},
+		{filter: Filter{Type: FilterTypeRegex, Value: "^prod-"}, want: false},
+		{filter: Filter{Type: FilterTypeGlob, Value: "**"}, want: true},
+		{filter: Filter{Type: FilterTypeGlob, Value: "prod-*"}, want: false},
+		{filter: Filter{Type: FilterTypeContains, Value: ""}, want: true},
+		{filter: Filter{Type: FilterTypeDateOlderThan, Value: "0s"}, want: false},
+		{filter: NewExactFilter("foo"), want: false},
+	}
+
+	for _, tc := range cases {
+		have := tc.filter.matchesEverything()
+		if have != tc.want {
+			t.Errorf("Wrong result for %#v. Want: %t. Have: %t", tc.filter, tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrgDiscovery selects the accounts of a run with --org-discover among the
+// active accounts of the organization.
+type OrgDiscovery struct {
+	// OUPaths are paths of organizational unit names like Root/Sandbox. Only
+	// accounts in these units or below them are selected. An empty list
+	// selects all accounts.
+	OUPaths []string `yaml:"ou-paths,omitempty"`
+
+	// Tags have to be set on an account with the given values, so it is
+	// selected.
+	Tags map[string]string `yaml:"tags,omitempty"`
+
+	// AccountDefaults are the settings of discovered accounts, which are not
+	// listed in the accounts section. Without them, these accounts are
+	// skipped. They require OUPaths or Tags, so they never apply to all
+	// accounts of the organization.
+	AccountDefaults *Account `yaml:"account-defaults,omitempty"`
+}
+
+// Matches returns true, if the account with the given OU path and tags is
+// selected.
+func (d OrgDiscovery) Matches(ouPath string, tags map[string]string) bool {
+	for key, value := range d.Tags {
+		if tags[key] != value {
+			return false
+		}
+	}
+
+	if len(d.OUPaths) == 0 {
+		return true
+	}
+
+	for _, path := range d.OUPaths {
+		path = strings.TrimSuffix(path, "/")
+		if ouPath == path || strings.HasPrefix(ouPath, path+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Nuke) validateOrgDiscovery() error {
+	d := c.OrgDiscovery
+	if d.AccountDefaults != nil && len(d.OUPaths) == 0 && len(d.Tags) == 0 {
+		return fmt.Errorf("The account-defaults of the org-discovery require ou-paths or tags, " +
+			"since they would apply to every account of the organization otherwise.")
+	}
+
+	return nil
+}
+
+// UseAccountDefaults adds the account-defaults for the account to the
+// accounts section, unless it is already listed. It returns false, if the
+// account is not listed and there are no defaults.
+func (c *Nuke) UseAccountDefaults(accountID string) bool {
+	if _, ok := c.Accounts[accountID]; ok {
+		return true
+	}
+
+	defaults := c.OrgDiscovery.AccountDefaults
+	if defaults == nil {
+		return false
+	}
+
+	if c.Accounts == nil {
+		c.Accounts = map[string]Account{}
+	}
+	c.Accounts[accountID] = c.withDefaults(*defaults)
+	return true
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrgDiscoveryMatches(t *testing.T) {
+	d := OrgDiscovery{
+		OUPaths: []string{"Root/Sandbox/"},
+		Tags:    map[string]string{"purpose": "sandbox"},
+	}
+	sandbox := map[string]string{"purpose": "sandbox", "team": "a"}
+
+	cases := []struct {
+		path  string
+		tags  map[string]string
+		match bool
+	}{
+		{"Root/Sandbox", sandbox, true},
+		{"Root/Sandbox/Team", sandbox, true},
+		{"Root/SandboxOld", sandbox, false},
+		{"Root", sandbox, false},
+		{"Root/Sandbox", map[string]string{"purpose": "production"}, false},
+		{"Root/Sandbox", nil, false},
+	}
+
+	for _, tc := range cases {
+		if d.Matches(tc.path, tc.tags) != tc.match {
+			t.Errorf("Wrong match for %s with %v.", tc.path, tc.tags)
+		}
+	}
+
+	if !(OrgDiscovery{}).Matches("Root", nil) {
+		t.Errorf("An empty org-discovery does not match all accounts.")
+	}
+}
+
+func TestUseAccountDefaults(t *testing.T) {
+	c := &Nuke{}
+	if c.UseAccountDefaults("111111111111") {
+		t.Errorf("Accounts without defaults are used.")
+	}
+
+	c.OrgDiscovery.AccountDefaults = &Account{Presets: []string{"sandbox"}}
+	if !c.UseAccountDefaults("111111111111") {
+		t.Fatalf("The account defaults are not used.")
+	}
+	if !reflect.DeepEqual(c.Accounts["111111111111"].Presets, []string{"sandbox"}) {
+		t.Errorf("Wrong account settings: %+v", c.Accounts["111111111111"])
+	}
+}
+
+func TestValidateOrgDiscovery(t *testing.T) {
+	defaults := &Account{Presets: []string{"sandbox"}}
+
+	cases := []struct {
+		discovery OrgDiscovery
+		valid     bool
+	}{
+		{OrgDiscovery{}, true},
+		{OrgDiscovery{AccountDefaults: defaults}, false},
+		{OrgDiscovery{AccountDefaults: defaults, OUPaths: []string{"Root/Sandbox"}}, true},
+		{OrgDiscovery{AccountDefaults: defaults, Tags: map[string]string{"purpose": "sandbox"}}, true},
+	}
+
+	for i, tc := range cases {
+		c := &Nuke{OrgDiscovery: tc.discovery}
+		err := c.validateOrgDiscovery()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d: Wrong validation result: %v", i, err)
+		}
+	}
+}
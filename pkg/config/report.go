@@ -0,0 +1,85 @@
+package config
+
+import "fmt"
+
+// Backends of the report storage.
+const (
+	ReportBackendFile   = "file"
+	ReportBackendS3     = "s3"
+	ReportBackendStdout = "stdout"
+)
+
+// Server-side encryption of reports, which are stored in S3.
+const (
+	ReportEncryptionAES256 = "AES256"
+	ReportEncryptionKMS    = "aws:kms"
+)
+
+// ReportStorage specifies where the report of every run is stored, eg in S3
+// since CI containers are ephemeral. It is used, if --output is not
+// specified.
+type ReportStorage struct {
+	Backend string `yaml:"backend,omitempty"`
+
+	// Format is the format of the report. It defaults to json.
+	Format string `yaml:"format,omitempty"`
+
+	// Path is the file of the file backend.
+	Path string `yaml:"path,omitempty"`
+
+	// Bucket and Prefix are the location of the s3 backend. The report of
+	// each run is stored as <prefix>/<account-id>/<start time>.<format>.
+	Bucket string `yaml:"bucket,omitempty"`
+	Prefix string `yaml:"prefix,omitempty"`
+
+	// Encryption is the server-side encryption of the s3 backend. KMSKeyID
+	// is the key for aws:kms. Without it, the AWS managed key is used.
+	Encryption string `yaml:"encryption,omitempty"`
+	KMSKeyID   string `yaml:"kms-key-id,omitempty"`
+}
+
+func (c *Nuke) validateReportStorage() error {
+	s := c.Report
+
+	switch s.Backend {
+	case "":
+		if s != (ReportStorage{}) {
+			return fmt.Errorf("The report storage does not specify a backend. "+
+				"Supported backends are: %s, %s, %s.", ReportBackendFile, ReportBackendS3, ReportBackendStdout)
+		}
+		return nil
+
+	case ReportBackendFile:
+		if s.Path == "" {
+			return fmt.Errorf("The report storage with the file backend does not specify a path.")
+		}
+
+	case ReportBackendS3:
+		if s.Bucket == "" {
+			return fmt.Errorf("The report storage with the s3 backend does not specify a bucket.")
+		}
+
+	case ReportBackendStdout:
+
+	default:
+		return fmt.Errorf("The report storage has the invalid backend '%s'. "+
+			"Supported backends are: %s, %s, %s.", s.Backend, ReportBackendFile, ReportBackendS3, ReportBackendStdout)
+	}
+
+	if s.Backend != ReportBackendS3 && (s.Bucket != "" || s.Prefix != "" || s.Encryption != "" || s.KMSKeyID != "") {
+		return fmt.Errorf("The bucket, prefix and encryption of the report storage are only supported by the s3 backend.")
+	}
+
+	switch s.Encryption {
+	case "", ReportEncryptionAES256, ReportEncryptionKMS:
+	default:
+		return fmt.Errorf("The report storage has the invalid encryption '%s'. "+
+			"Supported encryptions are: %s, %s.", s.Encryption, ReportEncryptionAES256, ReportEncryptionKMS)
+	}
+
+	if s.KMSKeyID != "" && s.Encryption != ReportEncryptionKMS {
+		return fmt.Errorf("The kms-key-id of the report storage requires the encryption %s.", ReportEncryptionKMS)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestValidateReportStorage(t *testing.T) {
+	cases := []struct {
+		storage ReportStorage
+		valid   bool
+	}{
+		{ReportStorage{}, true},
+		{ReportStorage{Backend: ReportBackendStdout, Format: "markdown"}, true},
+		{ReportStorage{Backend: ReportBackendFile, Path: "report.json"}, true},
+		{ReportStorage{Backend: ReportBackendS3, Bucket: "audit", Prefix: "aws-nuke"}, true},
+		{ReportStorage{Backend: ReportBackendS3, Bucket: "audit", Encryption: ReportEncryptionAES256}, true},
+		{ReportStorage{Backend: ReportBackendS3, Bucket: "audit", Encryption: ReportEncryptionKMS, KMSKeyID: "alias/aws-nuke"}, true},
+		{ReportStorage{Bucket: "audit"}, false},
+		{ReportStorage{Backend: "gcs", Bucket: "audit"}, false},
+		{ReportStorage{Backend: ReportBackendFile}, false},
+		{ReportStorage{Backend: ReportBackendS3}, false},
+		{ReportStorage{Backend: ReportBackendFile, Path: "report.json", Bucket: "audit"}, false},
+		{ReportStorage{Backend: ReportBackendS3, Bucket: "audit", Encryption: "aws:kms:dsse"}, false},
+		{ReportStorage{Backend: ReportBackendS3, Bucket: "audit", KMSKeyID: "alias/aws-nuke"}, false},
+	}
+
+	for i, tc := range cases {
+		c := &Nuke{Report: tc.storage}
+		err := c.validateReportStorage()
+		if (err == nil) != tc.valid {
+			t.Errorf("Case %d: wrong validation result for %+v: %v", i, tc.storage, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import "gopkg.in/yaml.v2"
+
+// Resolve returns a copy of the config, in which the defaults and presets are
+// merged into the filters of the accounts and the accounts list the regions, which are
+// scanned for them. YAML anchors are already expanded by Load.
+func (c *Nuke) Resolve() (*Nuke, error) {
+	resolved := *c
+	resolved.Presets = nil
+	resolved.Defaults = nil
+	resolved.Accounts = map[string]Account{}
+
+	for id, account := range c.Accounts {
+		filters := Filters{}
+		filters.Merge(account.Filters)
+
+		for _, name := range account.Presets {
+			preset, err := c.preset(name)
+			if err != nil {
+				return nil, err
+			}
+
+			filters.Merge(preset.Filters)
+		}
+
+		account.Filters = filters
+		account.Presets = nil
+		account.InheritDefaults = nil
+		account.Regions = c.AccountRegions(id)
+		resolved.Accounts[id] = account
+	}
+
+	return &resolved, nil
+}
+
+// Canonical returns the resolved config as YAML. The keys of maps are sorted
+// and empty settings are omitted, so configs which only differ in formatting
+// result in the same document.
+func (c *Nuke) Canonical() ([]byte, error) {
+	resolved, err := c.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(resolved)
+}
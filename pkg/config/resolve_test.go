@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	err := os.WriteFile(path, []byte(`
+regions: &regions
+- global
+- eu-west-1
+account-blacklist: [ "999999999999" ]
+presets:
+  common:
+    filters:
+      IAMRole:
+      - OrganizationAccountAccessRole
+accounts:
+  "111111111111":
+    presets: [ common ]
+    filters:
+      IAMRole:
+      - { property: Name, type: regex, value: "^admin" }
+  "222222222222":
+    regions: *regions
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canonical, err := conf.Canonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"      - OrganizationAccountAccessRole\n",
+		"      - property: Name\n        type: regex\n        value: ^admin\n",
+	} {
+		if !strings.Contains(string(canonical), want) {
+			t.Errorf("Canonical config does not contain %q:\n%s", want, canonical)
+		}
+	}
+	if strings.Contains(string(canonical), "presets") {
+		t.Errorf("Canonical config contains presets:\n%s", canonical)
+	}
+
+	err = os.WriteFile(path, canonical, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := conf.Canonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(canonical) {
+		t.Errorf("Canonical config is not stable.\nFirst:\n%s\nSecond:\n%s", canonical, again)
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SchemaVersion is the latest version of the config format which is
+// understood by this build. It has to be increased whenever a config setting
+// is added, that must not be ignored by older builds, because it restricts
+// what is removed.
+const SchemaVersion = 1
+
+// CheckCompatibility fails if the config requires a newer schema or a newer
+// version of aws-nuke than the running one. Otherwise an older binary could
+// silently ignore protections that were added to the config for a newer one.
+func (c *Nuke) CheckCompatibility(binaryVersion string) error {
+	if c.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("The config file uses schema version %d, but this version of aws-nuke "+
+			"only supports up to schema version %d. Please upgrade aws-nuke.",
+			c.SchemaVersion, SchemaVersion)
+	}
+
+	if strings.TrimSpace(c.MinVersion) == "" {
+		return nil
+	}
+
+	required, err := parseVersion(c.MinVersion)
+	if err != nil {
+		return fmt.Errorf("Invalid min-version in config: %v", err)
+	}
+
+	current, err := parseVersion(binaryVersion)
+	if err != nil {
+		log.Warnf("Cannot verify the min-version '%s' of the config, since the version "+
+			"of this build is unknown ('%s').", c.MinVersion, binaryVersion)
+		return nil
+	}
+
+	for i := range required {
+		if current[i] > required[i] {
+			return nil
+		}
+		if current[i] < required[i] {
+			return fmt.Errorf("The config file requires at least aws-nuke %s, but this is "+
+				"version %s. Please upgrade aws-nuke.", c.MinVersion, binaryVersion)
+		}
+	}
+
+	return nil
+}
+
+// parseVersion parses the major, minor and patch part of a version string
+// like "v2.14.0" or "v2.14.0.3.g1a2b3c4", which is the format of the build
+// version.
+func parseVersion(version string) ([3]int, error) {
+	var result [3]int
+
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	for i := range result {
+		if i >= len(parts) {
+			break
+		}
+
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			if i == 0 {
+				return result, fmt.Errorf("cannot parse version '%s'", version)
+			}
+			break
+		}
+		result[i] = n
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	cases := []struct {
+		schema  int
+		min     string
+		binary  string
+		wantErr bool
+	}{
+		{schema: 0, min: "", binary: "unknown", wantErr: false},
+		{schema: SchemaVersion, min: "", binary: "v2.0.0", wantErr: false},
+		{schema: SchemaVersion + 1, min: "", binary: "v2.0.0", wantErr: true},
+		{min: "v2.14.0", binary: "v2.14.0", wantErr: false},
+		{min: "v2.14.0", binary: "v2.14.0.3.g1a2b3c4", wantErr: false},
+		{min: "2.14", binary: "v2.15.0", wantErr: false},
+		{min: "v2.14.0", binary: "v3.0.0", wantErr: false},
+		{min: "v2.14.1", binary: "v2.14.0", wantErr: true},
+		{min: "v2.14.0", binary: "v2.9.9", wantErr: true},
+		{min: "v2.14.0", binary: "unknown", wantErr: false},
+		{min: "latest", binary: "v2.14.0", wantErr: true},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			config := Nuke{SchemaVersion: tc.schema, MinVersion: tc.min}
+			err := config.CheckCompatibility(tc.binary)
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected an error for min-version %#v and version %#v.", tc.min, tc.binary)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
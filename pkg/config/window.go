@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// RunWindow is a weekly time range, in which runs with --no-dry-run are
+// allowed. It is written like "Mon-Fri 18:00-23:59 Europe/Berlin". The days
+// can be a single day, a range or a comma separated list and refer to the
+// start of the window. A window whose end is before its start lasts until the
+// next day. The time zone defaults to UTC.
+type RunWindow struct {
+	days     [7]bool
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+func ParseRunWindow(s string) (RunWindow, error) {
+	w := RunWindow{location: time.UTC}
+
+	fields := strings.Fields(s)
+	if len(fields) < 2 || len(fields) > 3 {
+		return w, fmt.Errorf("invalid run window '%s'; expected eg 'Sat 00:00-23:59 UTC'", s)
+	}
+
+	for _, part := range strings.Split(fields[0], ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		from, ok := weekdays[strings.ToLower(bounds[0])]
+		if !ok {
+			return w, fmt.Errorf("invalid day '%s' in run window '%s'", bounds[0], s)
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			to, ok = weekdays[strings.ToLower(bounds[1])]
+			if !ok {
+				return w, fmt.Errorf("invalid day '%s' in run window '%s'", bounds[1], s)
+			}
+		}
+
+		for d := from; ; d = (d + 1) % 7 {
+			w.days[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+
+	times := strings.SplitN(fields[1], "-", 2)
+	if len(times) != 2 {
+		return w, fmt.Errorf("invalid time range '%s' in run window '%s'", fields[1], s)
+	}
+
+	var err error
+	w.start, err = parseClock(times[0])
+	if err != nil {
+		return w, fmt.Errorf("invalid start in run window '%s': %v", s, err)
+	}
+	w.end, err = parseClock(times[1])
+	if err != nil {
+		return w, fmt.Errorf("invalid end in run window '%s': %v", s, err)
+	}
+
+	if len(fields) == 3 {
+		w.location, err = time.LoadLocation(fields[2])
+		if err != nil {
+			return w, fmt.Errorf("invalid time zone in run window '%s': %v", s, err)
+		}
+	}
+
+	return w, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns true, if the time is within the window. The end minute is
+// part of the window, so "00:00-23:59" covers the whole day.
+func (w RunWindow) Contains(t time.Time) bool {
+	local := t.In(w.location)
+	day := local.Weekday()
+	clock := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	end := w.end + time.Minute
+
+	if w.start <= w.end {
+		return w.days[day] && clock >= w.start && clock < end
+	}
+
+	previous := (day + 6) % 7
+	return (w.days[day] && clock >= w.start) || (w.days[previous] && clock < end)
+}
+
+func (c *Nuke) validateRunWindows() error {
+	for _, s := range c.AllowedRunWindows {
+		_, err := ParseRunWindow(s)
+		if err != nil {
+			return fmt.Errorf("The allowed-run-windows are invalid: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// InRunWindow returns true, if the time is within one of the
+// allowed-run-windows or if there are none.
+func (c *Nuke) InRunWindow(t time.Time) bool {
+	if len(c.AllowedRunWindows) == 0 {
+		return true
+	}
+
+	for _, s := range c.AllowedRunWindows {
+		w, err := ParseRunWindow(s)
+		if err == nil && w.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunWindow(t *testing.T) {
+	// 2024-03-02 is a Saturday.
+	sat := func(clock string) time.Time {
+		ts, err := time.Parse("2006-01-02 15:04", "2024-03-02 "+clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	cases := []struct {
+		window string
+		time   time.Time
+		want   bool
+	}{
+		{"Sat 00:00-23:59 UTC", sat("00:00"), true},
+		{"Sat 00:00-23:59 UTC", sat("23:59").Add(59 * time.Second), true},
+		{"Sat 00:00-23:59", sat("12:00").Add(24 * time.Hour), false},
+		{"Mon-Fri 09:00-17:00", sat("12:00"), false},
+		{"Fri-Sun 09:00-17:00", sat("12:00"), true},
+		{"Sun,Sat 09:00-10:00", sat("09:30"), true},
+		{"Fri 22:00-06:00", sat("05:00"), true},
+		{"Fri 22:00-06:00", sat("07:00"), false},
+		{"Sat 11:00-12:00 Europe/Berlin", sat("10:30"), true},
+	}
+
+	for _, tc := range cases {
+		w, err := ParseRunWindow(tc.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		have := w.Contains(tc.time)
+		if have != tc.want {
+			t.Errorf("Wrong result for %s at %v. Want: %t. Have: %t", tc.window, tc.time, tc.want, have)
+		}
+	}
+}
+
+func TestParseRunWindowInvalid(t *testing.T) {
+	for _, s := range []string{"Sat", "Caturday 00:00-23:59", "Sat 25:00-26:00", "Sat 00:00-23:59 Mars/Olympus"} {
+		_, err := ParseRunWindow(s)
+		if err == nil {
+			t.Errorf("Expected error for '%s'.", s)
+		}
+	}
+}
+
+func TestInRunWindow(t *testing.T) {
+	c := &Nuke{}
+	if !c.InRunWindow(time.Now()) {
+		t.Errorf("Without windows every time should be allowed.")
+	}
+
+	c.AllowedRunWindows = []string{"Sat 00:00-23:59"}
+	if c.InRunWindow(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Monday should not be within the window.")
+	}
+}
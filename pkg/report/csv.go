@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+)
+
+func init() {
+	register("csv", WriteCSV)
+}
+
+var csvHeader = []string{"region", "type", "id", "state", "reason", "owner", "owner-tag", "properties"}
+
+// WriteCSV writes the report as CSV with one row per resource, so it can be
+// imported into spreadsheets.
+func WriteCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range r.Entries {
+		row := []string{
+			e.Region,
+			e.Type,
+			e.ID,
+			e.State,
+			e.Reason,
+			e.Owner,
+			ownerTag(e.Properties),
+			csvProperties(e.Properties),
+		}
+		for i := range row {
+			row[i] = csvCell(row[i])
+		}
+
+		err := cw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell prevents spreadsheets from evaluating a cell as formula, since tags
+// and names of resources can be chosen by anyone with access to the account.
+// Cells which start with a formula character are prefixed with a quote, as
+// recommended by OWASP.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+	return value
+}
+
+// ownerTag returns the value of the Owner tag, regardless of the case of the
+// tag key.
+func ownerTag(properties map[string]string) string {
+	for k, v := range properties {
+		if strings.EqualFold(k, "tag:owner") {
+			return v
+		}
+	}
+	return ""
+}
+
+func csvProperties(properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+properties[k])
+	}
+	return strings.Join(parts, "; ")
+}
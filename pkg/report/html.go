@@ -0,0 +1,187 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+func init() {
+	register("html", WriteHTML)
+}
+
+type htmlTable struct {
+	Region  string
+	Type    string
+	Entries []Entry
+}
+
+type htmlPage struct {
+	*Report
+
+	Title    string
+	Counts   []htmlCount
+	Failures []Entry
+	Tables   []htmlTable
+}
+
+type htmlCount struct {
+	State string
+	Count int
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339)
+	},
+	"duration": func(from, to time.Time) string {
+		if from.IsZero() || to.IsZero() {
+			return "-"
+		}
+		return to.Sub(from).Round(time.Second).String()
+	},
+	"properties": func(p map[string]string) []string {
+		keys := make([]string, 0, len(p))
+		for k := range p {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		result := make([]string, 0, len(keys))
+		for _, k := range keys {
+			result = append(result, fmt.Sprintf("%s: %s", k, p[k]))
+		}
+		return result
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+td.properties { font-size: 0.85em; }
+.state-new, .state-failed { color: #b00; font-weight: bold; }
+.state-finished { color: #070; }
+.state-filtered { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<table>
+<tr><th>Account</th><td>{{ .AccountID }}{{ if .AccountAlias }} ({{ .AccountAlias }}){{ end }}</td></tr>
+<tr><th>Config</th><td>{{ .Config }}</td></tr>
+<tr><th>Mode</th><td>{{ if .DryRun }}dry run{{ else }}removal{{ end }}</td></tr>
+<tr><th>aws-nuke</th><td>{{ .Version }}</td></tr>
+{{- with .Metadata }}
+<tr><th>Build</th><td>{{ .BuildHash }}</td></tr>
+<tr><th>Config SHA-256</th><td>{{ .ConfigSHA256 }}</td></tr>
+{{- if .ConfigRevision }}
+<tr><th>Config revision</th><td>{{ .ConfigRevision }}</td></tr>
+{{- end }}
+<tr><th>Caller</th><td>{{ .CallerARN }}</td></tr>
+<tr><th>Flags</th><td>{{ range .Flags }}{{ . }}<br>{{ end }}</td></tr>
+{{- end }}
+<tr><th>Started</th><td>{{ timestamp .Started }}</td></tr>
+<tr><th>Scan duration</th><td>{{ duration .Started .ScanCompleted }}</td></tr>
+<tr><th>Finished</th><td>{{ timestamp .Time }}</td></tr>
+<tr><th>Total duration</th><td>{{ duration .Started .Time }}</td></tr>
+</table>
+
+<h2>Summary</h2>
+<table>
+<tr><th>State</th><th>Resources</th></tr>
+{{- range .Counts }}
+<tr><td class="state-{{ .State }}">{{ .State }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</table>
+{{ if .Failures }}
+<h2>Failures</h2>
+<table>
+<tr><th>Region</th><th>Type</th><th>Resource</th><th>Reason</th></tr>
+{{- range .Failures }}
+<tr><td>{{ .Region }}</td><td>{{ .Type }}</td><td>{{ .Name }}</td><td>{{ .Reason }}</td></tr>
+{{- end }}
+</table>
+{{ end }}
+{{- if .ScanErrors }}
+<h2>Scan errors</h2>
+<p>These resource types could not be listed. Their resources are missing from this report.</p>
+<table>
+<tr><th>Region</th><th>Type</th><th>Error</th></tr>
+{{- range .ScanErrors }}
+<tr><td>{{ .Region }}</td><td>{{ .Type }}</td><td>{{ .Error }}</td></tr>
+{{- end }}
+</table>
+{{ end }}
+<h2>Resources</h2>
+{{- range .Tables }}
+<h3>{{ .Region }} - {{ .Type }}</h3>
+<table>
+<tr><th>ID</th><th>Properties</th><th>State</th><th>Reason</th><th>Owner</th></tr>
+{{- range .Entries }}
+<tr>
+<td>{{ .ID }}</td>
+<td class="properties">{{ range properties .Properties }}{{ . }}<br>{{ end }}</td>
+<td class="state-{{ .State }}">{{ .State }}</td>
+<td>{{ .Reason }}{{ if .EndOfLife }}<br>deprecated service: {{ .EndOfLife }}{{ end }}</td>
+<td>{{ .Owner }}</td>
+</tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a self-contained HTML page with a summary,
+// the failures and a table per region and resource type.
+func WriteHTML(w io.Writer, r *Report) error {
+	page := htmlPage{
+		Report: r,
+		Title:  fmt.Sprintf("aws-nuke report for %s", r.AccountID),
+	}
+
+	counts := map[string]int{}
+	tables := map[string]*htmlTable{}
+	for _, e := range r.Entries {
+		counts[e.State]++
+
+		if e.State == StateFailed {
+			page.Failures = append(page.Failures, e)
+		}
+
+		key := e.Region + "/" + e.Type
+		table, ok := tables[key]
+		if !ok {
+			table = &htmlTable{Region: e.Region, Type: e.Type}
+			tables[key] = table
+		}
+		table.Entries = append(table.Entries, e)
+	}
+
+	for _, state := range orderedStates {
+		if counts[state] > 0 {
+			page.Counts = append(page.Counts, htmlCount{State: state, Count: counts[state]})
+		}
+	}
+
+	keys := make([]string, 0, len(tables))
+	for key := range tables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		page.Tables = append(page.Tables, *tables[key])
+	}
+
+	return htmlTemplate.Execute(w, page)
+}
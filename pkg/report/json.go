@@ -0,0 +1,17 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func init() {
+	register("json", WriteJSON)
+}
+
+// WriteJSON writes the report as a single JSON document.
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,169 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+)
+
+func init() {
+	register("junit", WriteJUnit)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Errors   int              `xml:"errors,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Errors     int              `xml:"errors,attr,omitempty"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Cases      []junitTestCase  `xml:"testcase"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// newJUnitProperties returns the run metadata as properties, which are added
+// to every test suite.
+func newJUnitProperties(r *Report) *junitProperties {
+	m := r.Metadata
+	if m == nil {
+		return nil
+	}
+
+	return &junitProperties{Properties: []junitProperty{
+		{Name: "version", Value: r.Version},
+		{Name: "build-hash", Value: m.BuildHash},
+		{Name: "config-sha256", Value: m.ConfigSHA256},
+		{Name: "config-revision", Value: m.ConfigRevision},
+		{Name: "caller-arn", Value: m.CallerARN},
+		{Name: "flags", Value: strings.Join(m.Flags, " ")},
+	}}
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML with one test suite per resource
+// type and one test case per resource. Resources which would be removed or
+// failed to be removed are failures, filtered resources are skipped. After a
+// removal, removed resources are passed test cases.
+func WriteJUnit(w io.Writer, r *Report) error {
+	suites := map[string]*junitTestSuite{}
+	suiteFor := func(resourceType string) *junitTestSuite {
+		suite, ok := suites[resourceType]
+		if !ok {
+			suite = &junitTestSuite{Name: resourceType, Properties: newJUnitProperties(r)}
+			if !r.Time.IsZero() {
+				suite.Timestamp = r.Time.UTC().Format("2006-01-02T15:04:05")
+			}
+			suites[resourceType] = suite
+		}
+		return suite
+	}
+
+	for _, e := range r.Entries {
+		suite := suiteFor(e.Type)
+
+		tc := junitTestCase{
+			Name:      e.Name(),
+			Classname: e.Type,
+		}
+
+		switch e.State {
+		case StateNew:
+			if r.DryRun {
+				tc.Failure = &junitMessage{Message: "resource would be removed", Text: e.Owner}
+			} else {
+				tc.Failure = &junitMessage{Message: "resource was not removed", Text: e.Reason}
+			}
+		case StateFailed:
+			tc.Failure = &junitMessage{Message: "resource could not be removed", Text: e.Reason}
+		case StatePending, StateWaiting:
+			tc.Failure = &junitMessage{Message: "resource removal did not finish", Text: e.Reason}
+		case StateFiltered:
+			tc.Skipped = &junitMessage{Message: e.Reason}
+		}
+
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	// Resource types, which could not be listed, are errors, since their
+	// resources are missing.
+	for _, e := range r.ScanErrors {
+		suite := suiteFor(e.Type)
+		suite.Tests++
+		suite.Errors++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      e.Region + " - listing",
+			Classname: e.Type,
+			Error:     &junitMessage{Message: "resource type could not be listed", Text: e.Error},
+		})
+	}
+
+	names := make([]string, 0, len(suites))
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	doc := junitTestSuites{Name: "aws-nuke " + r.AccountID}
+	for _, name := range names {
+		suite := suites[name]
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Errors += suite.Errors
+		doc.Suites = append(doc.Suites, *suite)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,163 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+func init() {
+	register("markdown", WriteMarkdown)
+}
+
+// markdownMaxFailures limits the listed failures, so the summary stays
+// compact even if a whole resource type fails.
+const markdownMaxFailures = 50
+
+// WriteMarkdown writes a compact summary of the report as Markdown, which is
+// suitable for CI job summaries or merge request comments. Unlike the other
+// formats, it does not list every resource.
+func WriteMarkdown(w io.Writer, r *Report) error {
+	b := new(strings.Builder)
+
+	mode := "removal"
+	if r.DryRun {
+		mode = "dry run"
+	}
+
+	account := r.AccountID
+	if r.AccountAlias != "" {
+		account = fmt.Sprintf("%s (%s)", r.AccountID, r.AccountAlias)
+	}
+
+	fmt.Fprintf(b, "## aws-nuke %s of %s\n\n", mode, account)
+
+	if m := r.Metadata; m != nil {
+		fmt.Fprintf(b, "- Version: `%s` (%s)\n", r.Version, m.BuildHash)
+		fmt.Fprintf(b, "- Caller: `%s`\n", m.CallerARN)
+		fmt.Fprintf(b, "- Config SHA-256: `%s`\n", m.ConfigSHA256)
+		if m.ConfigRevision != "" {
+			fmt.Fprintf(b, "- Config revision: `%s`\n", m.ConfigRevision)
+		}
+		if len(m.Flags) > 0 {
+			fmt.Fprintf(b, "- Flags: `%s`\n", strings.Join(m.Flags, " "))
+		}
+		fmt.Fprintln(b)
+	}
+
+	counts := map[string]map[string]int{}
+	totals := map[string]int{}
+	reasons := map[string]int{}
+	failures := []Entry{}
+	for _, e := range r.Entries {
+		if counts[e.Type] == nil {
+			counts[e.Type] = map[string]int{}
+		}
+		counts[e.Type][e.State]++
+		totals[e.State]++
+
+		switch e.State {
+		case StateFiltered:
+			reasons[e.Reason]++
+		case StateFailed:
+			failures = append(failures, e)
+		}
+	}
+
+	if len(r.Entries) == 0 {
+		fmt.Fprintln(b, "No resources found.")
+		writeMarkdownScanErrors(b, r.ScanErrors)
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	states := []string{}
+	for _, state := range orderedStates {
+		if totals[state] > 0 {
+			states = append(states, state)
+		}
+	}
+
+	fmt.Fprintf(b, "| Resource type | %s |\n", strings.Join(states, " | "))
+	fmt.Fprintf(b, "|---|%s\n", strings.Repeat("---:|", len(states)))
+
+	resourceTypes := make([]string, 0, len(counts))
+	for resourceType := range counts {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	row := func(name string, values map[string]int) {
+		cells := make([]string, 0, len(states))
+		for _, state := range states {
+			cells = append(cells, fmt.Sprint(values[state]))
+		}
+		fmt.Fprintf(b, "| %s | %s |\n", name, strings.Join(cells, " | "))
+	}
+	for _, resourceType := range resourceTypes {
+		row(resourceType, counts[resourceType])
+	}
+	row("**Total**", totals)
+
+	if len(failures) > 0 {
+		fmt.Fprintf(b, "\n### Failures\n\n")
+		for i, e := range failures {
+			if i >= markdownMaxFailures {
+				fmt.Fprintf(b, "- ... and %d more\n", len(failures)-markdownMaxFailures)
+				break
+			}
+			fmt.Fprintf(b, "- `%s` %s: %s\n", e.Type, markdownEscape(e.Name()), markdownEscape(e.Reason))
+		}
+	}
+
+	if len(reasons) > 0 {
+		fmt.Fprintf(b, "\n### Skipped\n\n")
+		fmt.Fprintf(b, "| Reason | Resources |\n|---|---:|\n")
+
+		keys := make([]string, 0, len(reasons))
+		for reason := range reasons {
+			keys = append(keys, reason)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if reasons[keys[i]] != reasons[keys[j]] {
+				return reasons[keys[i]] > reasons[keys[j]]
+			}
+			return keys[i] < keys[j]
+		})
+
+		for _, reason := range keys {
+			fmt.Fprintf(b, "| %s | %d |\n", markdownEscape(reason), reasons[reason])
+		}
+	}
+
+	writeMarkdownScanErrors(b, r.ScanErrors)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownScanErrors(b *strings.Builder, errs []ScanError) {
+	if len(errs) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n### Scan errors\n\n")
+	for _, e := range errs {
+		fmt.Fprintf(b, "- `%s` %s: %s\n", e.Type, e.Region, markdownEscape(e.Error))
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"\n", " ",
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,152 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// States of the entries. They mirror the item states of a nuke run.
+const (
+	StateNew      = "new"
+	StatePending  = "pending"
+	StateWaiting  = "waiting"
+	StateFailed   = "failed"
+	StateFiltered = "filtered"
+	StateFinished = "finished"
+)
+
+// orderedStates are all entry states in the order they are shown in
+// summaries.
+var orderedStates = []string{
+	StateNew, StatePending, StateWaiting, StateFailed, StateFiltered, StateFinished,
+}
+
+// Report is the machine readable result of a scan or a nuke run.
+type Report struct {
+	Version      string    `json:"version"`
+	Config       string    `json:"config"`
+	AccountID    string    `json:"account-id"`
+	AccountAlias string    `json:"account-alias"`
+	DryRun       bool      `json:"dry-run"`
+	Time         time.Time `json:"time"`
+
+	// Started and ScanCompleted are the times the run started and the scan
+	// of all regions was completed. They are zero, if the run did not get
+	// that far.
+	Started       time.Time `json:"started"`
+	ScanCompleted time.Time `json:"scan-completed"`
+
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// Redacted is true, if values of the entries were redacted. Their IDs and
+	// properties do not identify the resources anymore.
+	Redacted bool `json:"redacted,omitempty"`
+
+	Entries []Entry `json:"entries"`
+
+	// ScanErrors are the resource types, which could not be listed. Their
+	// resources are missing from the entries.
+	ScanErrors []ScanError `json:"scan-errors,omitempty"`
+}
+
+// ScanError describes a resource type, whose lister failed or panicked in a
+// region.
+type ScanError struct {
+	Region string `json:"region"`
+	Type   string `json:"type"`
+	Error  string `json:"error"`
+	Panic  bool   `json:"panic,omitempty"`
+}
+
+// Metadata describes how the run was executed, so an audit can reconstruct
+// it.
+type Metadata struct {
+	BuildHash    string `json:"build-hash,omitempty"`
+	ConfigSHA256 string `json:"config-sha256,omitempty"`
+
+	// ConfigRevision is the commit of the repository containing the config,
+	// if it was specified with --config-revision.
+	ConfigRevision string `json:"config-revision,omitempty"`
+
+	CallerARN string `json:"caller-arn,omitempty"`
+
+	// Flags are the command line flags which were set, with the values of
+	// secrets redacted.
+	Flags []string `json:"flags"`
+}
+
+// Entry describes a single resource and its state at the end of the run.
+type Entry struct {
+	Region     string            `json:"region"`
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	State      string            `json:"state"`
+	Reason     string            `json:"reason,omitempty"`
+	Owner      string            `json:"owner,omitempty"`
+
+	// EndOfLife is set, if AWS announced the deprecation of the service of
+	// the resource.
+	EndOfLife string `json:"end-of-life,omitempty"`
+}
+
+// Name returns a string that identifies the entry within the report.
+func (e Entry) Name() string {
+	if e.ID != "" {
+		return fmt.Sprintf("%s - %s", e.Region, e.ID)
+	}
+
+	keys := make([]string, 0, len(e.Properties))
+	for k := range e.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	name := e.Region
+	for _, k := range keys {
+		name += fmt.Sprintf(" - %s: %s", k, e.Properties[k])
+	}
+	return name
+}
+
+// Writer serializes a report in a specific format.
+type Writer func(w io.Writer, r *Report) error
+
+var writers = map[string]Writer{}
+
+func register(format string, writer Writer) {
+	_, exists := writers[format]
+	if exists {
+		panic(fmt.Sprintf("a report writer for %s already exists", format))
+	}
+
+	writers[format] = writer
+}
+
+// Formats returns the names of all supported report formats.
+func Formats() []string {
+	formats := []string{}
+	for format := range writers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Supports returns true, if there is a writer for the format.
+func Supports(format string) bool {
+	_, ok := writers[format]
+	return ok
+}
+
+// Write serializes the report in the given format.
+func Write(format string, w io.Writer, r *Report) error {
+	writer, ok := writers[format]
+	if !ok {
+		return fmt.Errorf("unsupported output format '%s'", format)
+	}
+
+	return writer(w, r)
+}
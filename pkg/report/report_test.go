@@ -0,0 +1,366 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/report"
+)
+
+func testReport() *report.Report {
+	return &report.Report{
+		Version:   "v1.2.3",
+		Config:    "nuke-config.yaml",
+		AccountID: "012345678901",
+		Entries: []report.Entry{
+			{
+				Region: "eu-west-1",
+				Type:   "EC2Instance",
+				ID:     "i-01b489457a60298dd",
+				State:  report.StateNew,
+			},
+			{
+				Region: "eu-west-1",
+				Type:   "EC2Instance",
+				ID:     "i-0b0f4b4e8a0a7f3c1",
+				State:  report.StateFiltered,
+				Reason: "filtered by config",
+			},
+			{
+				Region:     "global",
+				Type:       "IAMRole",
+				Properties: map[string]string{"Name": "admin"},
+				State:      report.StateFailed,
+				Reason:     "AccessDenied",
+			},
+		},
+	}
+}
+
+func TestEntryName(t *testing.T) {
+	cases := []struct {
+		entry report.Entry
+		want  string
+	}{
+		{
+			entry: report.Entry{Region: "eu-west-1", ID: "foo"},
+			want:  "eu-west-1 - foo",
+		},
+		{
+			entry: report.Entry{Region: "global", Properties: map[string]string{"b": "2", "a": "1"}},
+			want:  "global - a: 1 - b: 2",
+		},
+	}
+
+	for _, tc := range cases {
+		have := tc.entry.Name()
+		if have != tc.want {
+			t.Errorf("Wrong name. Want: %#v. Have: %#v", tc.want, have)
+		}
+	}
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	err := report.Write("doc", new(bytes.Buffer), testReport())
+	if err == nil {
+		t.Fatal("Expected error for unsupported format.")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := report.Write("junit", buf, testReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+		Suites   []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	err = xml.Unmarshal(buf.Bytes(), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Tests != 3 || doc.Failures != 2 || doc.Skipped != 1 {
+		t.Errorf("Wrong counts. Have: tests=%d failures=%d skipped=%d",
+			doc.Tests, doc.Failures, doc.Skipped)
+	}
+
+	if len(doc.Suites) != 2 || doc.Suites[0].Name != "EC2Instance" || doc.Suites[1].Name != "IAMRole" {
+		t.Errorf("Wrong test suites: %#v", doc.Suites)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := report.Write("sarif", buf, testReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID       string        `json:"ruleId"`
+				Level        string        `json:"level"`
+				Suppressions []interface{} `json:"suppressions"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF document: %s", buf.String())
+	}
+
+	results := doc.Runs[0].Results
+	if len(results) != 3 {
+		t.Fatalf("Wrong number of results. Want: 3. Have: %d", len(results))
+	}
+
+	if results[0].RuleID != "nukeable-resource" || results[0].Level != "error" {
+		t.Errorf("Wrong first result: %#v", results[0])
+	}
+
+	if results[1].Level != "note" || len(results[1].Suppressions) != 1 {
+		t.Errorf("Filtered resource should be a suppressed note: %#v", results[1])
+	}
+
+	if results[2].RuleID != "removal-failed" {
+		t.Errorf("Wrong third result: %#v", results[2])
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := report.Write("json", buf, testReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var have report.Report
+	err = json.Unmarshal(buf.Bytes(), &have)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have.AccountID != "012345678901" || len(have.Entries) != 3 {
+		t.Fatalf("Unexpected JSON document: %s", buf.String())
+	}
+
+	if have.Entries[1].Reason != "filtered by config" || have.Entries[2].Properties["Name"] != "admin" {
+		t.Errorf("Wrong entries: %#v", have.Entries)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := testReport()
+	r.Entries[2].Properties["Name"] = "<script>"
+
+	buf := new(bytes.Buffer)
+	err := report.Write("html", buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	html := buf.String()
+	for _, want := range []string{
+		"<h3>eu-west-1 - EC2Instance</h3>",
+		"<h3>global - IAMRole</h3>",
+		"<h2>Failures</h2>",
+		"filtered by config",
+		"Name: &lt;script&gt;",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML report does not contain %q:\n%s", want, html)
+		}
+	}
+
+	if strings.Contains(html, "<script>") {
+		t.Errorf("HTML report contains unescaped properties.")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	r := testReport()
+	r.Entries[2].Properties["tag:owner"] = "platform"
+
+	buf := new(bytes.Buffer)
+	err := report.Write("csv", buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 4 || rows[0][0] != "region" {
+		t.Fatalf("Unexpected CSV rows: %v", rows)
+	}
+
+	want := []string{"global", "IAMRole", "", "failed", "AccessDenied", "", "platform", "Name=admin; tag:owner=platform"}
+	if strings.Join(rows[3], ",") != strings.Join(want, ",") {
+		t.Errorf("Wrong row. Want: %v. Have: %v", want, rows[3])
+	}
+}
+
+func TestWriteCSVFormulas(t *testing.T) {
+	r := testReport()
+	r.Entries[2].ID = "=HYPERLINK(\"https://example.com\")"
+	r.Entries[2].Reason = "-1"
+	r.Entries[2].Properties = map[string]string{"tag:owner": "@admin"}
+
+	buf := new(bytes.Buffer)
+	err := report.Write("csv", buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"global", "IAMRole", "'=HYPERLINK(\"https://example.com\")", "failed", "'-1", "", "'@admin", "tag:owner=@admin"}
+	if strings.Join(rows[3], ",") != strings.Join(want, ",") {
+		t.Errorf("Wrong row. Want: %v. Have: %v", want, rows[3])
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := report.Write("markdown", buf, testReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "## aws-nuke removal of 012345678901\n" +
+		"\n" +
+		"| Resource type | new | failed | filtered |\n" +
+		"|---|---:|---:|---:|\n" +
+		"| EC2Instance | 1 | 0 | 1 |\n" +
+		"| IAMRole | 0 | 1 | 0 |\n" +
+		"| **Total** | 1 | 1 | 1 |\n" +
+		"\n" +
+		"### Failures\n" +
+		"\n" +
+		"- `IAMRole` global - Name: admin: AccessDenied\n" +
+		"\n" +
+		"### Skipped\n" +
+		"\n" +
+		"| Reason | Resources |\n" +
+		"|---|---:|\n" +
+		"| filtered by config | 1 |\n"
+
+	if buf.String() != want {
+		t.Errorf("Wrong summary.\nWant:\n%s\nHave:\n%s", want, buf.String())
+	}
+}
+
+func TestWriteJUnitRemoval(t *testing.T) {
+	r := testReport()
+	r.Entries[0].State = report.StateFinished
+
+	buf := new(bytes.Buffer)
+	err := report.Write("junit", buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+	}
+	err = xml.Unmarshal(buf.Bytes(), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Tests != 3 || doc.Failures != 1 || doc.Skipped != 1 {
+		t.Errorf("Removed resource should pass. Have: tests=%d failures=%d skipped=%d",
+			doc.Tests, doc.Failures, doc.Skipped)
+	}
+}
+
+func TestWriteMetadata(t *testing.T) {
+	r := testReport()
+	r.Metadata = &report.Metadata{
+		BuildHash:      "0123abc",
+		ConfigSHA256:   "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		ConfigRevision: "4ee6ea0",
+		CallerARN:      "arn:aws:sts::012345678901:assumed-role/nuke/ci",
+		Flags:          []string{"--config=nuke-config.yaml", "--no-dry-run=true"},
+	}
+
+	for _, format := range []string{"html", "json", "junit", "markdown", "sarif"} {
+		buf := new(bytes.Buffer)
+		err := report.Write(format, buf, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range []string{
+			r.Metadata.ConfigSHA256,
+			r.Metadata.ConfigRevision,
+			r.Metadata.CallerARN,
+			"--no-dry-run=true",
+		} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s report does not contain %q:\n%s", format, want, buf.String())
+			}
+		}
+	}
+}
+
+func TestWriteScanErrors(t *testing.T) {
+	r := testReport()
+	r.ScanErrors = []report.ScanError{{
+		Region: "eu-west-1",
+		Type:   "EKSCluster",
+		Error:  "panic: runtime error: index out of range",
+		Panic:  true,
+	}}
+
+	for _, format := range []string{"html", "json", "junit", "markdown", "sarif"} {
+		buf := new(bytes.Buffer)
+		err := report.Write(format, buf, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range []string{"EKSCluster", "index out of range"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s report does not contain %q:\n%s", format, want, buf.String())
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	err := report.Write("junit", buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `errors="1"`) {
+		t.Errorf("JUnit report does not count the scan error:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,233 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+func init() {
+	register("sarif", WriteSARIF)
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://github.com/rebuy-de/aws-nuke"
+)
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+// sarifRules maps the entry states, which are reported as findings, to their
+// rules.
+var sarifRules = map[string]sarifRule{
+	StateNew: {
+		ID:               "nukeable-resource",
+		ShortDescription: sarifMessage{Text: "Resource is not protected by filters and would be removed."},
+	},
+	StateFailed: {
+		ID:               "removal-failed",
+		ShortDescription: sarifMessage{Text: "Removal of the resource failed."},
+	},
+	StatePending: {
+		ID:               "removal-unfinished",
+		ShortDescription: sarifMessage{Text: "Removal of the resource did not finish."},
+	},
+	StateWaiting: {
+		ID:               "removal-unfinished",
+		ShortDescription: sarifMessage{Text: "Removal of the resource did not finish."},
+	},
+}
+
+var sarifRuleOrder = []string{StateNew, StateFailed, StatePending}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifInvocation struct {
+	Arguments                  []string            `json:"arguments,omitempty"`
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+	Properties                 map[string]string   `json:"properties,omitempty"`
+}
+
+type sarifNotification struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// WriteSARIF writes the report as SARIF log. Every resource that is not
+// filtered results in a finding. Filtered resources are reported as
+// suppressed findings, so code scanning tools can show why they were kept.
+func WriteSARIF(w io.Writer, r *Report) error {
+	driver := sarifDriver{
+		Name:           "aws-nuke",
+		Version:        r.Version,
+		InformationURI: sarifToolURI,
+	}
+	for _, state := range sarifRuleOrder {
+		driver.Rules = append(driver.Rules, sarifRules[state])
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: driver},
+		Results: []sarifResult{},
+	}
+
+	if r.Metadata != nil || len(r.ScanErrors) > 0 {
+		run.Invocations = []sarifInvocation{newSARIFInvocation(r)}
+	}
+
+	for _, e := range r.Entries {
+		state := e.State
+		if state == StateFiltered {
+			state = StateNew
+		}
+
+		rule, ok := sarifRules[state]
+		if !ok {
+			continue
+		}
+
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{
+				Name:               e.Name(),
+				FullyQualifiedName: fmt.Sprintf("%s/%s/%s", r.AccountID, e.Type, e.Name()),
+				Kind:               "resource",
+			}},
+		}
+		if r.Config != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: r.Config},
+			}
+		}
+
+		result := sarifResult{
+			RuleID:     rule.ID,
+			Level:      "error",
+			Message:    sarifMessage{Text: fmt.Sprintf("%s %s: %s", e.Type, e.Name(), rule.ShortDescription.Text)},
+			Locations:  []sarifLocation{location},
+			Properties: e.Properties,
+		}
+
+		if e.State == StateFiltered {
+			result.Level = "note"
+			result.Suppressions = []sarifSuppression{{
+				Kind:          "external",
+				Justification: e.Reason,
+			}}
+		} else if e.Reason != "" {
+			result.Message.Text += " " + e.Reason
+		}
+
+		if e.EndOfLife != "" {
+			result.Message.Text += fmt.Sprintf(" (deprecated service: %s)", e.EndOfLife)
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// newSARIFInvocation returns the run metadata and the resource types, which
+// could not be listed, as tool execution notifications. The execution is
+// not successful, if any resource type could not be listed.
+func newSARIFInvocation(r *Report) sarifInvocation {
+	invocation := sarifInvocation{
+		ExecutionSuccessful: len(r.ScanErrors) == 0,
+	}
+
+	if m := r.Metadata; m != nil {
+		invocation.Arguments = m.Flags
+		invocation.Properties = map[string]string{
+			"buildHash":      m.BuildHash,
+			"configSha256":   m.ConfigSHA256,
+			"configRevision": m.ConfigRevision,
+			"callerArn":      m.CallerARN,
+		}
+	}
+
+	for _, e := range r.ScanErrors {
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+			Level:   "error",
+			Message: sarifMessage{Text: fmt.Sprintf("Listing %s in %s failed: %s", e.Type, e.Region, e.Error)},
+			Locations: []sarifLocation{{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               e.Type,
+					FullyQualifiedName: fmt.Sprintf("%s/%s/%s", r.AccountID, e.Type, e.Region),
+					Kind:               "resourceType",
+				}},
+			}},
+		})
+	}
+
+	return invocation
+}
@@ -3,6 +3,7 @@ package types_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
@@ -161,3 +162,17 @@ func TestPropertiesSetTagWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertiesSetTime(t *testing.T) {
+	date := time.Date(2023, 4, 5, 6, 7, 8, 0, time.FixedZone("CEST", 2*60*60))
+
+	have := types.NewProperties().
+		Set("Date", &date).
+		Set("Missing", (*time.Time)(nil)).
+		String()
+	want := `[Date: "2023-04-05T04:07:08Z"]`
+
+	if want != have {
+		t.Errorf("'%s' != '%s'", want, have)
+	}
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SanitizeRaw converts an API response of the AWS SDK into plain maps, slices
+// and values, which can be serialized as JSON. Fields, which are nil or
+// marked as sensitive by the SDK (eg passwords), are omitted.
+func SanitizeRaw(v interface{}) interface{} {
+	return sanitizeValue(reflect.ValueOf(v))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func sanitizeValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		result := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" || field.Tag.Get("sensitive") == "true" {
+				continue
+			}
+
+			value := sanitizeValue(v.Field(i))
+			if value != nil {
+				result[field.Name] = value
+			}
+		}
+		return result
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		result := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result = append(result, sanitizeValue(v.Index(i)))
+		}
+		return result
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		result := map[string]interface{}{}
+		for _, key := range v.MapKeys() {
+			value := sanitizeValue(v.MapIndex(key))
+			if value != nil {
+				result[fmt.Sprint(key.Interface())] = value
+			}
+		}
+		return result
+
+	case reflect.Invalid:
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// LookupRaw returns the value at the dot separated path (eg
+// "State.Name" or "Tags.0.Key") of a sanitized API response. Objects and
+// lists are returned as JSON. An empty path returns the whole response.
+func LookupRaw(raw interface{}, path string) (string, bool) {
+	current := raw
+	if path != "" {
+		for _, part := range strings.Split(path, ".") {
+			switch v := current.(type) {
+			case map[string]interface{}:
+				next, ok := v[part]
+				if !ok {
+					return "", false
+				}
+				current = next
+			case []interface{}:
+				index, err := strconv.Atoi(part)
+				if err != nil || index < 0 || index >= len(v) {
+					return "", false
+				}
+				current = v[index]
+			default:
+				return "", false
+			}
+		}
+	}
+
+	switch v := current.(type) {
+	case nil:
+		return "", false
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestLookupRaw(t *testing.T) {
+	raw := SanitizeRaw(&ec2.Instance{
+		InstanceId:   aws.String("i-01b489457a60298dd"),
+		LaunchTime:   aws.Time(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)),
+		State:        &ec2.InstanceState{Name: aws.String("running"), Code: aws.Int64(16)},
+		EbsOptimized: aws.Bool(false),
+		Tags: []*ec2.Tag{
+			{Key: aws.String("Name"), Value: aws.String("web")},
+		},
+	})
+
+	cases := []struct {
+		path  string
+		want  string
+		found bool
+	}{
+		{"InstanceId", "i-01b489457a60298dd", true},
+		{"LaunchTime", "2023-01-02T03:04:05Z", true},
+		{"State.Name", "running", true},
+		{"State.Code", "16", true},
+		{"EbsOptimized", "false", true},
+		{"Tags.0.Value", "web", true},
+		{"Tags", `[{"Key":"Name","Value":"web"}]`, true},
+		{"Tags.1.Value", "", false},
+		{"KernelId", "", false},
+		{"State.Name.Foo", "", false},
+	}
+
+	for _, tc := range cases {
+		have, found := LookupRaw(raw, tc.path)
+		if have != tc.want || found != tc.found {
+			t.Errorf("Wrong value for %s. Want: %#v, %t. Have: %#v, %t", tc.path, tc.want, tc.found, have, found)
+		}
+	}
+}
+
+func TestSanitizeRawOmitsSensitiveFields(t *testing.T) {
+	raw := SanitizeRaw(&cloudcontrolapi.ResourceDescription{
+		Identifier: aws.String("my-repository"),
+		Properties: aws.String(`{"Secret": "foo"}`),
+	})
+
+	if _, found := LookupRaw(raw, "Properties"); found {
+		t.Errorf("Sensitive field is not omitted: %#v", raw)
+	}
+
+	if value, _ := LookupRaw(raw, "Identifier"); value != "my-repository" {
+		t.Errorf("Wrong identifier: %#v", raw)
+	}
+}
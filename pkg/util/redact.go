@@ -0,0 +1,87 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/mb0/glob"
+)
+
+const Redacted = "<redacted>"
+
+// Redactor hides sensitive values before they are printed or written to
+// reports. A nil Redactor does not redact anything.
+type Redactor struct {
+	properties []string
+	patterns   []*regexp.Regexp
+}
+
+// NewRedactor creates a redactor, which hides the whole value of properties
+// whose names match one of the glob patterns in properties and all parts of
+// any value which match one of the regular expressions in patterns.
+func NewRedactor(properties, patterns []string) (*Redactor, error) {
+	r := &Redactor{
+		properties: properties,
+	}
+
+	for _, p := range properties {
+		_, err := glob.Match(p, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction property pattern '%s': %v", p, err)
+		}
+	}
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern '%s': %v", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// Enabled returns true, if the redactor hides any values.
+func (r *Redactor) Enabled() bool {
+	return r != nil && (len(r.properties) > 0 || len(r.patterns) > 0)
+}
+
+// String redacts all parts of the string which match one of the patterns.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// Properties returns a redacted copy of the properties.
+func (r *Redactor) Properties(props map[string]string) map[string]string {
+	if r == nil || props == nil {
+		return props
+	}
+
+	result := make(map[string]string, len(props))
+	for k, v := range props {
+		if r.hideProperty(k) {
+			result[k] = Redacted
+			continue
+		}
+		result[k] = r.String(v)
+	}
+	return result
+}
+
+func (r *Redactor) hideProperty(name string) bool {
+	for _, p := range r.properties {
+		match, _ := glob.Match(p, name)
+		if match {
+			return true
+		}
+	}
+	return false
+}
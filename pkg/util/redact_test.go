@@ -0,0 +1,82 @@
+package util_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/util"
+)
+
+func TestRedactor(t *testing.T) {
+	r, err := util.NewRedactor(
+		[]string{"UserData", "tag:*secret*"},
+		[]string{`(?i)password=[^;]*`, `arn:aws:secretsmanager:[^ ]+`},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have := r.Properties(map[string]string{
+		"Name":             "my-db",
+		"UserData":         "IyEvYmluL2Jhc2g=",
+		"tag:my-secret":    "hunter2",
+		"tag:Connection":   "host=db;Password=hunter2;port=5432",
+		"SecretArn":        "arn:aws:secretsmanager:eu-west-1:012345678901:secret:db",
+		"tag:SecretsOwner": "team",
+	})
+
+	want := map[string]string{
+		"Name":             "my-db",
+		"UserData":         util.Redacted,
+		"tag:my-secret":    util.Redacted,
+		"tag:Connection":   "host=db;" + util.Redacted + ";port=5432",
+		"SecretArn":        util.Redacted,
+		"tag:SecretsOwner": "team",
+	}
+
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("Wrong redaction.\n  Want: %#v\n  Have: %#v", want, have)
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	var r *util.Redactor
+
+	if r.String("password=foo") != "password=foo" {
+		t.Errorf("nil redactor must not change strings")
+	}
+
+	props := map[string]string{"UserData": "foo"}
+	if !reflect.DeepEqual(r.Properties(props), props) {
+		t.Errorf("nil redactor must not change properties")
+	}
+
+	if r.Enabled() {
+		t.Errorf("nil redactor must not be enabled")
+	}
+}
+
+func TestRedactorEnabled(t *testing.T) {
+	empty, err := util.NewRedactor(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Enabled() {
+		t.Errorf("redactor without rules must not be enabled")
+	}
+
+	r, err := util.NewRedactor([]string{"UserData"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Enabled() {
+		t.Errorf("redactor with a property must be enabled")
+	}
+}
+
+func TestInvalidRedactionPattern(t *testing.T) {
+	_, err := util.NewRedactor(nil, []string{"("})
+	if err == nil {
+		t.Errorf("expected error for invalid pattern")
+	}
+}
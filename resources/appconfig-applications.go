@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type AppConfigApplication struct {
+	svc  *appconfig.AppConfig
+	id   *string
+	name *string
+}
+
+func init() {
+	register("AppConfigApplication", ListAppConfigApplications)
+}
+
+func ListAppConfigApplications(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListApplicationsPages(&appconfig.ListApplicationsInput{},
+		func(page *appconfig.ListApplicationsOutput, lastPage bool) bool {
+			for _, application := range page.Items {
+				resources = append(resources, &AppConfigApplication{
+					svc:  svc,
+					id:   application.Id,
+					name: application.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the application. This fails until all of its environments
+// and configuration profiles are deleted.
+func (f *AppConfigApplication) Remove() error {
+	_, err := f.svc.DeleteApplication(&appconfig.DeleteApplicationInput{
+		ApplicationId: f.id,
+	})
+
+	return err
+}
+
+func (f *AppConfigApplication) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *AppConfigApplication) String() string {
+	return *f.id
+}
+
+// listAppConfigApplicationIDs returns the IDs of all applications, which are
+// needed to list their environments and configuration profiles.
+func listAppConfigApplicationIDs(svc *appconfig.AppConfig) ([]*string, error) {
+	ids := []*string{}
+
+	err := svc.ListApplicationsPages(&appconfig.ListApplicationsInput{},
+		func(page *appconfig.ListApplicationsOutput, lastPage bool) bool {
+			for _, application := range page.Items {
+				ids = append(ids, application.Id)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type AppConfigConfigurationProfile struct {
+	svc           *appconfig.AppConfig
+	applicationID *string
+	id            *string
+	name          *string
+	profileType   *string
+}
+
+func init() {
+	register("AppConfigConfigurationProfile", ListAppConfigConfigurationProfiles)
+}
+
+func ListAppConfigConfigurationProfiles(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	applicationIDs, err := listAppConfigApplicationIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, applicationID := range applicationIDs {
+		err := svc.ListConfigurationProfilesPages(&appconfig.ListConfigurationProfilesInput{
+			ApplicationId: applicationID,
+		}, func(page *appconfig.ListConfigurationProfilesOutput, lastPage bool) bool {
+			for _, profile := range page.Items {
+				resources = append(resources, &AppConfigConfigurationProfile{
+					svc:           svc,
+					applicationID: profile.ApplicationId,
+					id:            profile.Id,
+					name:          profile.Name,
+					profileType:   profile.Type,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// Remove deletes all hosted configuration versions of the profile, which
+// would block its deletion, and the profile afterwards.
+func (f *AppConfigConfigurationProfile) Remove() error {
+	versions := []*int64{}
+	err := f.svc.ListHostedConfigurationVersionsPages(&appconfig.ListHostedConfigurationVersionsInput{
+		ApplicationId:          f.applicationID,
+		ConfigurationProfileId: f.id,
+	}, func(page *appconfig.ListHostedConfigurationVersionsOutput, lastPage bool) bool {
+		for _, version := range page.Items {
+			versions = append(versions, version.VersionNumber)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		_, err := f.svc.DeleteHostedConfigurationVersion(&appconfig.DeleteHostedConfigurationVersionInput{
+			ApplicationId:          f.applicationID,
+			ConfigurationProfileId: f.id,
+			VersionNumber:          version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = f.svc.DeleteConfigurationProfile(&appconfig.DeleteConfigurationProfileInput{
+		ApplicationId:          f.applicationID,
+		ConfigurationProfileId: f.id,
+	})
+
+	return err
+}
+
+func (f *AppConfigConfigurationProfile) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ApplicationID", f.applicationID).
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("Type", f.profileType)
+}
+
+func (f *AppConfigConfigurationProfile) String() string {
+	return *f.id
+}
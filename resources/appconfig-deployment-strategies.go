@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// APPCONFIG_PREDEFINED_PREFIX is the prefix of the names of the deployment
+// strategies, which are provided by AWS.
+const APPCONFIG_PREDEFINED_PREFIX = "AppConfig."
+
+type AppConfigDeploymentStrategy struct {
+	svc  *appconfig.AppConfig
+	id   *string
+	name *string
+}
+
+func init() {
+	register("AppConfigDeploymentStrategy", ListAppConfigDeploymentStrategies)
+}
+
+func ListAppConfigDeploymentStrategies(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListDeploymentStrategiesPages(&appconfig.ListDeploymentStrategiesInput{},
+		func(page *appconfig.ListDeploymentStrategiesOutput, lastPage bool) bool {
+			for _, strategy := range page.Items {
+				resources = append(resources, &AppConfigDeploymentStrategy{
+					svc:  svc,
+					id:   strategy.Id,
+					name: strategy.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *AppConfigDeploymentStrategy) Filter() error {
+	if strings.HasPrefix(aws.StringValue(f.name), APPCONFIG_PREDEFINED_PREFIX) {
+		return fmt.Errorf("cannot delete predefined deployment strategy")
+	}
+	return nil
+}
+
+func (f *AppConfigDeploymentStrategy) Remove() error {
+	_, err := f.svc.DeleteDeploymentStrategy(&appconfig.DeleteDeploymentStrategyInput{
+		DeploymentStrategyId: f.id,
+	})
+
+	return err
+}
+
+func (f *AppConfigDeploymentStrategy) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *AppConfigDeploymentStrategy) String() string {
+	return *f.id
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type AppConfigEnvironment struct {
+	svc           *appconfig.AppConfig
+	applicationID *string
+	id            *string
+	name          *string
+	state         *string
+}
+
+func init() {
+	register("AppConfigEnvironment", ListAppConfigEnvironments)
+}
+
+func ListAppConfigEnvironments(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	applicationIDs, err := listAppConfigApplicationIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, applicationID := range applicationIDs {
+		err := svc.ListEnvironmentsPages(&appconfig.ListEnvironmentsInput{
+			ApplicationId: applicationID,
+		}, func(page *appconfig.ListEnvironmentsOutput, lastPage bool) bool {
+			for _, environment := range page.Items {
+				resources = append(resources, &AppConfigEnvironment{
+					svc:           svc,
+					applicationID: environment.ApplicationId,
+					id:            environment.Id,
+					name:          environment.Name,
+					state:         environment.State,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the environment. This fails while a deployment to it is in
+// progress.
+func (f *AppConfigEnvironment) Remove() error {
+	_, err := f.svc.DeleteEnvironment(&appconfig.DeleteEnvironmentInput{
+		ApplicationId: f.applicationID,
+		EnvironmentId: f.id,
+	})
+
+	return err
+}
+
+func (f *AppConfigEnvironment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ApplicationID", f.applicationID).
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("State", f.state)
+}
+
+func (f *AppConfigEnvironment) String() string {
+	return *f.id
+}
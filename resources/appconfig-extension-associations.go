@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type AppConfigExtensionAssociation struct {
+	svc          *appconfig.AppConfig
+	id           *string
+	extensionArn *string
+	resourceArn  *string
+}
+
+func init() {
+	register("AppConfigExtensionAssociation", ListAppConfigExtensionAssociations)
+}
+
+func ListAppConfigExtensionAssociations(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListExtensionAssociationsPages(&appconfig.ListExtensionAssociationsInput{},
+		func(page *appconfig.ListExtensionAssociationsOutput, lastPage bool) bool {
+			for _, association := range page.Items {
+				resources = append(resources, &AppConfigExtensionAssociation{
+					svc:          svc,
+					id:           association.Id,
+					extensionArn: association.ExtensionArn,
+					resourceArn:  association.ResourceArn,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *AppConfigExtensionAssociation) Remove() error {
+	_, err := f.svc.DeleteExtensionAssociation(&appconfig.DeleteExtensionAssociationInput{
+		ExtensionAssociationId: f.id,
+	})
+
+	return err
+}
+
+func (f *AppConfigExtensionAssociation) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("ExtensionArn", f.extensionArn).
+		Set("ResourceArn", f.resourceArn)
+}
+
+func (f *AppConfigExtensionAssociation) String() string {
+	return *f.id
+}
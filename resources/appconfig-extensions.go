@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type AppConfigExtension struct {
+	svc     *appconfig.AppConfig
+	id      *string
+	arn     *string
+	name    *string
+	version *int64
+}
+
+func init() {
+	register("AppConfigExtension", ListAppConfigExtensions)
+}
+
+func ListAppConfigExtensions(sess *session.Session) ([]Resource, error) {
+	svc := appconfig.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListExtensionsPages(&appconfig.ListExtensionsInput{},
+		func(page *appconfig.ListExtensionsOutput, lastPage bool) bool {
+			for _, extension := range page.Items {
+				resources = append(resources, &AppConfigExtension{
+					svc:     svc,
+					id:      extension.Id,
+					arn:     extension.Arn,
+					name:    extension.Name,
+					version: extension.VersionNumber,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Filter hides the extensions provided by AWS. Their ARNs don't contain an
+// account ID.
+func (f *AppConfigExtension) Filter() error {
+	parsed, err := arn.Parse(aws.StringValue(f.arn))
+	if err == nil && parsed.AccountID == "" {
+		return fmt.Errorf("cannot delete AWS authored extension")
+	}
+	return nil
+}
+
+// Remove deletes the listed version of the extension. This fails as long as
+// the extension is associated with a resource.
+func (f *AppConfigExtension) Remove() error {
+	_, err := f.svc.DeleteExtension(&appconfig.DeleteExtensionInput{
+		ExtensionIdentifier: f.id,
+		VersionNumber:       f.version,
+	})
+
+	return err
+}
+
+func (f *AppConfigExtension) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("VersionNumber", f.version)
+}
+
+func (f *AppConfigExtension) String() string {
+	return *f.name
+}
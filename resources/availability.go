@@ -0,0 +1,105 @@
+package resources
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// Availability corrects the endpoint data of the SDK for a service, which is
+// not actually available everywhere the SDK has endpoints for. The list is
+// maintained by hand in availability.json, keyed by the endpoints ID of the
+// service.
+type Availability struct {
+	// Unavailable are the regions and partition IDs, in which the service
+	// does not exist.
+	Unavailable []string `json:"unavailable"`
+
+	// Notice is a short explanation, why the service is unavailable.
+	Notice string `json:"notice"`
+}
+
+//go:embed availability.json
+var rawAvailability []byte
+
+var availability map[string]Availability
+
+func init() {
+	err := json.Unmarshal(rawAvailability, &availability)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded availability data: %v", err))
+	}
+}
+
+// Unavailable returns the reason, why the service of the resource type is not
+// available in the region according to availability.json. Unlike the request
+// handlers of the sessions, this avoids creating a client at all.
+func Unavailable(resourceType, region string) (string, bool) {
+	m, ok := metadata[resourceType]
+	if !ok || m.EndpointsID == "" {
+		return "", false
+	}
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		// This is the global pseudo region or a region of a custom endpoint.
+		return "", false
+	}
+
+	for _, unavailable := range availability[m.EndpointsID].Unavailable {
+		if unavailable == region || unavailable == partition.ID() {
+			return fmt.Sprintf("service '%s' is not available in region '%s': %s",
+				m.EndpointsID, region, availability[m.EndpointsID].Notice), true
+		}
+	}
+
+	return "", false
+}
+
+// MissingEndpoint returns the reason, why the service of the resource type is
+// presumably not available in the region, because the endpoint data of the
+// SDK for the partition of the region does not contain it. Since this data is
+// only as recent as the SDK, the second return value is false, if the region
+// or service might be newer than the SDK.
+func MissingEndpoint(resourceType, region string) (string, bool) {
+	m, ok := metadata[resourceType]
+	if !ok || m.EndpointsID == "" {
+		return "", false
+	}
+
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		// This is the global pseudo region or a region of a custom endpoint.
+		return "", false
+	}
+
+	_, ok = partition.Regions()[region]
+	if !ok {
+		// The region is newer than the endpoint data of the SDK.
+		return "", false
+	}
+
+	regions, ok := endpoints.RegionsForService(endpoints.DefaultPartitions(), partition.ID(), m.EndpointsID)
+	if !ok {
+		if partition.ID() == endpoints.AwsPartitionID {
+			// The service might be newer than the endpoint data of the SDK.
+			return "", false
+		}
+		return fmt.Sprintf("service '%s' has no endpoint in partition '%s'",
+			m.EndpointsID, partition.ID()), true
+	}
+
+	if len(regions) == 0 {
+		// Global services are handled by the sessions.
+		return "", false
+	}
+
+	_, ok = regions[region]
+	if !ok {
+		return fmt.Sprintf("service '%s' has no endpoint in region '%s'", m.EndpointsID, region), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,60 @@
+package resources
+
+import "testing"
+
+func TestUnavailable(t *testing.T) {
+	cases := []struct {
+		resourceType    string
+		region          string
+		unavailable     bool
+		missingEndpoint bool
+	}{
+		{"EC2Instance", "eu-west-1", false, false},
+		{"EC2Instance", "us-gov-west-1", false, false},
+		{"EC2Instance", "global", false, false},
+		{"EC2Instance", "eu-west-9", false, false},
+		{"IAMRole", "eu-west-1", false, false},
+		{"Cloud9Environment", "eu-west-1", false, false},
+		{"Cloud9Environment", "us-gov-west-1", false, true},
+		{"Cloud9Environment", "cn-north-1", false, true},
+		{"CodeStarProject", "eu-west-1", true, false},
+		{"UnknownResource", "eu-west-1", false, false},
+	}
+
+	for _, tc := range cases {
+		reason, unavailable := Unavailable(tc.resourceType, tc.region)
+		if unavailable != tc.unavailable {
+			t.Errorf("Wrong availability of %s in %s. Want: %t. Have: %t (%s)",
+				tc.resourceType, tc.region, !tc.unavailable, !unavailable, reason)
+		}
+		if unavailable && reason == "" {
+			t.Errorf("Missing reason for %s in %s.", tc.resourceType, tc.region)
+		}
+
+		reason, missing := MissingEndpoint(tc.resourceType, tc.region)
+		if missing != tc.missingEndpoint {
+			t.Errorf("Wrong endpoint of %s in %s. Want missing: %t. Have: %t (%s)",
+				tc.resourceType, tc.region, tc.missingEndpoint, missing, reason)
+		}
+		if missing && reason == "" {
+			t.Errorf("Missing reason for %s in %s.", tc.resourceType, tc.region)
+		}
+	}
+}
+
+func TestAvailabilityUsesKnownServices(t *testing.T) {
+	services := map[string]bool{}
+	for _, meta := range metadata {
+		services[meta.EndpointsID] = true
+	}
+
+	for service, a := range availability {
+		if !services[service] {
+			t.Errorf("availability data for unknown service %s", service)
+		}
+
+		if a.Notice == "" || len(a.Unavailable) == 0 {
+			t.Errorf("availability data for %s is incomplete", service)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/backup"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
@@ -12,6 +14,9 @@ type BackupVault struct {
 	arn  string
 	name string
 	tags map[string]*string
+
+	locked         bool
+	recoveryPoints int64
 }
 
 func init() {
@@ -37,6 +42,9 @@ func ListBackupVaults(sess *session.Session) ([]Resource, error) {
 			name: *out.BackupVaultName,
 			arn:  *out.BackupVaultArn,
 			tags: tagsOutput.Tags,
+
+			locked:         aws.BoolValue(out.Locked),
+			recoveryPoints: aws.Int64Value(out.NumberOfRecoveryPoints),
 		})
 	}
 
@@ -52,6 +60,13 @@ func (b *BackupVault) Properties() types.Properties {
 	return properties
 }
 
+func (b *BackupVault) CheckRemove() error {
+	if b.locked && b.recoveryPoints > 0 {
+		return fmt.Errorf("the vault lock no longer retains its %d recovery points", b.recoveryPoints)
+	}
+	return nil
+}
+
 func (b *BackupVault) Remove() error {
 	_, err := b.svc.DeleteBackupVault(&backup.DeleteBackupVaultInput{
 		BackupVaultName: &b.name,
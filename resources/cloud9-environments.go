@@ -4,11 +4,15 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloud9"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type Cloud9Environment struct {
 	svc           *cloud9.Cloud9
 	environmentID *string
+	name          *string
+	envType       *string
+	ownerArn      *string
 }
 
 func init() {
@@ -29,11 +33,23 @@ func ListCloud9Environments(sess *session.Session) ([]Resource, error) {
 			return nil, err
 		}
 
-		for _, environmentID := range resp.EnvironmentIds {
-			resources = append(resources, &Cloud9Environment{
-				svc:           svc,
-				environmentID: environmentID,
+		if len(resp.EnvironmentIds) > 0 {
+			details, err := svc.DescribeEnvironments(&cloud9.DescribeEnvironmentsInput{
+				EnvironmentIds: resp.EnvironmentIds,
 			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, environment := range details.Environments {
+				resources = append(resources, &Cloud9Environment{
+					svc:           svc,
+					environmentID: environment.Id,
+					name:          environment.Name,
+					envType:       environment.Type,
+					ownerArn:      environment.OwnerArn,
+				})
+			}
 		}
 
 		if resp.NextToken == nil {
@@ -55,6 +71,14 @@ func (f *Cloud9Environment) Remove() error {
 	return err
 }
 
+func (f *Cloud9Environment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("EnvironmentID", f.environmentID).
+		Set("Name", f.name).
+		Set("Type", f.envType).
+		Set("OwnerArn", f.ownerArn)
+}
+
 func (f *Cloud9Environment) String() string {
 	return *f.environmentID
 }
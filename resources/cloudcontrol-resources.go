@@ -0,0 +1,299 @@
+package resources
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// CLOUDCONTROL_RESOURCE_TYPE is the name of the fallback resource type. It is
+// only scanned with the enable-cloud-control-fallback feature flag.
+const CLOUDCONTROL_RESOURCE_TYPE = "CloudControlResource"
+
+// cloudControlType maps the resource type of an ARN to the CloudFormation
+// type, which is used to delete it via the Cloud Control API.
+type cloudControlType struct {
+	Type string `json:"type"`
+
+	// Identifier is "arn", if the primary identifier of the type is the ARN,
+	// or "name", if it is the last part of the ARN resource.
+	Identifier string `json:"identifier"`
+}
+
+// cloudControlTypes only contains types, which are not supported by a
+// dedicated resource type. The keys are "<arn service>:<arn resource type>".
+//
+//go:embed cloudcontrol-types.json
+var rawCloudControlTypes []byte
+
+var cloudControlTypes map[string]cloudControlType
+
+func init() {
+	err := json.Unmarshal(rawCloudControlTypes, &cloudControlTypes)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded cloud control types: %v", err))
+	}
+
+	register("CloudControlResource", ListCloudControlResources)
+}
+
+type CloudControlResource struct {
+	svc        *cloudcontrolapi.CloudControlApi
+	typeName   string
+	identifier string
+	arn        string
+	properties map[string]string
+	tags       map[string]string
+
+	// modelErr is set, if the resource model could not be parsed. The
+	// resource is filtered then, since its tags are unknown.
+	modelErr error
+}
+
+// ListCloudControlResources finds the resources with the Tagging API, so only
+// resources with at least one tag are found.
+func ListCloudControlResources(sess *session.Session) ([]Resource, error) {
+	svc := cloudcontrolapi.New(sess)
+	tagging := resourcegroupstaggingapi.New(sess)
+	resources := []Resource{}
+
+	err := tagging.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, mapping := range page.ResourceTagMappingList {
+				typeName, identifier, ok := cloudControlIdentifier(aws.StringValue(mapping.ResourceARN))
+				if !ok {
+					continue
+				}
+
+				tags := map[string]string{}
+				for _, tag := range mapping.Tags {
+					tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+				}
+
+				resources = append(resources, &CloudControlResource{
+					svc:        svc,
+					typeName:   typeName,
+					identifier: identifier,
+					arn:        aws.StringValue(mapping.ResourceARN),
+					tags:       tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// CloudControlLister returns the lister of a resource type, which lists all
+// resources of the CloudFormation type (eg AWS::ECR::PublicRepository) via the
+// Cloud Control API. The resource type has the same name as the CloudFormation
+// type. Unlike the other resource types, these are not registered, since they
+// are configured per account.
+func CloudControlLister(typeName string) ResourceLister {
+	return func(sess *session.Session) ([]Resource, error) {
+		return ListCloudControlResourcesOfType(sess, typeName)
+	}
+}
+
+func ListCloudControlResourcesOfType(sess *session.Session, typeName string) ([]Resource, error) {
+	svc := cloudcontrolapi.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListResourcesPages(&cloudcontrolapi.ListResourcesInput{
+		TypeName: aws.String(typeName),
+	}, func(page *cloudcontrolapi.ListResourcesOutput, lastPage bool) bool {
+		for _, desc := range page.ResourceDescriptions {
+			properties, tags, err := cloudControlModel(aws.StringValue(desc.Properties))
+
+			resources = append(resources, &CloudControlResource{
+				svc:        svc,
+				typeName:   typeName,
+				identifier: aws.StringValue(desc.Identifier),
+				arn:        properties["Arn"],
+				properties: properties,
+				tags:       tags,
+				modelErr:   err,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// cloudControlModel flattens the resource model into properties. Only the top
+// level values are used, except for tags, which are returned separately. It
+// fails, if the model or its tags cannot be parsed, since filters on tags
+// would not match otherwise.
+func cloudControlModel(raw string) (map[string]string, map[string]string, error) {
+	properties := map[string]string{}
+	tags := map[string]string{}
+
+	model := map[string]interface{}{}
+	err := json.Unmarshal([]byte(raw), &model)
+	if err != nil {
+		return properties, tags, fmt.Errorf("failed to parse the resource model: %v", err)
+	}
+
+	for key, value := range model {
+		if key == "Tags" {
+			err := cloudControlTags(value, tags)
+			if err != nil {
+				return properties, tags, err
+			}
+			continue
+		}
+
+		switch v := value.(type) {
+		case string, bool, float64:
+			properties[key] = fmt.Sprint(v)
+		}
+	}
+
+	return properties, tags, nil
+}
+
+// cloudControlTags adds the tags of a resource model to the map. Most types
+// use a list of Key and Value objects, but some use an object of the tags.
+func cloudControlTags(value interface{}, tags map[string]string) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+
+	case map[string]interface{}:
+		for key, value := range v {
+			tags[key] = cloudControlTagValue(value)
+		}
+		return nil
+
+	case []interface{}:
+		for _, entry := range v {
+			tag, ok := entry.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unsupported tag %v in the resource model", entry)
+			}
+			key, ok := tag["Key"].(string)
+			if !ok || key == "" {
+				return fmt.Errorf("tag %v of the resource model has no key", entry)
+			}
+			tags[key] = cloudControlTagValue(tag["Value"])
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported tags %v in the resource model", value)
+}
+
+func cloudControlTagValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// cloudControlIdentifier returns the CloudFormation type and the primary
+// identifier of the resource with the ARN. The last return value is false, if
+// the type cannot be deleted via the Cloud Control API.
+func cloudControlIdentifier(resourceARN string) (string, string, bool) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return "", "", false
+	}
+
+	parts := strings.FieldsFunc(parsed.Resource, func(r rune) bool {
+		return r == '/' || r == ':'
+	})
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	t, ok := cloudControlTypes[parsed.Service+":"+parts[0]]
+	if !ok {
+		return "", "", false
+	}
+
+	switch t.Identifier {
+	case "arn":
+		return t.Type, resourceARN, true
+	case "name":
+		return t.Type, parts[len(parts)-1], true
+	}
+
+	return "", "", false
+}
+
+// Filter checks the resource via the Cloud Control API, since the Tagging API
+// still returns resources for a while after their deletion. Resources, which
+// cannot be checked, are filtered as well, so they are never removed without
+// knowing their tags.
+func (r *CloudControlResource) Filter() error {
+	if r.modelErr != nil {
+		return r.modelErr
+	}
+
+	_, err := r.svc.GetResource(&cloudcontrolapi.GetResourceInput{
+		TypeName:   aws.String(r.typeName),
+		Identifier: aws.String(r.identifier),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudcontrolapi.ErrCodeResourceNotFoundException {
+		return fmt.Errorf("already deleted")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check the resource: %v", err)
+	}
+
+	return nil
+}
+
+// Remove starts the deletion. The deletion is asynchronous, so it only
+// finishes, once the resource is no longer found.
+func (r *CloudControlResource) Remove() error {
+	_, err := r.svc.DeleteResource(&cloudcontrolapi.DeleteResourceInput{
+		TypeName:   aws.String(r.typeName),
+		Identifier: aws.String(r.identifier),
+	})
+
+	return err
+}
+
+func (r *CloudControlResource) Properties() types.Properties {
+	properties := types.NewProperties()
+	for key, value := range r.properties {
+		properties.Set(key, value)
+	}
+
+	properties.
+		Set("Type", r.typeName).
+		Set("Identifier", r.identifier)
+	if r.arn != "" {
+		properties.Set("ARN", r.arn)
+	}
+
+	for key, value := range r.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+
+	return properties
+}
+
+func (r *CloudControlResource) String() string {
+	if r.arn != "" {
+		return r.arn
+	}
+	return r.identifier
+}
@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloudControlIdentifier(t *testing.T) {
+	cases := []struct {
+		arn        string
+		typeName   string
+		identifier string
+		ok         bool
+	}{
+		{
+			arn:        "arn:aws:scheduler:eu-west-1:012345678901:schedule-group/nightly",
+			typeName:   "AWS::Scheduler::ScheduleGroup",
+			identifier: "nightly",
+			ok:         true,
+		},
+		{
+			arn:        "arn:aws:synthetics:eu-west-1:012345678901:canary:homepage",
+			typeName:   "AWS::Synthetics::Canary",
+			identifier: "homepage",
+			ok:         true,
+		},
+		{
+			arn:        "arn:aws:xray:eu-west-1:012345678901:group/api/ABCDEF",
+			typeName:   "AWS::XRay::Group",
+			identifier: "arn:aws:xray:eu-west-1:012345678901:group/api/ABCDEF",
+			ok:         true,
+		},
+		{
+			arn: "arn:aws:ec2:eu-west-1:012345678901:instance/i-01b489457a60298dd",
+		},
+		{
+			arn: "not-an-arn",
+		},
+	}
+
+	for _, tc := range cases {
+		typeName, identifier, ok := cloudControlIdentifier(tc.arn)
+		if typeName != tc.typeName || identifier != tc.identifier || ok != tc.ok {
+			t.Errorf("Wrong result for %s. Want: %s, %s, %t. Have: %s, %s, %t",
+				tc.arn, tc.typeName, tc.identifier, tc.ok, typeName, identifier, ok)
+		}
+	}
+}
+
+func TestCloudControlModel(t *testing.T) {
+	properties, tags, err := cloudControlModel(`{
+		"RepositoryName": "foo",
+		"Arn": "arn:aws:ecr-public::012345678901:repository/foo",
+		"ImageScanningEnabled": true,
+		"RepositoryCatalogData": {"AboutText": "bar"},
+		"Tags": [{"Key": "owner", "Value": "jdoe"}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantProperties := map[string]string{
+		"RepositoryName":       "foo",
+		"Arn":                  "arn:aws:ecr-public::012345678901:repository/foo",
+		"ImageScanningEnabled": "true",
+	}
+	if !reflect.DeepEqual(wantProperties, properties) {
+		t.Errorf("Wrong properties. Want: %#v. Have: %#v", wantProperties, properties)
+	}
+
+	wantTags := map[string]string{"owner": "jdoe"}
+	if !reflect.DeepEqual(wantTags, tags) {
+		t.Errorf("Wrong tags. Want: %#v. Have: %#v", wantTags, tags)
+	}
+}
+
+func TestCloudControlModelTags(t *testing.T) {
+	cases := []struct {
+		raw     string
+		tags    map[string]string
+		wantErr bool
+	}{
+		{
+			raw:  `{"Tags": [{"Key": "owner", "Value": "jdoe"}, {"Key": "empty"}]}`,
+			tags: map[string]string{"owner": "jdoe", "empty": ""},
+		},
+		{
+			raw:  `{"Tags": {"owner": "jdoe", "environment": "prod"}}`,
+			tags: map[string]string{"owner": "jdoe", "environment": "prod"},
+		},
+		{
+			raw:  `{"Name": "foo"}`,
+			tags: map[string]string{},
+		},
+		{
+			raw:     `{"Tags": "owner=jdoe"}`,
+			wantErr: true,
+		},
+		{
+			raw:     `{"Tags": ["owner"]}`,
+			wantErr: true,
+		},
+		{
+			raw:     `{"Tags": [{"Value": "jdoe"}]}`,
+			wantErr: true,
+		},
+		{
+			raw:     `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, tags, err := cloudControlModel(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Expected an error for %s.", tc.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", tc.raw, err)
+		}
+		if !reflect.DeepEqual(tc.tags, tags) {
+			t.Errorf("Wrong tags for %s. Want: %#v. Have: %#v", tc.raw, tc.tags, tags)
+		}
+	}
+}
+
+func TestCloudControlResourceFilterModelError(t *testing.T) {
+	_, _, err := cloudControlModel(`{"Tags": "owner=jdoe"}`)
+	r := &CloudControlResource{typeName: "AWS::XRay::Group", identifier: "api", modelErr: err}
+
+	if r.Filter() == nil {
+		t.Errorf("Resources with an unparsable model must be filtered.")
+	}
+}
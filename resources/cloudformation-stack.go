@@ -2,6 +2,7 @@ package resources
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/mb0/glob"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 	"github.com/sirupsen/logrus"
@@ -16,6 +18,10 @@ import (
 
 const CLOUDFORMATION_MAX_DELETE_ATTEMPT = 3
 
+// CLOUDFORMATION_STACKSET_PREFIX is the name prefix of stacks, which are
+// deployed as instances of a StackSet.
+const CLOUDFORMATION_STACKSET_PREFIX = "StackSet-"
+
 func init() {
 	register("CloudFormationStack", ListCloudFormationStacks)
 }
@@ -46,14 +52,116 @@ func ListCloudFormationStacks(sess *session.Session) ([]Resource, error) {
 		params.NextToken = resp.NextToken
 	}
 
+	markExternalStackSetInstances(svc, resources)
+
 	return resources, nil
 }
 
+// markExternalStackSetInstances detects stacks, which were deployed by a
+// StackSet that is not administered from the current account and region.
+// Deleting them locally would cause a drift in the administrator account,
+// therefore they are only reported. Since StackSets are regional, this also
+// applies to StackSets of the current account in other regions. If the
+// StackSets cannot be listed, no stack is marked.
+func markExternalStackSetInstances(svc cloudformationiface.CloudFormationAPI, resources []Resource) {
+	var local map[string]bool
+
+	for _, r := range resources {
+		cfs := r.(*CloudFormationStack)
+		if !strings.HasPrefix(aws.StringValue(cfs.stack.StackName), CLOUDFORMATION_STACKSET_PREFIX) {
+			continue
+		}
+
+		if local == nil {
+			var err error
+			local, err = localStackSetInstances(svc)
+			if err != nil {
+				logrus.Warnf("failed to list the StackSets, stacks of other StackSets are not detected: %v", err)
+				return
+			}
+		}
+
+		cfs.externalStackSet = !local[aws.StringValue(cfs.stack.StackId)]
+	}
+}
+
+// localStackSetInstances returns the IDs of all stacks, which belong to
+// StackSets of the current account and region.
+func localStackSetInstances(svc cloudformationiface.CloudFormationAPI) (map[string]bool, error) {
+	stackIDs := map[string]bool{}
+
+	var stackSets []*string
+	err := svc.ListStackSetsPages(&cloudformation.ListStackSetsInput{
+		Status: aws.String(cloudformation.StackSetStatusActive),
+	}, func(page *cloudformation.ListStackSetsOutput, lastPage bool) bool {
+		for _, summary := range page.Summaries {
+			stackSets = append(stackSets, summary.StackSetName)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range stackSets {
+		err := svc.ListStackInstancesPages(&cloudformation.ListStackInstancesInput{
+			StackSetName: name,
+		}, func(page *cloudformation.ListStackInstancesOutput, lastPage bool) bool {
+			for _, summary := range page.Summaries {
+				if summary.StackId != nil {
+					stackIDs[*summary.StackId] = true
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stackIDs, nil
+}
+
 type CloudFormationStack struct {
 	svc               cloudformationiface.CloudFormationAPI
 	stack             *cloudformation.Stack
 	maxDeleteAttempts int
 	featureFlags      config.FeatureFlags
+	externalStackSet  bool
+}
+
+func (cfs *CloudFormationStack) Filter() error {
+	if cfs.stack.RootId != nil {
+		// Nested stacks can only be deleted via their root stack.
+		return fmt.Errorf("nested stack is deleted with its root stack")
+	}
+	if cfs.externalStackSet {
+		return fmt.Errorf("stack is managed by a StackSet of another account or region")
+	}
+	if aws.BoolValue(cfs.stack.EnableTerminationProtection) && !cfs.canDisableTerminationProtection() {
+		// The deletion would fail in every iteration.
+		return fmt.Errorf("termination protection is enabled")
+	}
+	return nil
+}
+
+func (cfs *CloudFormationStack) canDisableTerminationProtection() bool {
+	if !cfs.featureFlags.DisableDeletionProtection.CloudformationStack {
+		return false
+	}
+
+	for _, pattern := range cfs.featureFlags.DeletionProtectionExceptions.CloudformationStack {
+		match, err := glob.Match(pattern, aws.StringValue(cfs.stack.StackName))
+		if err != nil {
+			logrus.Warnf("invalid deletion protection exception '%s': %v", pattern, err)
+			return false
+		}
+		if match {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (cfs *CloudFormationStack) FeatureFlags(ff config.FeatureFlags) {
@@ -67,7 +175,7 @@ func (cfs *CloudFormationStack) Remove() error {
 func (cfs *CloudFormationStack) removeWithAttempts(attempt int) error {
 	if err := cfs.doRemove(); err != nil {
 		logrus.Errorf("CloudFormationStack stackName=%s attempt=%d maxAttempts=%d delete failed: %s", *cfs.stack.StackName, attempt, cfs.maxDeleteAttempts, err.Error())
-		if cfs.featureFlags.DisableDeletionProtection.CloudformationStack {
+		if cfs.canDisableTerminationProtection() {
 			awsErr, ok := err.(awserr.Error)
 			if ok && awsErr.Code() == "ValidationError" &&
 				awsErr.Message() == "Stack ["+*cfs.stack.StackName+"] cannot be deleted while TerminationProtection is enabled" {
@@ -114,6 +222,11 @@ func (cfs *CloudFormationStack) doRemove() error {
 			StackName: cfs.stack.StackName,
 		})
 	} else if *stack.StackStatus == cloudformation.StackStatusDeleteFailed {
+		if !cfs.featureFlags.RetainFailedStackResources {
+			return fmt.Errorf("stack is in %s, enable the feature flag retain-failed-stack-resources "+
+				"to delete it without the resources which could not be deleted", cloudformation.StackStatusDeleteFailed)
+		}
+
 		logrus.Infof("CloudFormationStack stackName=%s delete failed. Attempting to retain and delete stack", *cfs.stack.StackName)
 		// This means the CFS has undeleteable resources.
 		// In order to move on with nuking, we retain them in the deletion.
@@ -129,6 +242,13 @@ func (cfs *CloudFormationStack) doRemove() error {
 		for _, r := range retainableResources.StackResourceSummaries {
 			if *r.ResourceStatus != cloudformation.ResourceStatusDeleteComplete {
 				retain = append(retain, r.LogicalResourceId)
+
+				// The retained resources are removed by their own resource
+				// types, if aws-nuke supports them. Otherwise they are left
+				// behind, so they get logged.
+				logrus.Warnf("CloudFormationStack stackName=%s retaining logicalId=%s type=%s physicalId=%s",
+					*cfs.stack.StackName, aws.StringValue(r.LogicalResourceId),
+					aws.StringValue(r.ResourceType), aws.StringValue(r.PhysicalResourceId))
 			}
 		}
 
@@ -181,6 +301,10 @@ func (cfs *CloudFormationStack) waitForStackToStabalize(currentStatus string) er
 	}
 }
 
+func (cfs *CloudFormationStack) Raw() interface{} {
+	return cfs.stack
+}
+
 func (cfs *CloudFormationStack) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("Name", cfs.stack.StackName)
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/golang/mock/gomock"
 	"github.com/rebuy-de/aws-nuke/mocks/mock_cloudformationiface"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,6 +75,7 @@ func TestCloudformationStack_Remove_DeleteFailed(t *testing.T) {
 			StackName: aws.String("foobar"),
 		},
 	}
+	stack.featureFlags.RetainFailedStackResources = true
 
 	gomock.InOrder(
 		mockCloudformation.EXPECT().DescribeStacks(gomock.Eq(&cloudformation.DescribeStacksInput{
@@ -114,6 +116,36 @@ func TestCloudformationStack_Remove_DeleteFailed(t *testing.T) {
 	a.Nil(err)
 }
 
+func TestCloudformationStack_Remove_DeleteFailedWithoutRetain(t *testing.T) {
+	a := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCloudformation := mock_cloudformationiface.NewMockCloudFormationAPI(ctrl)
+
+	stack := CloudFormationStack{
+		svc: mockCloudformation,
+		stack: &cloudformation.Stack{
+			StackName: aws.String("foobar"),
+		},
+	}
+
+	mockCloudformation.EXPECT().DescribeStacks(gomock.Eq(&cloudformation.DescribeStacksInput{
+		StackName: aws.String("foobar"),
+	})).Return(&cloudformation.DescribeStacksOutput{
+		Stacks: []*cloudformation.Stack{
+			{
+				StackStatus: aws.String(cloudformation.StackStatusDeleteFailed),
+			},
+		},
+	}, nil)
+
+	// Neither the resources are listed nor is the stack deleted again.
+	err := stack.doRemove()
+	a.EqualError(err, "stack is in DELETE_FAILED, enable the feature flag retain-failed-stack-resources "+
+		"to delete it without the resources which could not be deleted")
+}
+
 // if the stack is currently in delete in progress
 func TestCloudformationStack_Remove_DeleteInProgress(t *testing.T) {
 	a := assert.New(t)
@@ -304,3 +336,132 @@ func TestCloudformationStack_Remove_Stack_UpdateInProgress(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudformationStack_MarkExternalStackSetInstances(t *testing.T) {
+	a := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCloudformation := mock_cloudformationiface.NewMockCloudFormationAPI(ctrl)
+
+	newStack := func(name, id string) *CloudFormationStack {
+		return &CloudFormationStack{
+			svc: mockCloudformation,
+			stack: &cloudformation.Stack{
+				StackName: aws.String(name),
+				StackId:   aws.String(id),
+			},
+		}
+	}
+
+	plain := newStack("foobar", "id-plain")
+	local := newStack("StackSet-local-1234", "id-local")
+	external := newStack("StackSet-external-5678", "id-external")
+
+	mockCloudformation.EXPECT().ListStackSetsPages(gomock.Eq(&cloudformation.ListStackSetsInput{
+		Status: aws.String(cloudformation.StackSetStatusActive),
+	}), gomock.Any()).DoAndReturn(func(input *cloudformation.ListStackSetsInput, fn func(*cloudformation.ListStackSetsOutput, bool) bool) error {
+		fn(&cloudformation.ListStackSetsOutput{
+			Summaries: []*cloudformation.StackSetSummary{
+				{StackSetName: aws.String("local")},
+			},
+		}, true)
+		return nil
+	})
+
+	mockCloudformation.EXPECT().ListStackInstancesPages(gomock.Eq(&cloudformation.ListStackInstancesInput{
+		StackSetName: aws.String("local"),
+	}), gomock.Any()).DoAndReturn(func(input *cloudformation.ListStackInstancesInput, fn func(*cloudformation.ListStackInstancesOutput, bool) bool) error {
+		fn(&cloudformation.ListStackInstancesOutput{
+			Summaries: []*cloudformation.StackInstanceSummary{
+				{StackId: aws.String("id-local")},
+			},
+		}, true)
+		return nil
+	})
+
+	markExternalStackSetInstances(mockCloudformation, []Resource{plain, local, external})
+
+	a.Nil(plain.Filter())
+	a.Nil(local.Filter())
+	a.EqualError(external.Filter(), "stack is managed by a StackSet of another account or region")
+}
+
+func TestCloudformationStack_MarkExternalStackSetInstances_AccessDenied(t *testing.T) {
+	a := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCloudformation := mock_cloudformationiface.NewMockCloudFormationAPI(ctrl)
+
+	stack := &CloudFormationStack{
+		svc: mockCloudformation,
+		stack: &cloudformation.Stack{
+			StackName: aws.String("StackSet-external-5678"),
+			StackId:   aws.String("id-external"),
+		},
+	}
+
+	mockCloudformation.EXPECT().ListStackSetsPages(gomock.Any(), gomock.Any()).
+		Return(awserr.New("AccessDenied", "not authorized", nil))
+
+	markExternalStackSetInstances(mockCloudformation, []Resource{stack})
+
+	a.Nil(stack.Filter())
+}
+
+func TestCloudformationStack_Filter_TerminationProtection(t *testing.T) {
+	a := assert.New(t)
+
+	ffDisabled := config.FeatureFlags{}
+	ffEnabled := config.FeatureFlags{}
+	ffEnabled.DisableDeletionProtection.CloudformationStack = true
+	ffEnabled.DeletionProtectionExceptions.CloudformationStack = []string{"prod-*"}
+
+	cases := []struct {
+		name      string
+		protected bool
+		flags     config.FeatureFlags
+		filtered  bool
+	}{
+		{name: "foobar", protected: false, flags: ffDisabled, filtered: false},
+		{name: "foobar", protected: true, flags: ffDisabled, filtered: true},
+		{name: "foobar", protected: true, flags: ffEnabled, filtered: false},
+		{name: "prod-foobar", protected: true, flags: ffEnabled, filtered: true},
+	}
+
+	for _, tc := range cases {
+		stack := CloudFormationStack{
+			stack: &cloudformation.Stack{
+				StackName:                   aws.String(tc.name),
+				EnableTerminationProtection: aws.Bool(tc.protected),
+			},
+		}
+		stack.FeatureFlags(tc.flags)
+
+		err := stack.Filter()
+		a.Equal(tc.filtered, err != nil, "stack=%s protected=%t", tc.name, tc.protected)
+	}
+}
+
+func TestCloudformationStack_Filter_NestedStack(t *testing.T) {
+	a := assert.New(t)
+
+	root := CloudFormationStack{
+		stack: &cloudformation.Stack{
+			StackName: aws.String("root"),
+			StackId:   aws.String("id-root"),
+		},
+	}
+	nested := CloudFormationStack{
+		stack: &cloudformation.Stack{
+			StackName: aws.String("root-child-1234"),
+			StackId:   aws.String("id-child"),
+			ParentId:  aws.String("id-root"),
+			RootId:    aws.String("id-root"),
+		},
+	}
+
+	a.Nil(root.Filter())
+	a.EqualError(nested.Filter(), "nested stack is deleted with its root stack")
+}
@@ -109,7 +109,6 @@ func (cfs *CloudFormationStackSet) deleteStackInstances(accountId string, region
 	regionsInput := make([]*string, len(regions))
 	for i, region := range regions {
 		regionsInput[i] = aws.String(region)
-		fmt.Printf("region=%s i=%d\n", region, i)
 	}
 	result, err := cfs.svc.DeleteStackInstances(&cloudformation.DeleteStackInstancesInput{
 		StackSetName: cfs.stackSetSummary.StackSetName,
@@ -118,11 +117,6 @@ func (cfs *CloudFormationStackSet) deleteStackInstances(accountId string, region
 		RetainStacks: aws.Bool(true), //this will remove the stack set instance from the stackset, but will leave the stack in the account/region it was deployed to
 	})
 
-	fmt.Printf("got result=%v err=%v\n", result, err)
-
-	if result == nil {
-		return fmt.Errorf("got null result")
-	}
 	if err != nil {
 		return err
 	}
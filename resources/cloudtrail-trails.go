@@ -3,6 +3,7 @@ package resources
 import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 func init() {
@@ -19,8 +20,10 @@ func ListCloudTrailTrails(sess *session.Session) ([]Resource, error) {
 	resources := make([]Resource, 0)
 	for _, trail := range resp.TrailList {
 		resources = append(resources, &CloudTrailTrail{
-			svc:  svc,
-			name: trail.Name,
+			svc:                 svc,
+			name:                trail.Name,
+			homeRegion:          trail.HomeRegion,
+			isOrganizationTrail: trail.IsOrganizationTrail,
 		})
 
 	}
@@ -28,8 +31,10 @@ func ListCloudTrailTrails(sess *session.Session) ([]Resource, error) {
 }
 
 type CloudTrailTrail struct {
-	svc  *cloudtrail.CloudTrail
-	name *string
+	svc                 *cloudtrail.CloudTrail
+	name                *string
+	homeRegion          *string
+	isOrganizationTrail *bool
 }
 
 func (trail *CloudTrailTrail) Remove() error {
@@ -39,6 +44,13 @@ func (trail *CloudTrailTrail) Remove() error {
 	return err
 }
 
+func (trail *CloudTrailTrail) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", trail.name).
+		Set("HomeRegion", trail.homeRegion).
+		Set("IsOrganizationTrail", trail.isOrganizationTrail)
+}
+
 func (trail *CloudTrailTrail) String() string {
 	return *trail.name
 }
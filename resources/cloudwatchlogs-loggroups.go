@@ -4,11 +4,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type CloudWatchLogsLogGroup struct {
 	svc          *cloudwatchlogs.CloudWatchLogs
 	logGroupName *string
+	lazy         types.Properties
 }
 
 func init() {
@@ -46,6 +48,20 @@ func ListCloudWatchLogsLogGroups(sess *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+// isCloudWatchLogsLogGroupEmpty returns whether the log group contains no log
+// streams. It returns nil, if this cannot be determined.
+func isCloudWatchLogsLogGroupEmpty(svc *cloudwatchlogs.CloudWatchLogs, name *string) *bool {
+	resp, err := svc.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: name,
+		Limit:        aws.Int64(1),
+	})
+	if err != nil {
+		return nil
+	}
+
+	return aws.Bool(len(resp.LogStreams) == 0)
+}
+
 func (f *CloudWatchLogsLogGroup) Remove() error {
 
 	_, err := f.svc.DeleteLogGroup(&cloudwatchlogs.DeleteLogGroupInput{
@@ -55,6 +71,14 @@ func (f *CloudWatchLogsLogGroup) Remove() error {
 	return err
 }
 
+func (f *CloudWatchLogsLogGroup) LazyProperty(key string) (string, error) {
+	if f.lazy == nil {
+		f.lazy = types.NewProperties().
+			Set("Empty", isCloudWatchLogsLogGroupEmpty(f.svc, f.logGroupName))
+	}
+	return f.lazy.Get(key), nil
+}
+
 func (f *CloudWatchLogsLogGroup) String() string {
 	return *f.logGroupName
 }
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codestarconnections"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type CodeStarConnectionsConnection struct {
+	svc          *codestarconnections.CodeStarConnections
+	arn          *string
+	name         *string
+	providerType *string
+	status       *string
+}
+
+func init() {
+	register("CodeStarConnectionsConnection", ListCodeStarConnectionsConnections)
+}
+
+func ListCodeStarConnectionsConnections(sess *session.Session) ([]Resource, error) {
+	svc := codestarconnections.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListConnectionsPages(&codestarconnections.ListConnectionsInput{},
+		func(page *codestarconnections.ListConnectionsOutput, lastPage bool) bool {
+			for _, connection := range page.Connections {
+				resources = append(resources, &CodeStarConnectionsConnection{
+					svc:          svc,
+					arn:          connection.ConnectionArn,
+					name:         connection.ConnectionName,
+					providerType: connection.ProviderType,
+					status:       connection.ConnectionStatus,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *CodeStarConnectionsConnection) Remove() error {
+	_, err := f.svc.DeleteConnection(&codestarconnections.DeleteConnectionInput{
+		ConnectionArn: f.arn,
+	})
+
+	return err
+}
+
+func (f *CodeStarConnectionsConnection) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ARN", f.arn).
+		Set("Name", f.name).
+		Set("ProviderType", f.providerType).
+		Set("Status", f.status)
+}
+
+func (f *CodeStarConnectionsConnection) String() string {
+	return *f.arn
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codestarconnections"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type CodeStarConnectionsHost struct {
+	svc          *codestarconnections.CodeStarConnections
+	arn          *string
+	name         *string
+	providerType *string
+	status       *string
+}
+
+func init() {
+	register("CodeStarConnectionsHost", ListCodeStarConnectionsHosts)
+}
+
+func ListCodeStarConnectionsHosts(sess *session.Session) ([]Resource, error) {
+	svc := codestarconnections.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListHostsPages(&codestarconnections.ListHostsInput{},
+		func(page *codestarconnections.ListHostsOutput, lastPage bool) bool {
+			for _, host := range page.Hosts {
+				resources = append(resources, &CodeStarConnectionsHost{
+					svc:          svc,
+					arn:          host.HostArn,
+					name:         host.Name,
+					providerType: host.ProviderType,
+					status:       host.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the host. This fails until all connections of the host are
+// deleted.
+func (f *CodeStarConnectionsHost) Remove() error {
+	_, err := f.svc.DeleteHost(&codestarconnections.DeleteHostInput{
+		HostArn: f.arn,
+	})
+
+	return err
+}
+
+func (f *CodeStarConnectionsHost) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ARN", f.arn).
+		Set("Name", f.name).
+		Set("ProviderType", f.providerType).
+		Set("Status", f.status)
+}
+
+func (f *CodeStarConnectionsHost) String() string {
+	return *f.arn
+}
@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/deadline"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DeadlineFarm struct {
+	svc         *deadline.Deadline
+	farmID      *string
+	displayName *string
+}
+
+func init() {
+	register("DeadlineFarm", ListDeadlineFarms)
+}
+
+func ListDeadlineFarms(sess *session.Session) ([]Resource, error) {
+	svc := deadline.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListFarmsPages(&deadline.ListFarmsInput{},
+		func(page *deadline.ListFarmsOutput, lastPage bool) bool {
+			for _, farm := range page.Farms {
+				resources = append(resources, &DeadlineFarm{
+					svc:         svc,
+					farmID:      farm.FarmId,
+					displayName: farm.DisplayName,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the farm. This fails until its fleets, queues and storage
+// profiles are deleted.
+func (f *DeadlineFarm) Remove() error {
+	_, err := f.svc.DeleteFarm(&deadline.DeleteFarmInput{
+		FarmId: f.farmID,
+	})
+
+	return err
+}
+
+func (f *DeadlineFarm) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FarmID", f.farmID).
+		Set("DisplayName", f.displayName)
+}
+
+func (f *DeadlineFarm) String() string {
+	return *f.farmID
+}
+
+// listDeadlineFarmIDs returns the IDs of all farms, which are needed to list
+// the resources inside of them.
+func listDeadlineFarmIDs(svc *deadline.Deadline) ([]*string, error) {
+	ids := []*string{}
+
+	err := svc.ListFarmsPages(&deadline.ListFarmsInput{},
+		func(page *deadline.ListFarmsOutput, lastPage bool) bool {
+			for _, farm := range page.Farms {
+				ids = append(ids, farm.FarmId)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
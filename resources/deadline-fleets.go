@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/deadline"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DeadlineFleet struct {
+	svc         *deadline.Deadline
+	farmID      *string
+	fleetID     *string
+	displayName *string
+	status      *string
+}
+
+func init() {
+	register("DeadlineFleet", ListDeadlineFleets)
+}
+
+func ListDeadlineFleets(sess *session.Session) ([]Resource, error) {
+	svc := deadline.New(sess)
+	resources := []Resource{}
+
+	farmIDs, err := listDeadlineFarmIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, farmID := range farmIDs {
+		err := svc.ListFleetsPages(&deadline.ListFleetsInput{
+			FarmId: farmID,
+		}, func(page *deadline.ListFleetsOutput, lastPage bool) bool {
+			for _, fleet := range page.Fleets {
+				resources = append(resources, &DeadlineFleet{
+					svc:         svc,
+					farmID:      fleet.FarmId,
+					fleetID:     fleet.FleetId,
+					displayName: fleet.DisplayName,
+					status:      fleet.Status,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the fleet and terminates its workers. This fails as long as
+// the fleet is associated with a queue.
+func (f *DeadlineFleet) Remove() error {
+	_, err := f.svc.DeleteFleet(&deadline.DeleteFleetInput{
+		FarmId:  f.farmID,
+		FleetId: f.fleetID,
+	})
+
+	return err
+}
+
+func (f *DeadlineFleet) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FarmID", f.farmID).
+		Set("FleetID", f.fleetID).
+		Set("DisplayName", f.displayName).
+		Set("Status", f.status)
+}
+
+func (f *DeadlineFleet) String() string {
+	return *f.fleetID
+}
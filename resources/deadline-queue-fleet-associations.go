@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/deadline"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DeadlineQueueFleetAssociation struct {
+	svc     *deadline.Deadline
+	farmID  *string
+	queueID *string
+	fleetID *string
+	status  *string
+}
+
+func init() {
+	register("DeadlineQueueFleetAssociation", ListDeadlineQueueFleetAssociations)
+}
+
+func ListDeadlineQueueFleetAssociations(sess *session.Session) ([]Resource, error) {
+	svc := deadline.New(sess)
+	resources := []Resource{}
+
+	farmIDs, err := listDeadlineFarmIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, farmID := range farmIDs {
+		err := svc.ListQueueFleetAssociationsPages(&deadline.ListQueueFleetAssociationsInput{
+			FarmId: farmID,
+		}, func(page *deadline.ListQueueFleetAssociationsOutput, lastPage bool) bool {
+			for _, association := range page.QueueFleetAssociations {
+				resources = append(resources, &DeadlineQueueFleetAssociation{
+					svc:     svc,
+					farmID:  farmID,
+					queueID: association.QueueId,
+					fleetID: association.FleetId,
+					status:  association.Status,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// Remove stops the scheduling of tasks and cancels the running ones. The
+// association can only be deleted after it is stopped, so the removal is
+// retried until then.
+func (f *DeadlineQueueFleetAssociation) Remove() error {
+	switch aws.StringValue(f.status) {
+	case deadline.QueueFleetAssociationStatusStopped, deadline.QueueFleetAssociationStatusStopSchedulingAndCancelTasks:
+	default:
+		_, err := f.svc.UpdateQueueFleetAssociation(&deadline.UpdateQueueFleetAssociationInput{
+			FarmId:  f.farmID,
+			QueueId: f.queueID,
+			FleetId: f.fleetID,
+			Status:  aws.String(deadline.UpdateQueueFleetAssociationStatusStopSchedulingAndCancelTasks),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := f.svc.GetQueueFleetAssociation(&deadline.GetQueueFleetAssociationInput{
+		FarmId:  f.farmID,
+		QueueId: f.queueID,
+		FleetId: f.fleetID,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.status = resp.Status
+	if aws.StringValue(f.status) != deadline.QueueFleetAssociationStatusStopped {
+		return fmt.Errorf("association is still stopping")
+	}
+
+	_, err = f.svc.DeleteQueueFleetAssociation(&deadline.DeleteQueueFleetAssociationInput{
+		FarmId:  f.farmID,
+		QueueId: f.queueID,
+		FleetId: f.fleetID,
+	})
+
+	return err
+}
+
+func (f *DeadlineQueueFleetAssociation) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FarmID", f.farmID).
+		Set("QueueID", f.queueID).
+		Set("FleetID", f.fleetID).
+		Set("Status", f.status)
+}
+
+func (f *DeadlineQueueFleetAssociation) String() string {
+	return fmt.Sprintf("%s -> %s", *f.queueID, *f.fleetID)
+}
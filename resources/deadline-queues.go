@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/deadline"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DeadlineQueue struct {
+	svc         *deadline.Deadline
+	farmID      *string
+	queueID     *string
+	displayName *string
+	status      *string
+}
+
+func init() {
+	register("DeadlineQueue", ListDeadlineQueues)
+}
+
+func ListDeadlineQueues(sess *session.Session) ([]Resource, error) {
+	svc := deadline.New(sess)
+	resources := []Resource{}
+
+	farmIDs, err := listDeadlineFarmIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, farmID := range farmIDs {
+		err := svc.ListQueuesPages(&deadline.ListQueuesInput{
+			FarmId: farmID,
+		}, func(page *deadline.ListQueuesOutput, lastPage bool) bool {
+			for _, queue := range page.Queues {
+				resources = append(resources, &DeadlineQueue{
+					svc:         svc,
+					farmID:      queue.FarmId,
+					queueID:     queue.QueueId,
+					displayName: queue.DisplayName,
+					status:      queue.Status,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the queue including its jobs. This fails as long as the
+// queue is associated with a fleet.
+func (f *DeadlineQueue) Remove() error {
+	_, err := f.svc.DeleteQueue(&deadline.DeleteQueueInput{
+		FarmId:  f.farmID,
+		QueueId: f.queueID,
+	})
+
+	return err
+}
+
+func (f *DeadlineQueue) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FarmID", f.farmID).
+		Set("QueueID", f.queueID).
+		Set("DisplayName", f.displayName).
+		Set("Status", f.status)
+}
+
+func (f *DeadlineQueue) String() string {
+	return *f.queueID
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/deadline"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DeadlineStorageProfile struct {
+	svc              *deadline.Deadline
+	farmID           *string
+	storageProfileID *string
+	displayName      *string
+	osFamily         *string
+}
+
+func init() {
+	register("DeadlineStorageProfile", ListDeadlineStorageProfiles)
+}
+
+func ListDeadlineStorageProfiles(sess *session.Session) ([]Resource, error) {
+	svc := deadline.New(sess)
+	resources := []Resource{}
+
+	farmIDs, err := listDeadlineFarmIDs(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, farmID := range farmIDs {
+		err := svc.ListStorageProfilesPages(&deadline.ListStorageProfilesInput{
+			FarmId: farmID,
+		}, func(page *deadline.ListStorageProfilesOutput, lastPage bool) bool {
+			for _, profile := range page.StorageProfiles {
+				resources = append(resources, &DeadlineStorageProfile{
+					svc:              svc,
+					farmID:           farmID,
+					storageProfileID: profile.StorageProfileId,
+					displayName:      profile.DisplayName,
+					osFamily:         profile.OsFamily,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+func (f *DeadlineStorageProfile) Remove() error {
+	_, err := f.svc.DeleteStorageProfile(&deadline.DeleteStorageProfileInput{
+		FarmId:           f.farmID,
+		StorageProfileId: f.storageProfileID,
+	})
+
+	return err
+}
+
+func (f *DeadlineStorageProfile) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FarmID", f.farmID).
+		Set("StorageProfileID", f.storageProfileID).
+		Set("DisplayName", f.displayName).
+		Set("OsFamily", f.osFamily)
+}
+
+func (f *DeadlineStorageProfile) String() string {
+	return *f.storageProfileID
+}
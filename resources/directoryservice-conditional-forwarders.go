@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/directoryservice"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DirectoryServiceConditionalForwarder struct {
+	svc              *directoryservice.DirectoryService
+	directoryID      *string
+	remoteDomainName *string
+}
+
+func init() {
+	register("DirectoryServiceConditionalForwarder", ListDirectoryServiceConditionalForwarders)
+}
+
+// ListDirectoryServiceConditionalForwarders lists the conditional forwarders
+// of all Microsoft AD directories, since only those support them.
+func ListDirectoryServiceConditionalForwarders(sess *session.Session) ([]Resource, error) {
+	svc := directoryservice.New(sess)
+	resources := []Resource{}
+
+	directories := []*string{}
+	err := svc.DescribeDirectoriesPages(&directoryservice.DescribeDirectoriesInput{},
+		func(page *directoryservice.DescribeDirectoriesOutput, lastPage bool) bool {
+			for _, directory := range page.DirectoryDescriptions {
+				if aws.StringValue(directory.Type) == directoryservice.DirectoryTypeMicrosoftAd &&
+					aws.StringValue(directory.Stage) == directoryservice.DirectoryStageActive {
+					directories = append(directories, directory.DirectoryId)
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, directoryID := range directories {
+		resp, err := svc.DescribeConditionalForwarders(&directoryservice.DescribeConditionalForwardersInput{
+			DirectoryId: directoryID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, forwarder := range resp.ConditionalForwarders {
+			resources = append(resources, &DirectoryServiceConditionalForwarder{
+				svc:              svc,
+				directoryID:      directoryID,
+				remoteDomainName: forwarder.RemoteDomainName,
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func (f *DirectoryServiceConditionalForwarder) Remove() error {
+	_, err := f.svc.DeleteConditionalForwarder(&directoryservice.DeleteConditionalForwarderInput{
+		DirectoryId:      f.directoryID,
+		RemoteDomainName: f.remoteDomainName,
+	})
+
+	return err
+}
+
+func (f *DirectoryServiceConditionalForwarder) Properties() types.Properties {
+	return types.NewProperties().
+		Set("DirectoryID", f.directoryID).
+		Set("RemoteDomainName", f.remoteDomainName)
+}
+
+func (f *DirectoryServiceConditionalForwarder) String() string {
+	return fmt.Sprintf("%s -> %s", *f.directoryID, *f.remoteDomainName)
+}
@@ -4,11 +4,15 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/directoryservice"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type DirectoryServiceDirectory struct {
 	svc         *directoryservice.DirectoryService
 	directoryID *string
+	name        *string
+	dirType     *string
+	stage       *string
 }
 
 func init() {
@@ -33,6 +37,9 @@ func ListDirectoryServiceDirectories(sess *session.Session) ([]Resource, error)
 			resources = append(resources, &DirectoryServiceDirectory{
 				svc:         svc,
 				directoryID: directory.DirectoryId,
+				name:        directory.Name,
+				dirType:     directory.Type,
+				stage:       directory.Stage,
 			})
 		}
 
@@ -55,6 +62,14 @@ func (f *DirectoryServiceDirectory) Remove() error {
 	return err
 }
 
+func (f *DirectoryServiceDirectory) Properties() types.Properties {
+	return types.NewProperties().
+		Set("DirectoryID", f.directoryID).
+		Set("Name", f.name).
+		Set("Type", f.dirType).
+		Set("Stage", f.stage)
+}
+
 func (f *DirectoryServiceDirectory) String() string {
 	return *f.directoryID
 }
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/directoryservice"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DirectoryServiceSharedDirectory struct {
+	svc               *directoryservice.DirectoryService
+	ownerDirectoryID  *string
+	sharedAccountID   *string
+	sharedDirectoryID *string
+	shareMethod       *string
+	shareStatus       *string
+}
+
+func init() {
+	register("DirectoryServiceSharedDirectory", ListDirectoryServiceSharedDirectories)
+}
+
+// ListDirectoryServiceSharedDirectories lists the shares of the directories,
+// which are owned by the account. Only Microsoft AD directories can be shared.
+func ListDirectoryServiceSharedDirectories(sess *session.Session) ([]Resource, error) {
+	svc := directoryservice.New(sess)
+	resources := []Resource{}
+
+	owners := []*string{}
+	err := svc.DescribeDirectoriesPages(&directoryservice.DescribeDirectoriesInput{},
+		func(page *directoryservice.DescribeDirectoriesOutput, lastPage bool) bool {
+			for _, directory := range page.DirectoryDescriptions {
+				if aws.StringValue(directory.Type) == directoryservice.DirectoryTypeMicrosoftAd {
+					owners = append(owners, directory.DirectoryId)
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		params := &directoryservice.DescribeSharedDirectoriesInput{
+			OwnerDirectoryId: owner,
+		}
+
+		err := svc.DescribeSharedDirectoriesPages(params,
+			func(page *directoryservice.DescribeSharedDirectoriesOutput, lastPage bool) bool {
+				for _, shared := range page.SharedDirectories {
+					if aws.StringValue(shared.ShareStatus) == directoryservice.ShareStatusDeleted {
+						continue
+					}
+
+					resources = append(resources, &DirectoryServiceSharedDirectory{
+						svc:               svc,
+						ownerDirectoryID:  shared.OwnerDirectoryId,
+						sharedAccountID:   shared.SharedAccountId,
+						sharedDirectoryID: shared.SharedDirectoryId,
+						shareMethod:       shared.ShareMethod,
+						shareStatus:       shared.ShareStatus,
+					})
+				}
+				return true
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+func (f *DirectoryServiceSharedDirectory) Remove() error {
+	_, err := f.svc.UnshareDirectory(&directoryservice.UnshareDirectoryInput{
+		DirectoryId: f.ownerDirectoryID,
+		UnshareTarget: &directoryservice.UnshareTarget{
+			Id:   f.sharedAccountID,
+			Type: aws.String(directoryservice.TargetTypeAccount),
+		},
+	})
+
+	return err
+}
+
+func (f *DirectoryServiceSharedDirectory) Properties() types.Properties {
+	return types.NewProperties().
+		Set("OwnerDirectoryID", f.ownerDirectoryID).
+		Set("SharedAccountID", f.sharedAccountID).
+		Set("SharedDirectoryID", f.sharedDirectoryID).
+		Set("ShareMethod", f.shareMethod).
+		Set("ShareStatus", f.shareStatus)
+}
+
+func (f *DirectoryServiceSharedDirectory) String() string {
+	return fmt.Sprintf("%s -> %s", *f.ownerDirectoryID, *f.sharedAccountID)
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/drs"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DRSRecoveryInstance struct {
+	svc                *drs.Drs
+	recoveryInstanceID *string
+	sourceServerID     *string
+	ec2InstanceID      *string
+	isDrill            *bool
+	tags               map[string]*string
+}
+
+func init() {
+	register("DRSRecoveryInstance", ListDRSRecoveryInstances)
+}
+
+func ListDRSRecoveryInstances(sess *session.Session) ([]Resource, error) {
+	svc := drs.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeRecoveryInstancesPages(&drs.DescribeRecoveryInstancesInput{},
+		func(page *drs.DescribeRecoveryInstancesOutput, lastPage bool) bool {
+			for _, instance := range page.Items {
+				resources = append(resources, &DRSRecoveryInstance{
+					svc:                svc,
+					recoveryInstanceID: instance.RecoveryInstanceID,
+					sourceServerID:     instance.SourceServerID,
+					ec2InstanceID:      instance.Ec2InstanceID,
+					isDrill:            instance.IsDrill,
+					tags:               instance.Tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == drs.ErrCodeUninitializedAccountException {
+			// The service was never used in this region.
+			return resources, nil
+		}
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove terminates the recovery instance including its EC2 instance.
+func (f *DRSRecoveryInstance) Remove() error {
+	_, err := f.svc.TerminateRecoveryInstances(&drs.TerminateRecoveryInstancesInput{
+		RecoveryInstanceIDs: []*string{f.recoveryInstanceID},
+	})
+
+	return err
+}
+
+func (f *DRSRecoveryInstance) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("RecoveryInstanceID", f.recoveryInstanceID).
+		Set("SourceServerID", f.sourceServerID).
+		Set("EC2InstanceID", f.ec2InstanceID).
+		Set("IsDrill", f.isDrill)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *DRSRecoveryInstance) String() string {
+	return *f.recoveryInstanceID
+}
@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/drs"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type DRSSourceServer struct {
+	svc              *drs.Drs
+	sourceServerID   *string
+	arn              *string
+	replicationState *string
+	tags             map[string]*string
+}
+
+func init() {
+	register("DRSSourceServer", ListDRSSourceServers)
+}
+
+func ListDRSSourceServers(sess *session.Session) ([]Resource, error) {
+	svc := drs.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeSourceServersPages(&drs.DescribeSourceServersInput{},
+		func(page *drs.DescribeSourceServersOutput, lastPage bool) bool {
+			for _, server := range page.Items {
+				r := &DRSSourceServer{
+					svc:            svc,
+					sourceServerID: server.SourceServerID,
+					arn:            server.Arn,
+					tags:           server.Tags,
+				}
+				if server.DataReplicationInfo != nil {
+					r.replicationState = server.DataReplicationInfo.DataReplicationState
+				}
+				resources = append(resources, r)
+			}
+			return true
+		})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == drs.ErrCodeUninitializedAccountException {
+			// The service was never used in this region.
+			return resources, nil
+		}
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove disconnects the server, which stops the replication, and deletes it
+// afterwards. The deletion fails as long as the server has recovery
+// instances.
+func (f *DRSSourceServer) Remove() error {
+	if aws.StringValue(f.replicationState) != drs.DataReplicationStateDisconnected {
+		_, err := f.svc.DisconnectSourceServer(&drs.DisconnectSourceServerInput{
+			SourceServerID: f.sourceServerID,
+		})
+		if err != nil {
+			return err
+		}
+		f.replicationState = aws.String(drs.DataReplicationStateDisconnected)
+	}
+
+	_, err := f.svc.DeleteSourceServer(&drs.DeleteSourceServerInput{
+		SourceServerID: f.sourceServerID,
+	})
+
+	return err
+}
+
+func (f *DRSSourceServer) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("SourceServerID", f.sourceServerID).
+		Set("ARN", f.arn).
+		Set("ReplicationState", f.replicationState)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *DRSSourceServer) String() string {
+	return *f.sourceServerID
+}
@@ -1,14 +1,17 @@
 package resources
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type DynamoDBTable struct {
-	svc  *dynamodb.DynamoDB
+	svc  dynamodbiface.DynamoDBAPI
 	id   string
 	tags []*dynamodb.Tag
 }
@@ -43,6 +46,21 @@ func ListDynamoDBTables(sess *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+func (i *DynamoDBTable) CheckRemove() error {
+	resp, err := i.svc.DescribeTable(&dynamodb.DescribeTableInput{
+		TableName: aws.String(i.id),
+	})
+	if err != nil {
+		return nil
+	}
+
+	if aws.BoolValue(resp.Table.DeletionProtectionEnabled) {
+		return fmt.Errorf("deletion protection is disabled")
+	}
+
+	return nil
+}
+
 func (i *DynamoDBTable) Remove() error {
 	params := &dynamodb.DeleteTableInput{
 		TableName: aws.String(i.id),
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/golang/mock/gomock"
+	"github.com/rebuy-de/aws-nuke/mocks/mock_dynamodbiface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamoDBTable_CheckRemove(t *testing.T) {
+	cases := []struct {
+		name    string
+		output  *dynamodb.DescribeTableOutput
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "deletion protection enabled",
+			output: &dynamodb.DescribeTableOutput{
+				Table: &dynamodb.TableDescription{DeletionProtectionEnabled: aws.Bool(true)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deletion protection disabled",
+			output: &dynamodb.DescribeTableOutput{
+				Table: &dynamodb.TableDescription{DeletionProtectionEnabled: aws.Bool(false)},
+			},
+		},
+		{
+			name:   "deletion protection unknown",
+			output: &dynamodb.DescribeTableOutput{Table: &dynamodb.TableDescription{}},
+		},
+		{
+			name: "describe failed",
+			err:  awserr.New(dynamodb.ErrCodeResourceNotFoundException, "Requested resource not found", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDynamoDB := mock_dynamodbiface.NewMockDynamoDBAPI(ctrl)
+			table := DynamoDBTable{
+				svc: mockDynamoDB,
+				id:  "foobar",
+			}
+
+			mockDynamoDB.EXPECT().DescribeTable(gomock.Eq(&dynamodb.DescribeTableInput{
+				TableName: aws.String("foobar"),
+			})).Return(tc.output, tc.err)
+
+			err := table.CheckRemove()
+			a.Equal(tc.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
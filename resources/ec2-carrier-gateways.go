@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2CarrierGateway struct {
+	svc *ec2.EC2
+	cgw *ec2.CarrierGateway
+}
+
+func init() {
+	register("EC2CarrierGateway", ListEC2CarrierGateways)
+}
+
+func ListEC2CarrierGateways(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeCarrierGatewaysPages(&ec2.DescribeCarrierGatewaysInput{},
+		func(page *ec2.DescribeCarrierGatewaysOutput, lastPage bool) bool {
+			for _, cgw := range page.CarrierGateways {
+				resources = append(resources, &EC2CarrierGateway{
+					svc: svc,
+					cgw: cgw,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2CarrierGateway) Filter() error {
+	if *e.cgw.State == ec2.CarrierGatewayStateDeleted {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+func (e *EC2CarrierGateway) Remove() error {
+	_, err := e.svc.DeleteCarrierGateway(&ec2.DeleteCarrierGatewayInput{
+		CarrierGatewayId: e.cgw.CarrierGatewayId,
+	})
+
+	return err
+}
+
+func (e *EC2CarrierGateway) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.cgw.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("VpcID", e.cgw.VpcId)
+	properties.Set("State", e.cgw.State)
+	return properties
+}
+
+func (e *EC2CarrierGateway) String() string {
+	return *e.cgw.CarrierGatewayId
+}
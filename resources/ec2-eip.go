@@ -1,16 +1,27 @@
 package resources
 
 import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
+// EC2_ADDRESS_AMAZON_POOL is the public IPv4 pool of addresses, which are
+// provided by AWS. All other pools contain addresses brought by the customer
+// (BYOIP).
+const EC2_ADDRESS_AMAZON_POOL = "amazon"
+
 type EC2Address struct {
-	svc *ec2.EC2
-	eip *ec2.Address
-	id  string
-	ip  string
+	svc          *ec2.EC2
+	eip          *ec2.Address
+	id           string
+	ip           string
+	natGatewayID *string
+	featureFlags config.FeatureFlags
 }
 
 func init() {
@@ -26,20 +37,75 @@ func ListEC2Addresses(sess *session.Session) ([]Resource, error) {
 		return nil, err
 	}
 
+	natGateways, err := ec2AddressNATGateways(svc)
+	if err != nil {
+		return nil, err
+	}
+
 	resources := make([]Resource, 0)
 	for _, out := range resp.Addresses {
 		resources = append(resources, &EC2Address{
-			svc: svc,
-			eip: out,
-			id:  *out.AllocationId,
-			ip:  *out.PublicIp,
+			svc:          svc,
+			eip:          out,
+			id:           *out.AllocationId,
+			ip:           *out.PublicIp,
+			natGatewayID: natGateways[aws.StringValue(out.AllocationId)],
 		})
 	}
 
 	return resources, nil
 }
 
+// ec2AddressNATGateways maps the allocation IDs of addresses to the NAT
+// gateways, which use them. The addresses can only be released after the
+// NAT gateway is deleted.
+func ec2AddressNATGateways(svc *ec2.EC2) (map[string]*string, error) {
+	natGateways := map[string]*string{}
+
+	err := svc.DescribeNatGatewaysPages(&ec2.DescribeNatGatewaysInput{},
+		func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+			for _, natgw := range page.NatGateways {
+				if aws.StringValue(natgw.State) == ec2.NatGatewayStateDeleted {
+					continue
+				}
+
+				for _, address := range natgw.NatGatewayAddresses {
+					if address.AllocationId != nil {
+						natGateways[*address.AllocationId] = natgw.NatGatewayId
+					}
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return natGateways, nil
+}
+
+func (e *EC2Address) FeatureFlags(ff config.FeatureFlags) {
+	e.featureFlags = ff
+}
+
+func (e *EC2Address) Filter() error {
+	pool := aws.StringValue(e.eip.PublicIpv4Pool)
+	if pool != "" && pool != EC2_ADDRESS_AMAZON_POOL {
+		return fmt.Errorf("address belongs to the BYOIP pool %s", pool)
+	}
+	return nil
+}
+
 func (e *EC2Address) Remove() error {
+	if e.eip.AssociationId != nil && e.natGatewayID == nil && e.featureFlags.DisassociateEC2Addresses {
+		_, err := e.svc.DisassociateAddress(&ec2.DisassociateAddressInput{
+			AssociationId: e.eip.AssociationId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := e.svc.ReleaseAddress(&ec2.ReleaseAddressInput{
 		AllocationId: &e.id,
 	})
@@ -50,12 +116,18 @@ func (e *EC2Address) Remove() error {
 	return nil
 }
 
+func (e *EC2Address) Raw() interface{} {
+	return e.eip
+}
+
 func (e *EC2Address) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.eip.Tags {
 		properties.SetTag(tagValue.Key, tagValue.Value)
 	}
 	properties.Set("AllocationID", e.id)
+	properties.Set("PublicIpv4Pool", e.eip.PublicIpv4Pool)
+	properties.Set("NATGatewayID", e.natGatewayID)
 	return properties
 }
 
@@ -7,12 +7,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type EC2Instance struct {
-	svc      *ec2.EC2
+	svc      ec2iface.EC2API
 	instance *ec2.Instance
 
 	featureFlags config.FeatureFlags
@@ -64,6 +65,26 @@ func (i *EC2Instance) Filter() error {
 	return nil
 }
 
+func (i *EC2Instance) CheckRemove() error {
+	if i.featureFlags.DisableDeletionProtection.EC2Instance {
+		return nil
+	}
+
+	resp, err := i.svc.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{
+		InstanceId: i.instance.InstanceId,
+		Attribute:  aws.String(ec2.InstanceAttributeNameDisableApiTermination),
+	})
+	if err != nil {
+		return nil
+	}
+
+	if resp.DisableApiTermination != nil && aws.BoolValue(resp.DisableApiTermination.Value) {
+		return fmt.Errorf("termination protection is disabled")
+	}
+
+	return nil
+}
+
 func (i *EC2Instance) Remove() error {
 	params := &ec2.TerminateInstancesInput{
 		InstanceIds: []*string{i.instance.InstanceId},
@@ -106,6 +127,10 @@ func (i *EC2Instance) DisableProtection() error {
 	return nil
 }
 
+func (i *EC2Instance) Raw() interface{} {
+	return i.instance
+}
+
 func (i *EC2Instance) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range i.instance.Tags {
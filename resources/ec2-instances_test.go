@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/rebuy-de/aws-nuke/mocks/mock_ec2iface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEC2Instance_CheckRemove(t *testing.T) {
+	cases := []struct {
+		name                      string
+		disableDeletionProtection bool
+		output                    *ec2.DescribeInstanceAttributeOutput
+		err                       error
+		wantErr                   bool
+	}{
+		{
+			name: "termination protection enabled",
+			output: &ec2.DescribeInstanceAttributeOutput{
+				DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "termination protection disabled",
+			output: &ec2.DescribeInstanceAttributeOutput{
+				DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+			},
+		},
+		{
+			name:                      "termination protection is disabled by the feature flag",
+			disableDeletionProtection: true,
+		},
+		{
+			name: "describe failed",
+			err:  awserr.New("InvalidInstanceID.NotFound", "The instance ID does not exist", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEC2 := mock_ec2iface.NewMockEC2API(ctrl)
+			instance := EC2Instance{
+				svc:      mockEC2,
+				instance: &ec2.Instance{InstanceId: aws.String("i-01b489457a60298dd")},
+			}
+			instance.featureFlags.DisableDeletionProtection.EC2Instance = tc.disableDeletionProtection
+
+			if !tc.disableDeletionProtection {
+				mockEC2.EXPECT().DescribeInstanceAttribute(gomock.Eq(&ec2.DescribeInstanceAttributeInput{
+					InstanceId: aws.String("i-01b489457a60298dd"),
+					Attribute:  aws.String(ec2.InstanceAttributeNameDisableApiTermination),
+				})).Return(tc.output, tc.err)
+			}
+
+			err := instance.CheckRemove()
+			a.Equal(tc.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
@@ -47,6 +47,10 @@ func (e *EC2InternetGateway) Remove() error {
 	return nil
 }
 
+func (e *EC2InternetGateway) Raw() interface{} {
+	return e.igw
+}
+
 func (e *EC2InternetGateway) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.igw.Tags {
@@ -0,0 +1,100 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2IPAMPool struct {
+	svc  *ec2.EC2
+	pool *ec2.IpamPool
+}
+
+func init() {
+	register("EC2IPAMPool", ListEC2IPAMPools)
+}
+
+func ListEC2IPAMPools(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeIpamPoolsPages(&ec2.DescribeIpamPoolsInput{},
+		func(page *ec2.DescribeIpamPoolsOutput, lastPage bool) bool {
+			for _, pool := range page.IpamPools {
+				resources = append(resources, &EC2IPAMPool{
+					svc:  svc,
+					pool: pool,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2IPAMPool) Filter() error {
+	if *e.pool.State == ec2.IpamPoolStateDeleteComplete {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+// Remove deprovisions all CIDRs of the pool, before deleting it. Both fail as
+// long as the pool has child pools or allocations.
+func (e *EC2IPAMPool) Remove() error {
+	cidrs := []*string{}
+	err := e.svc.GetIpamPoolCidrsPages(&ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: e.pool.IpamPoolId,
+	}, func(page *ec2.GetIpamPoolCidrsOutput, lastPage bool) bool {
+		for _, cidr := range page.IpamPoolCidrs {
+			switch *cidr.State {
+			case ec2.IpamPoolCidrStateProvisioned, ec2.IpamPoolCidrStateFailedDeprovision:
+				cidrs = append(cidrs, cidr.Cidr)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cidr := range cidrs {
+		_, err := e.svc.DeprovisionIpamPoolCidr(&ec2.DeprovisionIpamPoolCidrInput{
+			IpamPoolId: e.pool.IpamPoolId,
+			Cidr:       cidr,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = e.svc.DeleteIpamPool(&ec2.DeleteIpamPoolInput{
+		IpamPoolId: e.pool.IpamPoolId,
+	})
+
+	return err
+}
+
+func (e *EC2IPAMPool) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.pool.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("IpamPoolID", e.pool.IpamPoolId)
+	properties.Set("AddressFamily", e.pool.AddressFamily)
+	properties.Set("Locale", e.pool.Locale)
+	properties.Set("ScopeType", e.pool.IpamScopeType)
+	properties.Set("SourceIpamPoolID", e.pool.SourceIpamPoolId)
+	properties.Set("Description", e.pool.Description)
+	return properties
+}
+
+func (e *EC2IPAMPool) String() string {
+	return *e.pool.IpamPoolId
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2IPAMResourceDiscovery struct {
+	svc       *ec2.EC2
+	discovery *ec2.IpamResourceDiscovery
+}
+
+func init() {
+	register("EC2IPAMResourceDiscovery", ListEC2IPAMResourceDiscoveries)
+}
+
+func ListEC2IPAMResourceDiscoveries(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeIpamResourceDiscoveriesPages(&ec2.DescribeIpamResourceDiscoveriesInput{},
+		func(page *ec2.DescribeIpamResourceDiscoveriesOutput, lastPage bool) bool {
+			for _, discovery := range page.IpamResourceDiscoveries {
+				resources = append(resources, &EC2IPAMResourceDiscovery{
+					svc:       svc,
+					discovery: discovery,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2IPAMResourceDiscovery) Filter() error {
+	if aws.BoolValue(e.discovery.IsDefault) {
+		return fmt.Errorf("default resource discoveries are deleted with their IPAM")
+	}
+	return nil
+}
+
+// Remove deletes the resource discovery. This fails until it is disassociated
+// from all IPAMs by the EC2IPAMResourceDiscoveryAssociation resources.
+func (e *EC2IPAMResourceDiscovery) Remove() error {
+	_, err := e.svc.DeleteIpamResourceDiscovery(&ec2.DeleteIpamResourceDiscoveryInput{
+		IpamResourceDiscoveryId: e.discovery.IpamResourceDiscoveryId,
+	})
+
+	return err
+}
+
+func (e *EC2IPAMResourceDiscovery) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.discovery.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("IpamResourceDiscoveryID", e.discovery.IpamResourceDiscoveryId)
+	properties.Set("Description", e.discovery.Description)
+	return properties
+}
+
+func (e *EC2IPAMResourceDiscovery) String() string {
+	return *e.discovery.IpamResourceDiscoveryId
+}
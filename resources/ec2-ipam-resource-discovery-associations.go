@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2IPAMResourceDiscoveryAssociation struct {
+	svc         *ec2.EC2
+	association *ec2.IpamResourceDiscoveryAssociation
+}
+
+func init() {
+	register("EC2IPAMResourceDiscoveryAssociation", ListEC2IPAMResourceDiscoveryAssociations)
+}
+
+func ListEC2IPAMResourceDiscoveryAssociations(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeIpamResourceDiscoveryAssociationsPages(&ec2.DescribeIpamResourceDiscoveryAssociationsInput{},
+		func(page *ec2.DescribeIpamResourceDiscoveryAssociationsOutput, lastPage bool) bool {
+			for _, association := range page.IpamResourceDiscoveryAssociations {
+				resources = append(resources, &EC2IPAMResourceDiscoveryAssociation{
+					svc:         svc,
+					association: association,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2IPAMResourceDiscoveryAssociation) Filter() error {
+	if aws.BoolValue(e.association.IsDefault) {
+		return fmt.Errorf("default associations are deleted with their IPAM")
+	}
+	return nil
+}
+
+func (e *EC2IPAMResourceDiscoveryAssociation) Remove() error {
+	_, err := e.svc.DisassociateIpamResourceDiscovery(&ec2.DisassociateIpamResourceDiscoveryInput{
+		IpamResourceDiscoveryAssociationId: e.association.IpamResourceDiscoveryAssociationId,
+	})
+
+	return err
+}
+
+func (e *EC2IPAMResourceDiscoveryAssociation) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.association.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("IpamID", e.association.IpamId)
+	properties.Set("IpamResourceDiscoveryID", e.association.IpamResourceDiscoveryId)
+	return properties
+}
+
+func (e *EC2IPAMResourceDiscoveryAssociation) String() string {
+	return *e.association.IpamResourceDiscoveryAssociationId
+}
@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2IPAMScope struct {
+	svc   *ec2.EC2
+	scope *ec2.IpamScope
+}
+
+func init() {
+	register("EC2IPAMScope", ListEC2IPAMScopes)
+}
+
+func ListEC2IPAMScopes(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeIpamScopesPages(&ec2.DescribeIpamScopesInput{},
+		func(page *ec2.DescribeIpamScopesOutput, lastPage bool) bool {
+			for _, scope := range page.IpamScopes {
+				resources = append(resources, &EC2IPAMScope{
+					svc:   svc,
+					scope: scope,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2IPAMScope) Filter() error {
+	if aws.BoolValue(e.scope.IsDefault) {
+		return fmt.Errorf("default scopes are deleted with their IPAM")
+	}
+	return nil
+}
+
+func (e *EC2IPAMScope) Remove() error {
+	_, err := e.svc.DeleteIpamScope(&ec2.DeleteIpamScopeInput{
+		IpamScopeId: e.scope.IpamScopeId,
+	})
+
+	return err
+}
+
+func (e *EC2IPAMScope) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.scope.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("IpamScopeID", e.scope.IpamScopeId)
+	properties.Set("ScopeType", e.scope.IpamScopeType)
+	properties.Set("Description", e.scope.Description)
+	return properties
+}
+
+func (e *EC2IPAMScope) String() string {
+	return *e.scope.IpamScopeId
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2IPAM struct {
+	svc  *ec2.EC2
+	ipam *ec2.Ipam
+}
+
+func init() {
+	register("EC2IPAM", ListEC2IPAMs)
+}
+
+func ListEC2IPAMs(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeIpamsPages(&ec2.DescribeIpamsInput{},
+		func(page *ec2.DescribeIpamsOutput, lastPage bool) bool {
+			for _, ipam := range page.Ipams {
+				resources = append(resources, &EC2IPAM{
+					svc:  svc,
+					ipam: ipam,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2IPAM) Filter() error {
+	if *e.ipam.State == ec2.IpamStateDeleteComplete {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+// Remove deletes the IPAM without cascading, so its pools are deprovisioned
+// by the EC2IPAMPool resources first. The deletion fails until all scopes
+// and pools, which are not the defaults, are deleted.
+func (e *EC2IPAM) Remove() error {
+	_, err := e.svc.DeleteIpam(&ec2.DeleteIpamInput{
+		IpamId: e.ipam.IpamId,
+	})
+
+	return err
+}
+
+func (e *EC2IPAM) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.ipam.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("IpamID", e.ipam.IpamId)
+	properties.Set("Tier", e.ipam.Tier)
+	properties.Set("Description", e.ipam.Description)
+	return properties
+}
+
+func (e *EC2IPAM) String() string {
+	return *e.ipam.IpamId
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// EC2LocalGatewayRouteTableVPCAssociation connects a VPC to the local gateway
+// of an Outpost. The local gateway and its route tables belong to the Outpost
+// and cannot be deleted.
+type EC2LocalGatewayRouteTableVPCAssociation struct {
+	svc         *ec2.EC2
+	association *ec2.LocalGatewayRouteTableVpcAssociation
+}
+
+func init() {
+	register("EC2LocalGatewayRouteTableVPCAssociation", ListEC2LocalGatewayRouteTableVPCAssociations)
+}
+
+func ListEC2LocalGatewayRouteTableVPCAssociations(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeLocalGatewayRouteTableVpcAssociationsPages(&ec2.DescribeLocalGatewayRouteTableVpcAssociationsInput{},
+		func(page *ec2.DescribeLocalGatewayRouteTableVpcAssociationsOutput, lastPage bool) bool {
+			for _, association := range page.LocalGatewayRouteTableVpcAssociations {
+				resources = append(resources, &EC2LocalGatewayRouteTableVPCAssociation{
+					svc:         svc,
+					association: association,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2LocalGatewayRouteTableVPCAssociation) Filter() error {
+	if *e.association.State == "disassociated" {
+		return fmt.Errorf("already disassociated")
+	}
+	return nil
+}
+
+func (e *EC2LocalGatewayRouteTableVPCAssociation) Remove() error {
+	_, err := e.svc.DeleteLocalGatewayRouteTableVpcAssociation(&ec2.DeleteLocalGatewayRouteTableVpcAssociationInput{
+		LocalGatewayRouteTableVpcAssociationId: e.association.LocalGatewayRouteTableVpcAssociationId,
+	})
+
+	return err
+}
+
+func (e *EC2LocalGatewayRouteTableVPCAssociation) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.association.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("LocalGatewayID", e.association.LocalGatewayId)
+	properties.Set("LocalGatewayRouteTableID", e.association.LocalGatewayRouteTableId)
+	properties.Set("VpcID", e.association.VpcId)
+	return properties
+}
+
+func (e *EC2LocalGatewayRouteTableVPCAssociation) String() string {
+	return *e.association.LocalGatewayRouteTableVpcAssociationId
+}
@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2ManagedPrefixList struct {
+	svc        *ec2.EC2
+	prefixList *ec2.ManagedPrefixList
+}
+
+func init() {
+	register("EC2ManagedPrefixList", ListEC2ManagedPrefixLists)
+}
+
+func ListEC2ManagedPrefixLists(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeManagedPrefixListsPages(&ec2.DescribeManagedPrefixListsInput{},
+		func(page *ec2.DescribeManagedPrefixListsOutput, lastPage bool) bool {
+			for _, prefixList := range page.PrefixLists {
+				resources = append(resources, &EC2ManagedPrefixList{
+					svc:        svc,
+					prefixList: prefixList,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2ManagedPrefixList) Filter() error {
+	if aws.StringValue(e.prefixList.OwnerId) == "AWS" {
+		return fmt.Errorf("cannot delete AWS managed prefix list")
+	}
+	if *e.prefixList.State == ec2.PrefixListStateDeleteComplete {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+// Remove deletes the prefix list, once it isn't referenced by security groups
+// or route tables anymore. Until those are removed, it returns an error
+// naming them, so the removal is retried.
+func (e *EC2ManagedPrefixList) Remove() error {
+	references := []string{}
+	err := e.svc.GetManagedPrefixListAssociationsPages(&ec2.GetManagedPrefixListAssociationsInput{
+		PrefixListId: e.prefixList.PrefixListId,
+	}, func(page *ec2.GetManagedPrefixListAssociationsOutput, lastPage bool) bool {
+		for _, association := range page.PrefixListAssociations {
+			references = append(references, aws.StringValue(association.ResourceId))
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(references) > 0 {
+		return fmt.Errorf("prefix list is still referenced by %s", strings.Join(references, ", "))
+	}
+
+	_, err = e.svc.DeleteManagedPrefixList(&ec2.DeleteManagedPrefixListInput{
+		PrefixListId: e.prefixList.PrefixListId,
+	})
+
+	return err
+}
+
+func (e *EC2ManagedPrefixList) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.prefixList.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("PrefixListID", e.prefixList.PrefixListId)
+	properties.Set("Name", e.prefixList.PrefixListName)
+	properties.Set("AddressFamily", e.prefixList.AddressFamily)
+	properties.Set("OwnerID", e.prefixList.OwnerId)
+	return properties
+}
+
+func (e *EC2ManagedPrefixList) String() string {
+	return *e.prefixList.PrefixListId
+}
@@ -57,11 +57,16 @@ func (n *EC2NATGateway) Remove() error {
 	return nil
 }
 
+func (n *EC2NATGateway) Raw() interface{} {
+	return n.natgw
+}
+
 func (n *EC2NATGateway) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range n.natgw.Tags {
 		properties.SetTag(tagValue.Key, tagValue.Value)
 	}
+	properties.Set("NATGatewayID", n.natgw.NatGatewayId)
 	return properties
 }
 
@@ -48,6 +48,10 @@ func (e *EC2NetworkInterface) Remove() error {
 	return nil
 }
 
+func (e *EC2NetworkInterface) Raw() interface{} {
+	return e.eni
+}
+
 func (r *EC2NetworkInterface) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tag := range r.eni.TagSet {
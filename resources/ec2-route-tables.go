@@ -47,6 +47,10 @@ func (e *EC2RouteTable) Remove() error {
 	return nil
 }
 
+func (e *EC2RouteTable) Raw() interface{} {
+	return e.routeTable
+}
+
 func (e *EC2RouteTable) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.routeTable.Tags {
@@ -84,6 +84,10 @@ func (sg *EC2SecurityGroup) Remove() error {
 	return nil
 }
 
+func (sg *EC2SecurityGroup) Raw() interface{} {
+	return sg.group
+}
+
 func (sg *EC2SecurityGroup) Properties() types.Properties {
 	properties := types.NewProperties()
     for _, tagValue := range sg.group.Tags {
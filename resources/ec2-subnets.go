@@ -1,14 +1,16 @@
 package resources
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type EC2Subnet struct {
-	svc    *ec2.EC2
-	subnet *ec2.Subnet
+	svc      *ec2.EC2
+	subnet   *ec2.Subnet
+	zoneType *string
 }
 
 func init() {
@@ -24,17 +26,42 @@ func ListEC2Subnets(sess *session.Session) ([]Resource, error) {
 		return nil, err
 	}
 
+	zoneTypes, err := ec2ZoneTypes(svc)
+	if err != nil {
+		return nil, err
+	}
+
 	resources := make([]Resource, 0)
 	for _, out := range resp.Subnets {
 		resources = append(resources, &EC2Subnet{
-			svc:    svc,
-			subnet: out,
+			svc:      svc,
+			subnet:   out,
+			zoneType: zoneTypes[aws.StringValue(out.AvailabilityZone)],
 		})
 	}
 
 	return resources, nil
 }
 
+// ec2ZoneTypes maps the names of all zones of the region to their type (eg
+// availability-zone, local-zone or wavelength-zone). Zones of groups, which
+// are not opted in, are included too, since subnets can outlive the opt-in.
+func ec2ZoneTypes(svc *ec2.EC2) (map[string]*string, error) {
+	resp, err := svc.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zoneTypes := map[string]*string{}
+	for _, zone := range resp.AvailabilityZones {
+		zoneTypes[aws.StringValue(zone.ZoneName)] = zone.ZoneType
+	}
+
+	return zoneTypes, nil
+}
+
 func (e *EC2Subnet) Remove() error {
 	params := &ec2.DeleteSubnetInput{
 		SubnetId: e.subnet.SubnetId,
@@ -48,12 +75,19 @@ func (e *EC2Subnet) Remove() error {
 	return nil
 }
 
+func (e *EC2Subnet) Raw() interface{} {
+	return e.subnet
+}
+
 func (e *EC2Subnet) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.subnet.Tags {
 		properties.SetTag(tagValue.Key, tagValue.Value)
 	}
 	properties.Set("DefaultForAz", e.subnet.DefaultForAz)
+	properties.Set("AvailabilityZone", e.subnet.AvailabilityZone)
+	properties.Set("ZoneType", e.zoneType)
+	properties.Set("OutpostArn", e.subnet.OutpostArn)
 	return properties
 }
 
@@ -67,6 +67,10 @@ func (e *EC2TGW) Filter() error {
 	return nil
 }
 
+func (e *EC2TGW) Raw() interface{} {
+	return e.tgw
+}
+
 func (e *EC2TGW) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.tgw.Tags {
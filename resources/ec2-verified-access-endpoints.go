@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2VerifiedAccessEndpoint struct {
+	svc      *ec2.EC2
+	endpoint *ec2.VerifiedAccessEndpoint
+}
+
+func init() {
+	register("EC2VerifiedAccessEndpoint", ListEC2VerifiedAccessEndpoints)
+}
+
+func ListEC2VerifiedAccessEndpoints(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeVerifiedAccessEndpointsPages(&ec2.DescribeVerifiedAccessEndpointsInput{},
+		func(page *ec2.DescribeVerifiedAccessEndpointsOutput, lastPage bool) bool {
+			for _, endpoint := range page.VerifiedAccessEndpoints {
+				resources = append(resources, &EC2VerifiedAccessEndpoint{
+					svc:      svc,
+					endpoint: endpoint,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (e *EC2VerifiedAccessEndpoint) Filter() error {
+	if e.endpoint.Status != nil && aws.StringValue(e.endpoint.Status.Code) == ec2.VerifiedAccessEndpointStatusCodeDeleted {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+// Remove deletes the endpoint. Its network interfaces are deleted
+// asynchronously, so subnets and security groups of the endpoint can only be
+// removed afterwards.
+func (e *EC2VerifiedAccessEndpoint) Remove() error {
+	_, err := e.svc.DeleteVerifiedAccessEndpoint(&ec2.DeleteVerifiedAccessEndpointInput{
+		VerifiedAccessEndpointId: e.endpoint.VerifiedAccessEndpointId,
+	})
+
+	return err
+}
+
+func (e *EC2VerifiedAccessEndpoint) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.endpoint.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("VerifiedAccessEndpointID", e.endpoint.VerifiedAccessEndpointId)
+	properties.Set("VerifiedAccessGroupID", e.endpoint.VerifiedAccessGroupId)
+	properties.Set("VerifiedAccessInstanceID", e.endpoint.VerifiedAccessInstanceId)
+	properties.Set("EndpointType", e.endpoint.EndpointType)
+	properties.Set("ApplicationDomain", e.endpoint.ApplicationDomain)
+	return properties
+}
+
+func (e *EC2VerifiedAccessEndpoint) String() string {
+	return *e.endpoint.VerifiedAccessEndpointId
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2VerifiedAccessGroup struct {
+	svc   *ec2.EC2
+	group *ec2.VerifiedAccessGroup
+}
+
+func init() {
+	register("EC2VerifiedAccessGroup", ListEC2VerifiedAccessGroups)
+}
+
+func ListEC2VerifiedAccessGroups(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeVerifiedAccessGroupsPages(&ec2.DescribeVerifiedAccessGroupsInput{},
+		func(page *ec2.DescribeVerifiedAccessGroupsOutput, lastPage bool) bool {
+			for _, group := range page.VerifiedAccessGroups {
+				resources = append(resources, &EC2VerifiedAccessGroup{
+					svc:   svc,
+					group: group,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the group. This fails until all of its endpoints are
+// deleted.
+func (e *EC2VerifiedAccessGroup) Remove() error {
+	_, err := e.svc.DeleteVerifiedAccessGroup(&ec2.DeleteVerifiedAccessGroupInput{
+		VerifiedAccessGroupId: e.group.VerifiedAccessGroupId,
+	})
+
+	return err
+}
+
+func (e *EC2VerifiedAccessGroup) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.group.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("VerifiedAccessGroupID", e.group.VerifiedAccessGroupId)
+	properties.Set("VerifiedAccessInstanceID", e.group.VerifiedAccessInstanceId)
+	properties.Set("Description", e.group.Description)
+	properties.Set("CreationTime", e.group.CreationTime)
+	return properties
+}
+
+func (e *EC2VerifiedAccessGroup) String() string {
+	return *e.group.VerifiedAccessGroupId
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2VerifiedAccessInstance struct {
+	svc      *ec2.EC2
+	instance *ec2.VerifiedAccessInstance
+}
+
+func init() {
+	register("EC2VerifiedAccessInstance", ListEC2VerifiedAccessInstances)
+}
+
+func ListEC2VerifiedAccessInstances(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeVerifiedAccessInstancesPages(&ec2.DescribeVerifiedAccessInstancesInput{},
+		func(page *ec2.DescribeVerifiedAccessInstancesOutput, lastPage bool) bool {
+			for _, instance := range page.VerifiedAccessInstances {
+				resources = append(resources, &EC2VerifiedAccessInstance{
+					svc:      svc,
+					instance: instance,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove detaches all trust providers, so they can be deleted independently,
+// and deletes the instance afterwards. The deletion fails until all groups of
+// the instance are deleted.
+func (e *EC2VerifiedAccessInstance) Remove() error {
+	for _, provider := range e.instance.VerifiedAccessTrustProviders {
+		_, err := e.svc.DetachVerifiedAccessTrustProvider(&ec2.DetachVerifiedAccessTrustProviderInput{
+			VerifiedAccessInstanceId:      e.instance.VerifiedAccessInstanceId,
+			VerifiedAccessTrustProviderId: provider.VerifiedAccessTrustProviderId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	e.instance.VerifiedAccessTrustProviders = nil
+
+	_, err := e.svc.DeleteVerifiedAccessInstance(&ec2.DeleteVerifiedAccessInstanceInput{
+		VerifiedAccessInstanceId: e.instance.VerifiedAccessInstanceId,
+	})
+
+	return err
+}
+
+func (e *EC2VerifiedAccessInstance) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.instance.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("VerifiedAccessInstanceID", e.instance.VerifiedAccessInstanceId)
+	properties.Set("Description", e.instance.Description)
+	properties.Set("CreationTime", e.instance.CreationTime)
+	return properties
+}
+
+func (e *EC2VerifiedAccessInstance) String() string {
+	return *e.instance.VerifiedAccessInstanceId
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type EC2VerifiedAccessTrustProvider struct {
+	svc      *ec2.EC2
+	provider *ec2.VerifiedAccessTrustProvider
+}
+
+func init() {
+	register("EC2VerifiedAccessTrustProvider", ListEC2VerifiedAccessTrustProviders)
+}
+
+func ListEC2VerifiedAccessTrustProviders(sess *session.Session) ([]Resource, error) {
+	svc := ec2.New(sess)
+	resources := make([]Resource, 0)
+
+	err := svc.DescribeVerifiedAccessTrustProvidersPages(&ec2.DescribeVerifiedAccessTrustProvidersInput{},
+		func(page *ec2.DescribeVerifiedAccessTrustProvidersOutput, lastPage bool) bool {
+			for _, provider := range page.VerifiedAccessTrustProviders {
+				resources = append(resources, &EC2VerifiedAccessTrustProvider{
+					svc:      svc,
+					provider: provider,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the trust provider. This fails as long as it is attached to
+// an instance. The EC2VerifiedAccessInstance resources detach them.
+func (e *EC2VerifiedAccessTrustProvider) Remove() error {
+	_, err := e.svc.DeleteVerifiedAccessTrustProvider(&ec2.DeleteVerifiedAccessTrustProviderInput{
+		VerifiedAccessTrustProviderId: e.provider.VerifiedAccessTrustProviderId,
+	})
+
+	return err
+}
+
+func (e *EC2VerifiedAccessTrustProvider) Properties() types.Properties {
+	properties := types.NewProperties()
+	for _, tagValue := range e.provider.Tags {
+		properties.SetTag(tagValue.Key, tagValue.Value)
+	}
+	properties.Set("VerifiedAccessTrustProviderID", e.provider.VerifiedAccessTrustProviderId)
+	properties.Set("TrustProviderType", e.provider.TrustProviderType)
+	properties.Set("PolicyReferenceName", e.provider.PolicyReferenceName)
+	properties.Set("Description", e.provider.Description)
+	return properties
+}
+
+func (e *EC2VerifiedAccessTrustProvider) String() string {
+	return *e.provider.VerifiedAccessTrustProviderId
+}
@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
@@ -41,9 +43,29 @@ func (e *EC2Volume) Remove() error {
 	return err
 }
 
+// LastActivity returns the current time for attached volumes. Since EC2 does
+// not tell when a volume was detached, detached volumes count as active since
+// their creation.
+func (e *EC2Volume) LastActivity() (time.Time, bool) {
+	if len(e.volume.Attachments) > 0 {
+		return time.Now(), true
+	}
+
+	if e.volume.CreateTime == nil {
+		return time.Time{}, false
+	}
+	return *e.volume.CreateTime, true
+}
+
+func (e *EC2Volume) Raw() interface{} {
+	return e.volume
+}
+
 func (e *EC2Volume) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("State", e.volume.State)
+	properties.Set("CreateTime", e.volume.CreateTime)
+	properties.Set("Attached", len(e.volume.Attachments) > 0)
 	for _, tagValue := range e.volume.Tags {
 		properties.SetTag(tagValue.Key, tagValue.Value)
 	}
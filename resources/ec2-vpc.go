@@ -47,6 +47,10 @@ func (e *EC2VPC) Remove() error {
 	return nil
 }
 
+func (e *EC2VPC) Raw() interface{} {
+	return e.vpc
+}
+
 func (e *EC2VPC) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range e.vpc.Tags {
@@ -6,11 +6,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type ECRRepository struct {
 	svc  *ecr.ECR
 	name *string
+	lazy types.Properties
 }
 
 func init() {
@@ -48,6 +50,20 @@ func ListECRRepositories(sess *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+// isECRRepositoryEmpty returns whether the repository contains no images. It
+// returns nil, if this cannot be determined.
+func isECRRepositoryEmpty(svc *ecr.ECR, name *string) *bool {
+	resp, err := svc.ListImages(&ecr.ListImagesInput{
+		RepositoryName: name,
+		MaxResults:     aws.Int64(1),
+	})
+	if err != nil {
+		return nil
+	}
+
+	return aws.Bool(len(resp.ImageIds) == 0)
+}
+
 func (r *ECRRepository) Filter() error {
 	return nil
 }
@@ -61,6 +77,14 @@ func (r *ECRRepository) Remove() error {
 	return err
 }
 
+func (r *ECRRepository) LazyProperty(key string) (string, error) {
+	if r.lazy == nil {
+		r.lazy = types.NewProperties().
+			Set("Empty", isECRRepositoryEmpty(r.svc, r.name))
+	}
+	return r.lazy.Get(key), nil
+}
+
 func (r *ECRRepository) String() string {
 	return fmt.Sprintf("Repository: %s", *r.name)
 }
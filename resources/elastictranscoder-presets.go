@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elastictranscoder"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ElasticTranscoderPreset struct {
+	svc        *elastictranscoder.ElasticTranscoder
+	presetID   *string
+	name       *string
+	presetType *string
+}
+
+func init() {
+	register("ElasticTranscoderPreset", ListElasticTranscoderPresets)
+}
+
+func ListElasticTranscoderPresets(sess *session.Session) ([]Resource, error) {
+	svc := elastictranscoder.New(sess)
+	resources := []Resource{}
+
+	params := &elastictranscoder.ListPresetsInput{}
+
+	for {
+		resp, err := svc.ListPresets(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, preset := range resp.Presets {
+			resources = append(resources, &ElasticTranscoderPreset{
+				svc:        svc,
+				presetID:   preset.Id,
+				name:       preset.Name,
+				presetType: preset.Type,
+			})
+		}
+
+		if resp.NextPageToken == nil {
+			break
+		}
+
+		params.PageToken = resp.NextPageToken
+	}
+
+	return resources, nil
+}
+
+func (f *ElasticTranscoderPreset) Filter() error {
+	if aws.StringValue(f.presetType) == "System" {
+		return fmt.Errorf("cannot delete system preset")
+	}
+	return nil
+}
+
+func (f *ElasticTranscoderPreset) Remove() error {
+	_, err := f.svc.DeletePreset(&elastictranscoder.DeletePresetInput{
+		Id: f.presetID,
+	})
+
+	return err
+}
+
+func (f *ElasticTranscoderPreset) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.presetID).
+		Set("Name", f.name).
+		Set("Type", f.presetType)
+}
+
+func (f *ElasticTranscoderPreset) String() string {
+	return *f.presetID
+}
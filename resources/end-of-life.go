@@ -0,0 +1,50 @@
+package resources
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// EndOfLife describes a service, whose deprecation was announced by AWS. The
+// list is maintained by hand in end-of-life.json, keyed by the SDK package of
+// the service.
+type EndOfLife struct {
+	// Date is the day the service stops working or stops being supported. It
+	// is empty, if AWS only stopped the onboarding of new customers.
+	Date string `json:"date,omitempty"`
+
+	// Notice is a short explanation of the deprecation.
+	Notice string `json:"notice"`
+}
+
+func (e EndOfLife) String() string {
+	if e.Date == "" {
+		return e.Notice
+	}
+	return fmt.Sprintf("end of life on %s, %s", e.Date, e.Notice)
+}
+
+//go:embed end-of-life.json
+var rawEndOfLife []byte
+
+var endOfLife map[string]EndOfLife
+
+func init() {
+	err := json.Unmarshal(rawEndOfLife, &endOfLife)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded end of life data: %v", err))
+	}
+}
+
+// GetEndOfLife returns the deprecation of the service of the resource type.
+// The second return value is false, if no deprecation is known.
+func GetEndOfLife(name string) (EndOfLife, bool) {
+	m, ok := metadata[name]
+	if !ok {
+		return EndOfLife{}, false
+	}
+
+	eol, ok := endOfLife[m.Service]
+	return eol, ok
+}
@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/finspace"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type FinSpaceEnvironment struct {
+	svc           *finspace.Finspace
+	environmentID *string
+	name          *string
+	status        *string
+}
+
+func init() {
+	register("FinSpaceEnvironment", ListFinSpaceEnvironments)
+}
+
+func ListFinSpaceEnvironments(sess *session.Session) ([]Resource, error) {
+	svc := finspace.New(sess)
+	resources := []Resource{}
+
+	params := &finspace.ListEnvironmentsInput{}
+
+	for {
+		output, err := svc.ListEnvironments(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, environment := range output.Environments {
+			if aws.StringValue(environment.Status) == finspace.EnvironmentStatusDeleted {
+				continue
+			}
+
+			resources = append(resources, &FinSpaceEnvironment{
+				svc:           svc,
+				environmentID: environment.EnvironmentId,
+				name:          environment.Name,
+				status:        environment.Status,
+			})
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+
+		params.NextToken = output.NextToken
+	}
+
+	return resources, nil
+}
+
+func (f *FinSpaceEnvironment) Remove() error {
+	_, err := f.svc.DeleteEnvironment(&finspace.DeleteEnvironmentInput{
+		EnvironmentId: f.environmentID,
+	})
+
+	return err
+}
+
+func (f *FinSpaceEnvironment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("EnvironmentID", f.environmentID).
+		Set("Name", f.name).
+		Set("Status", f.status)
+}
+
+func (f *FinSpaceEnvironment) String() string {
+	return *f.environmentID
+}
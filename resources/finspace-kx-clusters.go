@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/finspace"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type FinSpaceKxCluster struct {
+	svc           *finspace.Finspace
+	environmentID *string
+	name          *string
+	clusterType   *string
+	status        *string
+}
+
+func init() {
+	register("FinSpaceKxCluster", ListFinSpaceKxClusters)
+}
+
+func ListFinSpaceKxClusters(sess *session.Session) ([]Resource, error) {
+	svc := finspace.New(sess)
+	resources := []Resource{}
+
+	environments, err := listFinSpaceKxEnvironments(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environment := range environments {
+		params := &finspace.ListKxClustersInput{
+			EnvironmentId: environment.EnvironmentId,
+		}
+
+		for {
+			output, err := svc.ListKxClusters(params)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, cluster := range output.KxClusterSummaries {
+				if aws.StringValue(cluster.Status) == finspace.KxClusterStatusDeleted {
+					continue
+				}
+
+				resources = append(resources, &FinSpaceKxCluster{
+					svc:           svc,
+					environmentID: environment.EnvironmentId,
+					name:          cluster.ClusterName,
+					clusterType:   cluster.ClusterType,
+					status:        cluster.Status,
+				})
+			}
+
+			if output.NextToken == nil {
+				break
+			}
+
+			params.NextToken = output.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+func (f *FinSpaceKxCluster) Remove() error {
+	_, err := f.svc.DeleteKxCluster(&finspace.DeleteKxClusterInput{
+		EnvironmentId: f.environmentID,
+		ClusterName:   f.name,
+	})
+
+	return err
+}
+
+func (f *FinSpaceKxCluster) Properties() types.Properties {
+	return types.NewProperties().
+		Set("EnvironmentID", f.environmentID).
+		Set("Name", f.name).
+		Set("Type", f.clusterType).
+		Set("Status", f.status)
+}
+
+func (f *FinSpaceKxCluster) String() string {
+	return fmt.Sprintf("%s -> %s", *f.environmentID, *f.name)
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/finspace"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type FinSpaceKxEnvironment struct {
+	svc           *finspace.Finspace
+	environmentID *string
+	name          *string
+	status        *string
+}
+
+func init() {
+	register("FinSpaceKxEnvironment", ListFinSpaceKxEnvironments)
+}
+
+func ListFinSpaceKxEnvironments(sess *session.Session) ([]Resource, error) {
+	svc := finspace.New(sess)
+	resources := []Resource{}
+
+	environments, err := listFinSpaceKxEnvironments(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environment := range environments {
+		resources = append(resources, &FinSpaceKxEnvironment{
+			svc:           svc,
+			environmentID: environment.EnvironmentId,
+			name:          environment.Name,
+			status:        environment.Status,
+		})
+	}
+
+	return resources, nil
+}
+
+// listFinSpaceKxEnvironments returns all kdb environments, which are not
+// deleted yet.
+func listFinSpaceKxEnvironments(svc *finspace.Finspace) ([]*finspace.KxEnvironment, error) {
+	environments := []*finspace.KxEnvironment{}
+
+	err := svc.ListKxEnvironmentsPages(&finspace.ListKxEnvironmentsInput{},
+		func(page *finspace.ListKxEnvironmentsOutput, lastPage bool) bool {
+			for _, environment := range page.Environments {
+				if aws.StringValue(environment.Status) == finspace.EnvironmentStatusDeleted {
+					continue
+				}
+				environments = append(environments, environment)
+			}
+			return true
+		})
+
+	return environments, err
+}
+
+// Remove deletes the environment. This fails until its clusters are
+// deleted.
+func (f *FinSpaceKxEnvironment) Remove() error {
+	_, err := f.svc.DeleteKxEnvironment(&finspace.DeleteKxEnvironmentInput{
+		EnvironmentId: f.environmentID,
+	})
+
+	return err
+}
+
+func (f *FinSpaceKxEnvironment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("EnvironmentID", f.environmentID).
+		Set("Name", f.name).
+		Set("Status", f.status)
+}
+
+func (f *FinSpaceKxEnvironment) String() string {
+	return *f.environmentID
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/fis"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type FISExperimentTemplate struct {
+	svc         *fis.FIS
+	id          *string
+	description *string
+	tags        map[string]*string
+}
+
+func init() {
+	register("FISExperimentTemplate", ListFISExperimentTemplates)
+}
+
+func ListFISExperimentTemplates(sess *session.Session) ([]Resource, error) {
+	svc := fis.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListExperimentTemplatesPages(&fis.ListExperimentTemplatesInput{},
+		func(page *fis.ListExperimentTemplatesOutput, lastPage bool) bool {
+			for _, template := range page.ExperimentTemplates {
+				resources = append(resources, &FISExperimentTemplate{
+					svc:         svc,
+					id:          template.Id,
+					description: template.Description,
+					tags:        template.Tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *FISExperimentTemplate) Remove() error {
+	_, err := f.svc.DeleteExperimentTemplate(&fis.DeleteExperimentTemplateInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *FISExperimentTemplate) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("ID", f.id).
+		Set("Description", f.description)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *FISExperimentTemplate) String() string {
+	return *f.id
+}
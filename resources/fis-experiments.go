@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/fis"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// FISExperiment is a running experiment. Finished experiments are kept by
+// FIS and cannot be deleted, so only active experiments are listed and
+// removing them stops them.
+type FISExperiment struct {
+	svc        *fis.FIS
+	id         *string
+	templateID *string
+	status     *string
+	tags       map[string]*string
+}
+
+func init() {
+	register("FISExperiment", ListFISExperiments)
+}
+
+func ListFISExperiments(sess *session.Session) ([]Resource, error) {
+	svc := fis.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListExperimentsPages(&fis.ListExperimentsInput{},
+		func(page *fis.ListExperimentsOutput, lastPage bool) bool {
+			for _, experiment := range page.Experiments {
+				if experiment.State == nil {
+					continue
+				}
+
+				switch aws.StringValue(experiment.State.Status) {
+				case fis.ExperimentStatusPending, fis.ExperimentStatusInitiating, fis.ExperimentStatusRunning:
+				default:
+					continue
+				}
+
+				resources = append(resources, &FISExperiment{
+					svc:        svc,
+					id:         experiment.Id,
+					templateID: experiment.ExperimentTemplateId,
+					status:     experiment.State.Status,
+					tags:       experiment.Tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *FISExperiment) Remove() error {
+	_, err := f.svc.StopExperiment(&fis.StopExperimentInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *FISExperiment) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("ID", f.id).
+		Set("ExperimentTemplateID", f.templateID).
+		Set("Status", f.status)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *FISExperiment) String() string {
+	return *f.id
+}
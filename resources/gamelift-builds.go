@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftBuild struct {
+	svc     *gamelift.GameLift
+	buildID *string
+	name    *string
+	version *string
+	status  *string
+}
+
+func init() {
+	register("GameLiftBuild", ListGameLiftBuilds)
+}
+
+func ListGameLiftBuilds(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListBuildsPages(&gamelift.ListBuildsInput{},
+		func(page *gamelift.ListBuildsOutput, lastPage bool) bool {
+			for _, build := range page.Builds {
+				resources = append(resources, &GameLiftBuild{
+					svc:     svc,
+					buildID: build.BuildId,
+					name:    build.Name,
+					version: build.Version,
+					status:  build.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *GameLiftBuild) Remove() error {
+	_, err := f.svc.DeleteBuild(&gamelift.DeleteBuildInput{
+		BuildId: f.buildID,
+	})
+
+	return err
+}
+
+func (f *GameLiftBuild) Properties() types.Properties {
+	return types.NewProperties().
+		Set("BuildID", f.buildID).
+		Set("Name", f.name).
+		Set("Version", f.version).
+		Set("Status", f.status)
+}
+
+func (f *GameLiftBuild) String() string {
+	return *f.buildID
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftFleet struct {
+	svc       *gamelift.GameLift
+	fleetID   *string
+	name      *string
+	status    *string
+	fleetType *string
+}
+
+func init() {
+	register("GameLiftFleet", ListGameLiftFleets)
+}
+
+func ListGameLiftFleets(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	fleetIDs := []*string{}
+	err := svc.ListFleetsPages(&gamelift.ListFleetsInput{},
+		func(page *gamelift.ListFleetsOutput, lastPage bool) bool {
+			fleetIDs = append(fleetIDs, page.FleetIds...)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fleetIDs) == 0 {
+		return resources, nil
+	}
+
+	err = svc.DescribeFleetAttributesPages(&gamelift.DescribeFleetAttributesInput{
+		FleetIds: fleetIDs,
+	}, func(page *gamelift.DescribeFleetAttributesOutput, lastPage bool) bool {
+		for _, fleet := range page.FleetAttributes {
+			resources = append(resources, &GameLiftFleet{
+				svc:       svc,
+				fleetID:   fleet.FleetId,
+				name:      fleet.Name,
+				status:    fleet.Status,
+				fleetType: fleet.FleetType,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *GameLiftFleet) Filter() error {
+	if *f.status == gamelift.FleetStatusTerminated {
+		return fmt.Errorf("already terminated")
+	}
+	return nil
+}
+
+// Remove deletes the fleet and terminates its instances. This fails as long as
+// the fleet has active game sessions.
+func (f *GameLiftFleet) Remove() error {
+	_, err := f.svc.DeleteFleet(&gamelift.DeleteFleetInput{
+		FleetId: f.fleetID,
+	})
+
+	return err
+}
+
+func (f *GameLiftFleet) Properties() types.Properties {
+	return types.NewProperties().
+		Set("FleetID", f.fleetID).
+		Set("Name", f.name).
+		Set("Status", f.status).
+		Set("FleetType", f.fleetType)
+}
+
+func (f *GameLiftFleet) String() string {
+	return *f.fleetID
+}
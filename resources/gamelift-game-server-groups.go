@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftGameServerGroup struct {
+	svc    *gamelift.GameLift
+	name   *string
+	arn    *string
+	status *string
+}
+
+func init() {
+	register("GameLiftGameServerGroup", ListGameLiftGameServerGroups)
+}
+
+func ListGameLiftGameServerGroups(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListGameServerGroupsPages(&gamelift.ListGameServerGroupsInput{},
+		func(page *gamelift.ListGameServerGroupsOutput, lastPage bool) bool {
+			for _, group := range page.GameServerGroups {
+				resources = append(resources, &GameLiftGameServerGroup{
+					svc:    svc,
+					name:   group.GameServerGroupName,
+					arn:    group.GameServerGroupArn,
+					status: group.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *GameLiftGameServerGroup) Filter() error {
+	if aws.StringValue(f.status) == gamelift.GameServerGroupStatusDeleted {
+		return fmt.Errorf("already deleted")
+	}
+	return nil
+}
+
+// Remove deletes the game server group including its Auto Scaling group and
+// instances, even if game servers are still running on them.
+func (f *GameLiftGameServerGroup) Remove() error {
+	_, err := f.svc.DeleteGameServerGroup(&gamelift.DeleteGameServerGroupInput{
+		GameServerGroupName: f.name,
+		DeleteOption:        aws.String(gamelift.GameServerGroupDeleteOptionForceDelete),
+	})
+
+	return err
+}
+
+func (f *GameLiftGameServerGroup) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("ARN", f.arn).
+		Set("Status", f.status)
+}
+
+func (f *GameLiftGameServerGroup) String() string {
+	return *f.name
+}
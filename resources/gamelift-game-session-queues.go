@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftGameSessionQueue struct {
+	svc  *gamelift.GameLift
+	name *string
+	arn  *string
+}
+
+func init() {
+	register("GameLiftGameSessionQueue", ListGameLiftGameSessionQueues)
+}
+
+func ListGameLiftGameSessionQueues(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeGameSessionQueuesPages(&gamelift.DescribeGameSessionQueuesInput{},
+		func(page *gamelift.DescribeGameSessionQueuesOutput, lastPage bool) bool {
+			for _, queue := range page.GameSessionQueues {
+				resources = append(resources, &GameLiftGameSessionQueue{
+					svc:  svc,
+					name: queue.Name,
+					arn:  queue.GameSessionQueueArn,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the queue. This fails as long as a matchmaking configuration
+// uses it.
+func (f *GameLiftGameSessionQueue) Remove() error {
+	_, err := f.svc.DeleteGameSessionQueue(&gamelift.DeleteGameSessionQueueInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *GameLiftGameSessionQueue) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("ARN", f.arn)
+}
+
+func (f *GameLiftGameSessionQueue) String() string {
+	return *f.name
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftMatchmakingConfiguration struct {
+	svc         *gamelift.GameLift
+	name        *string
+	ruleSetName *string
+}
+
+func init() {
+	register("GameLiftMatchmakingConfiguration", ListGameLiftMatchmakingConfigurations)
+}
+
+func ListGameLiftMatchmakingConfigurations(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeMatchmakingConfigurationsPages(&gamelift.DescribeMatchmakingConfigurationsInput{},
+		func(page *gamelift.DescribeMatchmakingConfigurationsOutput, lastPage bool) bool {
+			for _, configuration := range page.Configurations {
+				resources = append(resources, &GameLiftMatchmakingConfiguration{
+					svc:         svc,
+					name:        configuration.Name,
+					ruleSetName: configuration.RuleSetName,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *GameLiftMatchmakingConfiguration) Remove() error {
+	_, err := f.svc.DeleteMatchmakingConfiguration(&gamelift.DeleteMatchmakingConfigurationInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *GameLiftMatchmakingConfiguration) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("RuleSetName", f.ruleSetName)
+}
+
+func (f *GameLiftMatchmakingConfiguration) String() string {
+	return *f.name
+}
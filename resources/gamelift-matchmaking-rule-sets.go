@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftMatchmakingRuleSet struct {
+	svc  *gamelift.GameLift
+	name *string
+}
+
+func init() {
+	register("GameLiftMatchmakingRuleSet", ListGameLiftMatchmakingRuleSets)
+}
+
+func ListGameLiftMatchmakingRuleSets(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeMatchmakingRuleSetsPages(&gamelift.DescribeMatchmakingRuleSetsInput{},
+		func(page *gamelift.DescribeMatchmakingRuleSetsOutput, lastPage bool) bool {
+			for _, ruleSet := range page.RuleSets {
+				resources = append(resources, &GameLiftMatchmakingRuleSet{
+					svc:  svc,
+					name: ruleSet.RuleSetName,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the rule set. This fails as long as a matchmaking
+// configuration uses it.
+func (f *GameLiftMatchmakingRuleSet) Remove() error {
+	_, err := f.svc.DeleteMatchmakingRuleSet(&gamelift.DeleteMatchmakingRuleSetInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *GameLiftMatchmakingRuleSet) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name)
+}
+
+func (f *GameLiftMatchmakingRuleSet) String() string {
+	return *f.name
+}
@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/gamelift"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type GameLiftScript struct {
+	svc      *gamelift.GameLift
+	scriptID *string
+	name     *string
+	version  *string
+}
+
+func init() {
+	register("GameLiftScript", ListGameLiftScripts)
+}
+
+func ListGameLiftScripts(sess *session.Session) ([]Resource, error) {
+	svc := gamelift.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListScriptsPages(&gamelift.ListScriptsInput{},
+		func(page *gamelift.ListScriptsOutput, lastPage bool) bool {
+			for _, script := range page.Scripts {
+				resources = append(resources, &GameLiftScript{
+					svc:      svc,
+					scriptID: script.ScriptId,
+					name:     script.Name,
+					version:  script.Version,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *GameLiftScript) Remove() error {
+	_, err := f.svc.DeleteScript(&gamelift.DeleteScriptInput{
+		ScriptId: f.scriptID,
+	})
+
+	return err
+}
+
+func (f *GameLiftScript) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ScriptID", f.scriptID).
+		Set("Name", f.name).
+		Set("Version", f.version)
+}
+
+func (f *GameLiftScript) String() string {
+	return *f.scriptID
+}
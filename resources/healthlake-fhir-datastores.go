@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/healthlake"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type HealthLakeFHIRDatastore struct {
+	svc    *healthlake.HealthLake
+	id     *string
+	name   *string
+	status *string
+}
+
+func init() {
+	register("HealthLakeFHIRDatastore", ListHealthLakeFHIRDatastores)
+}
+
+func ListHealthLakeFHIRDatastores(sess *session.Session) ([]Resource, error) {
+	svc := healthlake.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListFHIRDatastoresPages(&healthlake.ListFHIRDatastoresInput{},
+		func(page *healthlake.ListFHIRDatastoresOutput, lastPage bool) bool {
+			for _, datastore := range page.DatastorePropertiesList {
+				switch aws.StringValue(datastore.DatastoreStatus) {
+				case healthlake.DatastoreStatusDeleting, healthlake.DatastoreStatusDeleted:
+					continue
+				}
+
+				resources = append(resources, &HealthLakeFHIRDatastore{
+					svc:    svc,
+					id:     datastore.DatastoreId,
+					name:   datastore.DatastoreName,
+					status: datastore.DatastoreStatus,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *HealthLakeFHIRDatastore) Remove() error {
+	_, err := f.svc.DeleteFHIRDatastore(&healthlake.DeleteFHIRDatastoreInput{
+		DatastoreId: f.id,
+	})
+
+	return err
+}
+
+func (f *HealthLakeFHIRDatastore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("Status", f.status)
+}
+
+func (f *HealthLakeFHIRDatastore) String() string {
+	return *f.id
+}
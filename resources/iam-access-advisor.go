@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+const (
+	// iamAccessAdvisorPollInterval is the time between polls of a running
+	// access advisor job.
+	iamAccessAdvisorPollInterval = time.Second
+
+	// iamAccessAdvisorTimeout is the maximum time to wait for an access
+	// advisor job.
+	iamAccessAdvisorTimeout = time.Minute
+)
+
+// getIAMLastAccessed returns when the IAM user or role with the given ARN
+// accessed any service last, according to the IAM access advisor. It returns
+// nil, if the entity did not access any service within the tracking period.
+func getIAMLastAccessed(svc *iam.IAM, arn *string) (*time.Time, error) {
+	job, err := svc.GenerateServiceLastAccessedDetails(&iam.GenerateServiceLastAccessedDetailsInput{
+		Arn: arn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := &iam.GetServiceLastAccessedDetailsInput{
+		JobId: job.JobId,
+	}
+	deadline := time.Now().Add(iamAccessAdvisorTimeout)
+
+	var lastAccessed *time.Time
+	for {
+		resp, err := svc.GetServiceLastAccessedDetails(params)
+		if err != nil {
+			return nil, err
+		}
+
+		switch aws.StringValue(resp.JobStatus) {
+		case iam.JobStatusTypeInProgress:
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("the access advisor job for %s did not finish within %v",
+					aws.StringValue(arn), iamAccessAdvisorTimeout)
+			}
+			time.Sleep(iamAccessAdvisorPollInterval)
+			continue
+		case iam.JobStatusTypeFailed:
+			return nil, fmt.Errorf("the access advisor job for %s failed", aws.StringValue(arn))
+		}
+
+		for _, service := range resp.ServicesLastAccessed {
+			lastAccessed = latestTime(lastAccessed, service.LastAuthenticated)
+		}
+
+		if !aws.BoolValue(resp.IsTruncated) {
+			return lastAccessed, nil
+		}
+		params.Marker = resp.Marker
+	}
+}
@@ -3,6 +3,7 @@ package resources
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -14,6 +15,10 @@ type IAMRole struct {
 	role *iam.Role
 	name string
 	path string
+
+	lastAccessed       *time.Time
+	lastAccessedErr    error
+	lastAccessedLoaded bool
 }
 
 func init() {
@@ -75,15 +80,49 @@ func (e *IAMRole) Remove() error {
 	return nil
 }
 
+func (e *IAMRole) LastActivity() (time.Time, bool) {
+	activity := e.role.CreateDate
+	if e.role.RoleLastUsed != nil {
+		activity = latestTime(activity, e.role.RoleLastUsed.LastUsedDate)
+	}
+
+	if activity == nil {
+		return time.Time{}, false
+	}
+	return *activity, true
+}
+
+func (e *IAMRole) Raw() interface{} {
+	return e.role
+}
+
 func (role *IAMRole) Properties() types.Properties {
 	properties := types.NewProperties()
 	for _, tagValue := range role.role.Tags {
 		properties.SetTag(tagValue.Key, tagValue.Value)
 	}
 	properties.Set("Name", role.name)
+	if role.role.RoleLastUsed != nil {
+		properties.Set("LastUsed", role.role.RoleLastUsed.LastUsedDate)
+		properties.Set("LastUsedRegion", role.role.RoleLastUsed.Region)
+	}
 	return properties
 }
 
+func (e *IAMRole) LazyProperty(key string) (string, error) {
+	if !e.lastAccessedLoaded {
+		e.lastAccessed, e.lastAccessedErr = getIAMLastAccessed(e.svc, e.role.Arn)
+		e.lastAccessedLoaded = true
+	}
+	if e.lastAccessedErr != nil {
+		return "", e.lastAccessedErr
+	}
+
+	return types.NewProperties().
+		Set("LastAccessed", e.lastAccessed).
+		Get(key), nil
+}
+
 func (e *IAMRole) String() string {
 	return e.name
 }
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -13,6 +14,10 @@ type IAMUserAccessKey struct {
 	accessKeyId string
 	userName    string
 	status      string
+	createDate  *time.Time
+
+	lastUsed       *iam.AccessKeyLastUsed
+	lastUsedLoaded bool
 }
 
 func init() {
@@ -43,6 +48,7 @@ func ListIAMUserAccessKeys(sess *session.Session) ([]Resource, error) {
 				accessKeyId: *meta.AccessKeyId,
 				userName:    *meta.UserName,
 				status:      *meta.Status,
+				createDate:  meta.CreateDate,
 			})
 		}
 	}
@@ -50,6 +56,19 @@ func ListIAMUserAccessKeys(sess *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+// getIAMAccessKeyLastUsed returns when and where the access key was used
+// last. It returns nil, if this cannot be determined.
+func getIAMAccessKeyLastUsed(svc *iam.IAM, accessKeyID *string) *iam.AccessKeyLastUsed {
+	resp, err := svc.GetAccessKeyLastUsed(&iam.GetAccessKeyLastUsedInput{
+		AccessKeyId: accessKeyID,
+	})
+	if err != nil {
+		return nil
+	}
+
+	return resp.AccessKeyLastUsed
+}
+
 func (e *IAMUserAccessKey) Remove() error {
 	_, err := e.svc.DeleteAccessKey(
 		&iam.DeleteAccessKeyInput{
@@ -63,12 +82,42 @@ func (e *IAMUserAccessKey) Remove() error {
 	return nil
 }
 
+// loadLastUsed returns the last use of the access key. It is only read once.
+func (e *IAMUserAccessKey) loadLastUsed() *iam.AccessKeyLastUsed {
+	if !e.lastUsedLoaded {
+		e.lastUsed = getIAMAccessKeyLastUsed(e.svc, &e.accessKeyId)
+		e.lastUsedLoaded = true
+	}
+	return e.lastUsed
+}
+
+func (e *IAMUserAccessKey) LastActivity() (time.Time, bool) {
+	lastUsed := e.loadLastUsed()
+	if lastUsed == nil || e.createDate == nil {
+		return time.Time{}, false
+	}
+
+	return *latestTime(e.createDate, lastUsed.LastUsedDate), true
+}
+
 func (e *IAMUserAccessKey) Properties() types.Properties {
 	return types.NewProperties().
 		Set("UserName", e.userName).
 		Set("AccessKeyID", e.accessKeyId)
 }
 
+func (e *IAMUserAccessKey) LazyProperty(key string) (string, error) {
+	lastUsed := e.loadLastUsed()
+	if lastUsed == nil {
+		return "", fmt.Errorf("failed to get the last use of access key %s", e.accessKeyId)
+	}
+
+	return types.NewProperties().
+		Set("LastUsed", lastUsed.LastUsedDate).
+		Set("LastUsedService", lastUsed.ServiceName).
+		Get(key), nil
+}
+
 func (e *IAMUserAccessKey) String() string {
 	return fmt.Sprintf("%s -> %s", e.userName, e.accessKeyId)
 }
@@ -1,13 +1,31 @@
 package resources
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type IAMUser struct {
-	svc  *iam.IAM
-	name string
+	svc              *iam.IAM
+	name             string
+	arn              *string
+	createDate       *time.Time
+	passwordLastUsed *time.Time
+
+	lastUsed       *time.Time
+	lastUsedLoaded bool
+
+	// lastUsedErr is set, if the last use of the access keys could not be
+	// determined.
+	lastUsedErr error
+
+	lastAccessed       *time.Time
+	lastAccessedErr    error
+	lastAccessedLoaded bool
 }
 
 func init() {
@@ -25,14 +43,39 @@ func ListIAMUsers(sess *session.Session) ([]Resource, error) {
 	resources := make([]Resource, 0)
 	for _, out := range resp.Users {
 		resources = append(resources, &IAMUser{
-			svc:  svc,
-			name: *out.UserName,
+			svc:              svc,
+			name:             *out.UserName,
+			arn:              out.Arn,
+			createDate:       out.CreateDate,
+			passwordLastUsed: out.PasswordLastUsed,
 		})
 	}
 
 	return resources, nil
 }
 
+// getIAMUserAccessKeysLastUsed returns when any access key of the user was
+// used last. It returns nil, if none of them was used.
+func getIAMUserAccessKeysLastUsed(svc *iam.IAM, userName *string) (*time.Time, error) {
+	resp, err := svc.ListAccessKeys(&iam.ListAccessKeysInput{
+		UserName: userName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastUsed *time.Time
+	for _, meta := range resp.AccessKeyMetadata {
+		keyLastUsed := getIAMAccessKeyLastUsed(svc, meta.AccessKeyId)
+		if keyLastUsed == nil {
+			return nil, fmt.Errorf("failed to get the last use of access key %s", *meta.AccessKeyId)
+		}
+		lastUsed = latestTime(lastUsed, keyLastUsed.LastUsedDate)
+	}
+
+	return lastUsed, nil
+}
+
 func (e *IAMUser) Remove() error {
 	_, err := e.svc.DeleteUser(&iam.DeleteUserInput{
 		UserName: &e.name,
@@ -44,6 +87,61 @@ func (e *IAMUser) Remove() error {
 	return nil
 }
 
+// loadLastUsed determines the most recent use of either the password or one
+// of the access keys. It is only determined once.
+func (e *IAMUser) loadLastUsed() {
+	if e.lastUsedLoaded {
+		return
+	}
+
+	keysLastUsed, err := getIAMUserAccessKeysLastUsed(e.svc, &e.name)
+	e.lastUsed = latestTime(e.passwordLastUsed, keysLastUsed)
+	e.lastUsedErr = err
+	e.lastUsedLoaded = true
+}
+
+// loadLastAccessed determines the last access of any service with the access
+// advisor. It is only determined once, since each call waits for a report.
+func (e *IAMUser) loadLastAccessed() {
+	if e.lastAccessedLoaded {
+		return
+	}
+
+	e.lastAccessed, e.lastAccessedErr = getIAMLastAccessed(e.svc, e.arn)
+	e.lastAccessedLoaded = true
+}
+
+func (e *IAMUser) LastActivity() (time.Time, bool) {
+	e.loadLastUsed()
+	if e.lastUsedErr != nil || e.createDate == nil {
+		return time.Time{}, false
+	}
+
+	return *latestTime(e.createDate, e.lastUsed), true
+}
+
+func (e *IAMUser) LazyProperty(key string) (string, error) {
+	switch key {
+	case "LastAccessed":
+		e.loadLastAccessed()
+		if e.lastAccessedErr != nil {
+			return "", e.lastAccessedErr
+		}
+		return types.NewProperties().Set("LastAccessed", e.lastAccessed).Get(key), nil
+
+	case "LastUsed":
+		e.loadLastUsed()
+		if e.lastUsedErr != nil {
+			return "", e.lastUsedErr
+		}
+		return types.NewProperties().Set("LastUsed", e.lastUsed).Get(key), nil
+	}
+
+	return types.NewProperties().
+		Set("PasswordLastUsed", e.passwordLastUsed).
+		Get(key), nil
+}
+
 func (e *IAMUser) String() string {
 	return e.name
 }
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/rebuy-de/aws-nuke/pkg/config"
@@ -31,6 +32,51 @@ type ResourcePropertyGetter interface {
 	Properties() types.Properties
 }
 
+// RawProperty is the name of the property, which contains the API response of
+// resources implementing RawGetter as JSON. Single fields are accessed with
+// a dot separated path, eg _raw.State.Name.
+const RawProperty = "_raw"
+
+// RawGetter is implemented by resources, which keep the API response they
+// were listed from. Filters can access it with the _raw property.
+type RawGetter interface {
+	Resource
+	Raw() interface{}
+}
+
+// LazyPropertyGetter is implemented by resources with properties, which need
+// additional API calls per resource. They are only determined, if a filter
+// uses them, and are neither part of the output nor of reports.
+type LazyPropertyGetter interface {
+	Resource
+
+	// LazyProperty returns the value of the lazy property with the given key.
+	// It returns an error, if the value could not be determined.
+	LazyProperty(key string) (string, error)
+}
+
+// RemoveChecker is implemented by resources, which can tell in advance that
+// their removal is going to fail. It is used by --deep-dry-run.
+type RemoveChecker interface {
+	Resource
+
+	// CheckRemove returns an error, if the removal is blocked. The message
+	// describes the condition which has to be met first (eg "deletion
+	// protection is disabled").
+	CheckRemove() error
+}
+
+// ActivityGetter is implemented by resources, which know when they were used
+// last. It is used by --only-idle.
+type ActivityGetter interface {
+	Resource
+
+	// LastActivity returns the time of the last observed activity, which is
+	// at least the creation of the resource. It returns false, if this
+	// cannot be determined.
+	LastActivity() (time.Time, bool)
+}
+
 type FeatureFlagGetter interface {
 	Resource
 	FeatureFlags(config.FeatureFlags)
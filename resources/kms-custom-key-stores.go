@@ -0,0 +1,86 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// KMSCustomKeyStore is a key store backed by a CloudHSM cluster or by an
+// external key manager behind an XKS proxy. The proxy configuration is part
+// of the key store and is removed with it.
+type KMSCustomKeyStore struct {
+	svc             *kms.KMS
+	id              *string
+	name            *string
+	storeType       *string
+	connectionState *string
+	xksProxyURI     *string
+}
+
+func init() {
+	register("KMSCustomKeyStore", ListKMSCustomKeyStores)
+}
+
+func ListKMSCustomKeyStores(sess *session.Session) ([]Resource, error) {
+	svc := kms.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeCustomKeyStoresPages(&kms.DescribeCustomKeyStoresInput{},
+		func(page *kms.DescribeCustomKeyStoresOutput, lastPage bool) bool {
+			for _, store := range page.CustomKeyStores {
+				r := &KMSCustomKeyStore{
+					svc:             svc,
+					id:              store.CustomKeyStoreId,
+					name:            store.CustomKeyStoreName,
+					storeType:       store.CustomKeyStoreType,
+					connectionState: store.ConnectionState,
+				}
+				if store.XksProxyConfiguration != nil {
+					r.xksProxyURI = store.XksProxyConfiguration.UriEndpoint
+				}
+				resources = append(resources, r)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove disconnects the key store and deletes it afterwards. The deletion
+// fails as long as the key store contains KMS keys, including keys which are
+// pending deletion.
+func (f *KMSCustomKeyStore) Remove() error {
+	if aws.StringValue(f.connectionState) != kms.ConnectionStateTypeDisconnected {
+		_, err := f.svc.DisconnectCustomKeyStore(&kms.DisconnectCustomKeyStoreInput{
+			CustomKeyStoreId: f.id,
+		})
+		if err != nil {
+			return err
+		}
+		f.connectionState = aws.String(kms.ConnectionStateTypeDisconnected)
+	}
+
+	_, err := f.svc.DeleteCustomKeyStore(&kms.DeleteCustomKeyStoreInput{
+		CustomKeyStoreId: f.id,
+	})
+
+	return err
+}
+
+func (f *KMSCustomKeyStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("Type", f.storeType).
+		Set("ConnectionState", f.connectionState).
+		Set("XksProxyURI", f.xksProxyURI)
+}
+
+func (f *KMSCustomKeyStore) String() string {
+	return *f.name
+}
@@ -51,6 +51,10 @@ func (m *LambdaEventSourceMapping) Remove() error {
 	return err
 }
 
+func (m *LambdaEventSourceMapping) Raw() interface{} {
+	return m.mapping
+}
+
 func (m *LambdaEventSourceMapping) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("UUID", m.mapping.UUID)
@@ -1,15 +1,32 @@
 package resources
 
 import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
+// lambdaInvocationLookback is how far back the invocation metrics of the
+// functions are queried.
+const lambdaInvocationLookback = 90 * 24 * time.Hour
+
 type LambdaFunction struct {
 	svc          *lambda.Lambda
+	metrics      *cloudwatch.CloudWatch
 	functionName *string
 	tags         map[string]*string
+	lastModified *string
+
+	lastInvocation    *time.Time
+	invocationsLoaded bool
+
+	// invocationsKnown is false, if the invocation metrics could not be
+	// read.
+	invocationsKnown bool
 }
 
 func init() {
@@ -18,6 +35,7 @@ func init() {
 
 func ListLambdaFunctions(sess *session.Session) ([]Resource, error) {
 	svc := lambda.New(sess)
+	metrics := cloudwatch.New(sess)
 
 	params := &lambda.ListFunctionsInput{}
 	resp, err := svc.ListFunctions(params)
@@ -37,14 +55,47 @@ func ListLambdaFunctions(sess *session.Session) ([]Resource, error) {
 
 		resources = append(resources, &LambdaFunction{
 			svc:          svc,
+			metrics:      metrics,
 			functionName: function.FunctionName,
 			tags:         tags.Tags,
+			lastModified: function.LastModified,
 		})
 	}
 
 	return resources, nil
 }
 
+// getLambdaLastInvocation returns the day of the last invocation of the
+// function within the lookback period, based on its CloudWatch metrics. It
+// returns nil, if the function was not invoked.
+func getLambdaLastInvocation(metrics *cloudwatch.CloudWatch, functionName *string) (*time.Time, error) {
+	now := time.Now()
+	resp, err := metrics.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/Lambda"),
+		MetricName: aws.String("Invocations"),
+		Dimensions: []*cloudwatch.Dimension{{
+			Name:  aws.String("FunctionName"),
+			Value: functionName,
+		}},
+		StartTime:  aws.Time(now.Add(-lambdaInvocationLookback)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int64(int64((24 * time.Hour).Seconds())),
+		Statistics: aws.StringSlice([]string{cloudwatch.StatisticSum}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastInvocation *time.Time
+	for _, datapoint := range resp.Datapoints {
+		if aws.Float64Value(datapoint.Sum) > 0 {
+			lastInvocation = latestTime(lastInvocation, datapoint.Timestamp)
+		}
+	}
+
+	return lastInvocation, nil
+}
+
 func (f *LambdaFunction) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("Name", f.functionName)
@@ -56,6 +107,48 @@ func (f *LambdaFunction) Properties() types.Properties {
 	return properties
 }
 
+// loadInvocations reads the last invocation from the metrics of the
+// function. Since this is a paid API call, it is only done once and only if
+// needed.
+func (f *LambdaFunction) loadInvocations() {
+	if f.invocationsLoaded {
+		return
+	}
+
+	lastInvocation, err := getLambdaLastInvocation(f.metrics, f.functionName)
+	f.lastInvocation = lastInvocation
+	f.invocationsKnown = err == nil
+	f.invocationsLoaded = true
+}
+
+func (f *LambdaFunction) LazyProperty(key string) (string, error) {
+	f.loadInvocations()
+	return types.NewProperties().
+		Set("LastInvocation", f.lastInvocation).
+		Get(key), nil
+}
+
+// LastActivity returns the last invocation or modification of the function.
+// Since the invocations are only known for the lookback period, functions
+// without invocations count as active at its beginning.
+func (f *LambdaFunction) LastActivity() (time.Time, bool) {
+	f.loadInvocations()
+	if !f.invocationsKnown || f.lastModified == nil {
+		return time.Time{}, false
+	}
+
+	lastModified, err := time.Parse("2006-01-02T15:04:05.000-0700", *f.lastModified)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	activity := f.lastInvocation
+	if activity == nil {
+		activity = aws.Time(time.Now().Add(-lambdaInvocationLookback))
+	}
+	return *latestTime(&lastModified, activity), true
+}
+
 func (f *LambdaFunction) Remove() error {
 
 	_, err := f.svc.DeleteFunction(&lambda.DeleteFunctionInput{
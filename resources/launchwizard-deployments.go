@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/launchwizard"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type LaunchWizardDeployment struct {
+	svc          *launchwizard.LaunchWizard
+	id           *string
+	name         *string
+	workloadName *string
+	status       *string
+}
+
+func init() {
+	register("LaunchWizardDeployment", ListLaunchWizardDeployments)
+}
+
+func ListLaunchWizardDeployments(sess *session.Session) ([]Resource, error) {
+	svc := launchwizard.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListDeploymentsPages(&launchwizard.ListDeploymentsInput{},
+		func(page *launchwizard.ListDeploymentsOutput, lastPage bool) bool {
+			for _, deployment := range page.Deployments {
+				resources = append(resources, &LaunchWizardDeployment{
+					svc:          svc,
+					id:           deployment.Id,
+					name:         deployment.Name,
+					workloadName: deployment.WorkloadName,
+					status:       deployment.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Filter hides deleted deployments and shows deployments, which are still
+// being created, as filtered, since they cannot be deleted yet.
+func (f *LaunchWizardDeployment) Filter() error {
+	switch aws.StringValue(f.status) {
+	case launchwizard.DeploymentStatusDeleted:
+		return fmt.Errorf("already deleted")
+	case launchwizard.DeploymentStatusCreating, launchwizard.DeploymentStatusValidating, launchwizard.DeploymentStatusInProgress:
+		return fmt.Errorf("deployment is still in progress")
+	}
+	return nil
+}
+
+// Remove deletes the deployment including the resources, which were
+// deployed by it.
+func (f *LaunchWizardDeployment) Remove() error {
+	_, err := f.svc.DeleteDeployment(&launchwizard.DeleteDeploymentInput{
+		DeploymentId: f.id,
+	})
+
+	return err
+}
+
+func (f *LaunchWizardDeployment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("WorkloadName", f.workloadName).
+		Set("Status", f.status)
+}
+
+func (f *LaunchWizardDeployment) String() string {
+	return *f.name
+}
@@ -0,0 +1,92 @@
+package resources
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run ../tools/metadata -o metadata.json
+
+// Metadata describes a resource type. It is extracted from the sources of
+// this package at build time and embedded into the binary.
+type Metadata struct {
+	// Service is the name of the SDK package of the service.
+	Service string `json:"service"`
+
+	// EndpointsID is the ID to look up the service endpoint with.
+	EndpointsID string `json:"endpoints-id"`
+
+	// IAMPrefix is the service prefix used in IAM actions.
+	IAMPrefix string `json:"iam-prefix"`
+
+	// LegacyID is true, if the resource can be filtered without property.
+	LegacyID bool `json:"legacy-id,omitempty"`
+
+	// Properties are the statically known filter properties.
+	Properties []string `json:"properties,omitempty"`
+
+	// Tags is true, if the tags of the resource are available as properties.
+	Tags bool `json:"tags,omitempty"`
+
+	// DynamicProperties is true, if the resource has properties whose names
+	// are only known at runtime.
+	DynamicProperties bool `json:"dynamic-properties,omitempty"`
+
+	// Raw is true, if the API response of the resource is available as the
+	// _raw property.
+	Raw bool `json:"raw,omitempty"`
+
+	// LazyProperties are the properties, which are only determined if a
+	// filter uses them.
+	LazyProperties []string `json:"lazy-properties,omitempty"`
+
+	// Actions are the IAM actions that are required to list and remove the
+	// resource.
+	Actions []string `json:"actions,omitempty"`
+}
+
+//go:embed metadata.json
+var rawMetadata []byte
+
+var metadata map[string]Metadata
+
+func init() {
+	err := json.Unmarshal(rawMetadata, &metadata)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded resource metadata: %v", err))
+	}
+}
+
+// GetMetadata returns the metadata of the resource type. The second return
+// value is false, if there is no metadata for the type.
+func GetMetadata(name string) (Metadata, bool) {
+	m, ok := metadata[name]
+	return m, ok
+}
+
+// KnownProperties returns the statically known properties of a resource type.
+// The second return value is false, if the properties cannot be known in
+// advance.
+func KnownProperties(name string) ([]string, bool) {
+	m, ok := metadata[name]
+	if !ok || m.DynamicProperties {
+		return nil, false
+	}
+	properties := append(append([]string{}, m.Properties...), m.LazyProperties...)
+	if m.Raw {
+		properties = append(properties, RawProperty)
+	}
+	return properties, true
+}
+
+// IsLazyProperty returns true, if the property of the resource type is only
+// determined by LazyProperty.
+func IsLazyProperty(name, property string) bool {
+	for _, lazy := range metadata[name].LazyProperties {
+		if lazy == property {
+			return true
+		}
+	}
+	return false
+}
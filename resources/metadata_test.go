@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/rebuy-de/aws-nuke/pkg/config"
+)
+
+func TestMetadataCoversAllResourceTypes(t *testing.T) {
+	for _, name := range GetListerNames() {
+		meta, ok := GetMetadata(name)
+		if !ok {
+			t.Errorf("missing metadata for %s, run 'go generate ./resources'", name)
+			continue
+		}
+
+		if meta.Service == "" {
+			t.Errorf("metadata for %s has no service", name)
+		}
+	}
+}
+
+func TestBuiltinPresetsUseKnownResourceTypes(t *testing.T) {
+	for _, name := range config.BuiltinPresetNames() {
+		preset, _, err := config.BuiltinPreset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for resourceType, filters := range preset.Filters {
+			properties, ok := KnownProperties(resourceType)
+			if !ok {
+				t.Errorf("built-in preset %s uses unknown resource type %s", name, resourceType)
+				continue
+			}
+
+			known := map[string]bool{"": true}
+			for _, p := range properties {
+				known[p] = true
+			}
+
+			for _, filter := range filters {
+				if !known[filter.Property] {
+					t.Errorf("built-in preset %s uses unknown property %s of %s", name, filter.Property, resourceType)
+				}
+			}
+		}
+	}
+}
+
+func TestEndOfLifeUsesKnownServices(t *testing.T) {
+	services := map[string]bool{}
+	for _, meta := range metadata {
+		services[meta.Service] = true
+	}
+
+	for service, eol := range endOfLife {
+		if !services[service] {
+			t.Errorf("end of life data for unknown service %s", service)
+		}
+
+		if eol.Notice == "" {
+			t.Errorf("end of life data for %s has no notice", service)
+		}
+	}
+}
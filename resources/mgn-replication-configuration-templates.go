@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mgn"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type MGNReplicationConfigurationTemplate struct {
+	svc        *mgn.Mgn
+	templateID *string
+	arn        *string
+	tags       map[string]*string
+}
+
+func init() {
+	register("MGNReplicationConfigurationTemplate", ListMGNReplicationConfigurationTemplates)
+}
+
+func ListMGNReplicationConfigurationTemplates(sess *session.Session) ([]Resource, error) {
+	svc := mgn.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeReplicationConfigurationTemplatesPages(&mgn.DescribeReplicationConfigurationTemplatesInput{},
+		func(page *mgn.DescribeReplicationConfigurationTemplatesOutput, lastPage bool) bool {
+			for _, template := range page.Items {
+				resources = append(resources, &MGNReplicationConfigurationTemplate{
+					svc:        svc,
+					templateID: template.ReplicationConfigurationTemplateID,
+					arn:        template.Arn,
+					tags:       template.Tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == mgn.ErrCodeUninitializedAccountException {
+			// The service was never used in this region.
+			return resources, nil
+		}
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *MGNReplicationConfigurationTemplate) Remove() error {
+	_, err := f.svc.DeleteReplicationConfigurationTemplate(&mgn.DeleteReplicationConfigurationTemplateInput{
+		ReplicationConfigurationTemplateID: f.templateID,
+	})
+
+	return err
+}
+
+func (f *MGNReplicationConfigurationTemplate) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("TemplateID", f.templateID).
+		Set("ARN", f.arn)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *MGNReplicationConfigurationTemplate) String() string {
+	return *f.templateID
+}
@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/mgn"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type MGNSourceServer struct {
+	svc            *mgn.Mgn
+	sourceServerID *string
+	arn            *string
+	state          *string
+	tags           map[string]*string
+}
+
+func init() {
+	register("MGNSourceServer", ListMGNSourceServers)
+}
+
+func ListMGNSourceServers(sess *session.Session) ([]Resource, error) {
+	svc := mgn.New(sess)
+	resources := []Resource{}
+
+	err := svc.DescribeSourceServersPages(&mgn.DescribeSourceServersInput{},
+		func(page *mgn.DescribeSourceServersOutput, lastPage bool) bool {
+			for _, server := range page.Items {
+				r := &MGNSourceServer{
+					svc:            svc,
+					sourceServerID: server.SourceServerID,
+					arn:            server.Arn,
+					tags:           server.Tags,
+				}
+				if server.LifeCycle != nil {
+					r.state = server.LifeCycle.State
+				}
+				resources = append(resources, r)
+			}
+			return true
+		})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == mgn.ErrCodeUninitializedAccountException {
+			// The service was never used in this region.
+			return resources, nil
+		}
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove disconnects the server from the service, which stops the
+// replication, and deletes it afterwards.
+func (f *MGNSourceServer) Remove() error {
+	if aws.StringValue(f.state) != mgn.LifeCycleStateDisconnected {
+		_, err := f.svc.DisconnectFromService(&mgn.DisconnectFromServiceInput{
+			SourceServerID: f.sourceServerID,
+		})
+		if err != nil {
+			return err
+		}
+		f.state = aws.String(mgn.LifeCycleStateDisconnected)
+	}
+
+	_, err := f.svc.DeleteSourceServer(&mgn.DeleteSourceServerInput{
+		SourceServerID: f.sourceServerID,
+	})
+
+	return err
+}
+
+func (f *MGNSourceServer) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("SourceServerID", f.sourceServerID).
+		Set("ARN", f.arn).
+		Set("State", f.state)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *MGNSourceServer) String() string {
+	return *f.sourceServerID
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsAnnotationStore struct {
+	svc  *omics.Omics
+	id   *string
+	name *string
+}
+
+func init() {
+	register("OmicsAnnotationStore", ListOmicsAnnotationStores)
+}
+
+func ListOmicsAnnotationStores(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListAnnotationStoresPages(&omics.ListAnnotationStoresInput{},
+		func(page *omics.ListAnnotationStoresOutput, lastPage bool) bool {
+			for _, store := range page.AnnotationStores {
+				resources = append(resources, &OmicsAnnotationStore{
+					svc:  svc,
+					id:   store.Id,
+					name: store.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *OmicsAnnotationStore) Remove() error {
+	_, err := f.svc.DeleteAnnotationStore(&omics.DeleteAnnotationStoreInput{
+		Name:  f.name,
+		Force: aws.Bool(true),
+	})
+
+	return err
+}
+
+func (f *OmicsAnnotationStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *OmicsAnnotationStore) String() string {
+	return *f.name
+}
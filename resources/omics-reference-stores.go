@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsReferenceStore struct {
+	svc  *omics.Omics
+	id   *string
+	name *string
+}
+
+func init() {
+	register("OmicsReferenceStore", ListOmicsReferenceStores)
+}
+
+func ListOmicsReferenceStores(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListReferenceStoresPages(&omics.ListReferenceStoresInput{},
+		func(page *omics.ListReferenceStoresOutput, lastPage bool) bool {
+			for _, store := range page.ReferenceStores {
+				resources = append(resources, &OmicsReferenceStore{
+					svc:  svc,
+					id:   store.Id,
+					name: store.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the store. This fails until all of its references are deleted.
+func (f *OmicsReferenceStore) Remove() error {
+	_, err := f.svc.DeleteReferenceStore(&omics.DeleteReferenceStoreInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *OmicsReferenceStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *OmicsReferenceStore) String() string {
+	return *f.id
+}
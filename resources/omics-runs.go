@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsRun struct {
+	svc        *omics.Omics
+	id         *string
+	name       *string
+	status     *string
+	workflowID *string
+}
+
+func init() {
+	register("OmicsRun", ListOmicsRuns)
+}
+
+func ListOmicsRuns(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListRunsPages(&omics.ListRunsInput{},
+		func(page *omics.ListRunsOutput, lastPage bool) bool {
+			for _, run := range page.Items {
+				if aws.StringValue(run.Status) == omics.RunStatusDeleted {
+					continue
+				}
+
+				resources = append(resources, &OmicsRun{
+					svc:        svc,
+					id:         run.Id,
+					name:       run.Name,
+					status:     run.Status,
+					workflowID: run.WorkflowId,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove cancels active runs before deleting them. The deletion fails until
+// the run is stopped, so it gets retried in the next iteration.
+func (f *OmicsRun) Remove() error {
+	switch aws.StringValue(f.status) {
+	case omics.RunStatusPending, omics.RunStatusStarting, omics.RunStatusRunning:
+		_, err := f.svc.CancelRun(&omics.CancelRunInput{
+			Id: f.id,
+		})
+		if err != nil {
+			return err
+		}
+		f.status = aws.String(omics.RunStatusStopping)
+	}
+
+	_, err := f.svc.DeleteRun(&omics.DeleteRunInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *OmicsRun) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("Status", f.status).
+		Set("WorkflowID", f.workflowID)
+}
+
+func (f *OmicsRun) String() string {
+	return *f.id
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsSequenceStore struct {
+	svc  *omics.Omics
+	id   *string
+	name *string
+}
+
+func init() {
+	register("OmicsSequenceStore", ListOmicsSequenceStores)
+}
+
+func ListOmicsSequenceStores(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListSequenceStoresPages(&omics.ListSequenceStoresInput{},
+		func(page *omics.ListSequenceStoresOutput, lastPage bool) bool {
+			for _, store := range page.SequenceStores {
+				resources = append(resources, &OmicsSequenceStore{
+					svc:  svc,
+					id:   store.Id,
+					name: store.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the store. This fails until all of its read sets are deleted.
+func (f *OmicsSequenceStore) Remove() error {
+	_, err := f.svc.DeleteSequenceStore(&omics.DeleteSequenceStoreInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *OmicsSequenceStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *OmicsSequenceStore) String() string {
+	return *f.id
+}
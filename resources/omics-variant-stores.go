@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsVariantStore struct {
+	svc  *omics.Omics
+	id   *string
+	name *string
+}
+
+func init() {
+	register("OmicsVariantStore", ListOmicsVariantStores)
+}
+
+func ListOmicsVariantStores(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListVariantStoresPages(&omics.ListVariantStoresInput{},
+		func(page *omics.ListVariantStoresOutput, lastPage bool) bool {
+			for _, store := range page.VariantStores {
+				resources = append(resources, &OmicsVariantStore{
+					svc:  svc,
+					id:   store.Id,
+					name: store.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *OmicsVariantStore) Remove() error {
+	_, err := f.svc.DeleteVariantStore(&omics.DeleteVariantStoreInput{
+		Name:  f.name,
+		Force: aws.Bool(true),
+	})
+
+	return err
+}
+
+func (f *OmicsVariantStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name)
+}
+
+func (f *OmicsVariantStore) String() string {
+	return *f.name
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/omics"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type OmicsWorkflow struct {
+	svc    *omics.Omics
+	id     *string
+	name   *string
+	status *string
+}
+
+func init() {
+	register("OmicsWorkflow", ListOmicsWorkflows)
+}
+
+func ListOmicsWorkflows(sess *session.Session) ([]Resource, error) {
+	svc := omics.New(sess)
+	resources := []Resource{}
+
+	// Ready2Run workflows are provided by AWS and cannot be deleted.
+	params := &omics.ListWorkflowsInput{
+		Type: aws.String(omics.WorkflowTypePrivate),
+	}
+
+	err := svc.ListWorkflowsPages(params,
+		func(page *omics.ListWorkflowsOutput, lastPage bool) bool {
+			for _, workflow := range page.Items {
+				resources = append(resources, &OmicsWorkflow{
+					svc:    svc,
+					id:     workflow.Id,
+					name:   workflow.Name,
+					status: workflow.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *OmicsWorkflow) Remove() error {
+	_, err := f.svc.DeleteWorkflow(&omics.DeleteWorkflowInput{
+		Id: f.id,
+	})
+
+	return err
+}
+
+func (f *OmicsWorkflow) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ID", f.id).
+		Set("Name", f.name).
+		Set("Status", f.status)
+}
+
+func (f *OmicsWorkflow) String() string {
+	return *f.id
+}
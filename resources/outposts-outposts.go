@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/outposts"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+// OutpostsOutpost is only listed to show the Outposts of the account in the
+// report. An Outpost is physical hardware, which can only be returned by
+// contacting AWS, so it is always filtered.
+type OutpostsOutpost struct {
+	svc     *outposts.Outposts
+	outpost *outposts.Outpost
+}
+
+func init() {
+	register("OutpostsOutpost", ListOutpostsOutposts)
+}
+
+func ListOutpostsOutposts(sess *session.Session) ([]Resource, error) {
+	svc := outposts.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListOutpostsPages(&outposts.ListOutpostsInput{},
+		func(page *outposts.ListOutpostsOutput, lastPage bool) bool {
+			for _, outpost := range page.Outposts {
+				resources = append(resources, &OutpostsOutpost{
+					svc:     svc,
+					outpost: outpost,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *OutpostsOutpost) Filter() error {
+	return fmt.Errorf("outposts can only be returned by contacting AWS")
+}
+
+func (f *OutpostsOutpost) Remove() error {
+	return fmt.Errorf("outposts can only be returned by contacting AWS")
+}
+
+func (f *OutpostsOutpost) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("OutpostID", f.outpost.OutpostId).
+		Set("Name", f.outpost.Name).
+		Set("SiteID", f.outpost.SiteId).
+		Set("AvailabilityZone", f.outpost.AvailabilityZone).
+		Set("LifeCycleStatus", f.outpost.LifeCycleStatus)
+	for key, value := range f.outpost.Tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *OutpostsOutpost) String() string {
+	return *f.outpost.OutpostId
+}
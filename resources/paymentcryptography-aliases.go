@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/paymentcryptography"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type PaymentCryptographyAlias struct {
+	svc    *paymentcryptography.PaymentCryptography
+	name   *string
+	keyArn *string
+}
+
+func init() {
+	register("PaymentCryptographyAlias", ListPaymentCryptographyAliases)
+}
+
+func ListPaymentCryptographyAliases(sess *session.Session) ([]Resource, error) {
+	svc := paymentcryptography.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListAliasesPages(&paymentcryptography.ListAliasesInput{},
+		func(page *paymentcryptography.ListAliasesOutput, lastPage bool) bool {
+			for _, alias := range page.Aliases {
+				resources = append(resources, &PaymentCryptographyAlias{
+					svc:    svc,
+					name:   alias.AliasName,
+					keyArn: alias.KeyArn,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *PaymentCryptographyAlias) Remove() error {
+	_, err := f.svc.DeleteAlias(&paymentcryptography.DeleteAliasInput{
+		AliasName: f.name,
+	})
+
+	return err
+}
+
+func (f *PaymentCryptographyAlias) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("KeyArn", f.keyArn)
+}
+
+func (f *PaymentCryptographyAlias) String() string {
+	return *f.name
+}
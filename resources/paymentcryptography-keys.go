@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/paymentcryptography"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type PaymentCryptographyKey struct {
+	svc   *paymentcryptography.PaymentCryptography
+	arn   *string
+	state *string
+}
+
+func init() {
+	register("PaymentCryptographyKey", ListPaymentCryptographyKeys)
+}
+
+func ListPaymentCryptographyKeys(sess *session.Session) ([]Resource, error) {
+	svc := paymentcryptography.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListKeysPages(&paymentcryptography.ListKeysInput{},
+		func(page *paymentcryptography.ListKeysOutput, lastPage bool) bool {
+			for _, key := range page.Keys {
+				resources = append(resources, &PaymentCryptographyKey{
+					svc:   svc,
+					arn:   key.KeyArn,
+					state: key.KeyState,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *PaymentCryptographyKey) Filter() error {
+	switch aws.StringValue(f.state) {
+	case paymentcryptography.KeyStateDeletePending, paymentcryptography.KeyStateDeleteComplete:
+		return fmt.Errorf("is already scheduled for deletion")
+	}
+	return nil
+}
+
+// Remove schedules the deletion of the key with the shortest allowed waiting
+// period.
+func (f *PaymentCryptographyKey) Remove() error {
+	_, err := f.svc.DeleteKey(&paymentcryptography.DeleteKeyInput{
+		KeyIdentifier:   f.arn,
+		DeleteKeyInDays: aws.Int64(3),
+	})
+
+	return err
+}
+
+func (f *PaymentCryptographyKey) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ARN", f.arn).
+		Set("State", f.state)
+}
+
+func (f *PaymentCryptographyKey) String() string {
+	return *f.arn
+}
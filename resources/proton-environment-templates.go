@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/proton"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ProtonEnvironmentTemplate struct {
+	svc  *proton.Proton
+	name *string
+}
+
+func init() {
+	register("ProtonEnvironmentTemplate", ListProtonEnvironmentTemplates)
+}
+
+func ListProtonEnvironmentTemplates(sess *session.Session) ([]Resource, error) {
+	svc := proton.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListEnvironmentTemplatesPages(&proton.ListEnvironmentTemplatesInput{},
+		func(page *proton.ListEnvironmentTemplatesOutput, lastPage bool) bool {
+			for _, template := range page.Templates {
+				resources = append(resources, &ProtonEnvironmentTemplate{
+					svc:  svc,
+					name: template.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes all versions of the template and the template afterwards.
+// The recommended minor version of a major version can only be deleted
+// last. The deletion fails as long as an environment uses the template.
+func (f *ProtonEnvironmentTemplate) Remove() error {
+	majors := []*string{}
+	err := f.svc.ListEnvironmentTemplateVersionsPages(&proton.ListEnvironmentTemplateVersionsInput{
+		TemplateName: f.name,
+	}, func(page *proton.ListEnvironmentTemplateVersionsOutput, lastPage bool) bool {
+		for _, version := range page.TemplateVersions {
+			majors = append(majors, version.MajorVersion)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, major := range majors {
+		versions := []*proton.EnvironmentTemplateVersionSummary{}
+		recommended := []*proton.EnvironmentTemplateVersionSummary{}
+		err := f.svc.ListEnvironmentTemplateVersionsPages(&proton.ListEnvironmentTemplateVersionsInput{
+			TemplateName: f.name,
+			MajorVersion: major,
+		}, func(page *proton.ListEnvironmentTemplateVersionsOutput, lastPage bool) bool {
+			for _, version := range page.TemplateVersions {
+				if aws.StringValue(version.MinorVersion) == aws.StringValue(version.RecommendedMinorVersion) {
+					recommended = append(recommended, version)
+				} else {
+					versions = append(versions, version)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, version := range append(versions, recommended...) {
+			_, err := f.svc.DeleteEnvironmentTemplateVersion(&proton.DeleteEnvironmentTemplateVersionInput{
+				TemplateName: f.name,
+				MajorVersion: version.MajorVersion,
+				MinorVersion: version.MinorVersion,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = f.svc.DeleteEnvironmentTemplate(&proton.DeleteEnvironmentTemplateInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *ProtonEnvironmentTemplate) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name)
+}
+
+func (f *ProtonEnvironmentTemplate) String() string {
+	return *f.name
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/proton"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ProtonEnvironment struct {
+	svc              *proton.Proton
+	name             *string
+	templateName     *string
+	deploymentStatus *string
+}
+
+func init() {
+	register("ProtonEnvironment", ListProtonEnvironments)
+}
+
+func ListProtonEnvironments(sess *session.Session) ([]Resource, error) {
+	svc := proton.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListEnvironmentsPages(&proton.ListEnvironmentsInput{},
+		func(page *proton.ListEnvironmentsOutput, lastPage bool) bool {
+			for _, environment := range page.Environments {
+				resources = append(resources, &ProtonEnvironment{
+					svc:              svc,
+					name:             environment.Name,
+					templateName:     environment.TemplateName,
+					deploymentStatus: environment.DeploymentStatus,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the environment. This fails as long as service instances
+// are deployed into it, so it is retried until the ProtonService resources
+// are deleted.
+func (f *ProtonEnvironment) Remove() error {
+	_, err := f.svc.DeleteEnvironment(&proton.DeleteEnvironmentInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *ProtonEnvironment) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("TemplateName", f.templateName).
+		Set("DeploymentStatus", f.deploymentStatus)
+}
+
+func (f *ProtonEnvironment) String() string {
+	return *f.name
+}
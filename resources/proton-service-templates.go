@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/proton"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ProtonServiceTemplate struct {
+	svc  *proton.Proton
+	name *string
+}
+
+func init() {
+	register("ProtonServiceTemplate", ListProtonServiceTemplates)
+}
+
+func ListProtonServiceTemplates(sess *session.Session) ([]Resource, error) {
+	svc := proton.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListServiceTemplatesPages(&proton.ListServiceTemplatesInput{},
+		func(page *proton.ListServiceTemplatesOutput, lastPage bool) bool {
+			for _, template := range page.Templates {
+				resources = append(resources, &ProtonServiceTemplate{
+					svc:  svc,
+					name: template.Name,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes all versions of the template and the template afterwards.
+// The recommended minor version of a major version can only be deleted
+// last. The deletion fails as long as a service uses the template.
+func (f *ProtonServiceTemplate) Remove() error {
+	majors := []*string{}
+	err := f.svc.ListServiceTemplateVersionsPages(&proton.ListServiceTemplateVersionsInput{
+		TemplateName: f.name,
+	}, func(page *proton.ListServiceTemplateVersionsOutput, lastPage bool) bool {
+		for _, version := range page.TemplateVersions {
+			majors = append(majors, version.MajorVersion)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, major := range majors {
+		versions := []*proton.ServiceTemplateVersionSummary{}
+		recommended := []*proton.ServiceTemplateVersionSummary{}
+		err := f.svc.ListServiceTemplateVersionsPages(&proton.ListServiceTemplateVersionsInput{
+			TemplateName: f.name,
+			MajorVersion: major,
+		}, func(page *proton.ListServiceTemplateVersionsOutput, lastPage bool) bool {
+			for _, version := range page.TemplateVersions {
+				if aws.StringValue(version.MinorVersion) == aws.StringValue(version.RecommendedMinorVersion) {
+					recommended = append(recommended, version)
+				} else {
+					versions = append(versions, version)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, version := range append(versions, recommended...) {
+			_, err := f.svc.DeleteServiceTemplateVersion(&proton.DeleteServiceTemplateVersionInput{
+				TemplateName: f.name,
+				MajorVersion: version.MajorVersion,
+				MinorVersion: version.MinorVersion,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = f.svc.DeleteServiceTemplate(&proton.DeleteServiceTemplateInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *ProtonServiceTemplate) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name)
+}
+
+func (f *ProtonServiceTemplate) String() string {
+	return *f.name
+}
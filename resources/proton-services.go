@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/proton"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ProtonService struct {
+	svc          *proton.Proton
+	name         *string
+	templateName *string
+	status       *string
+}
+
+func init() {
+	register("ProtonService", ListProtonServices)
+}
+
+func ListProtonServices(sess *session.Session) ([]Resource, error) {
+	svc := proton.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListServicesPages(&proton.ListServicesInput{},
+		func(page *proton.ListServicesOutput, lastPage bool) bool {
+			for _, service := range page.Services {
+				resources = append(resources, &ProtonService{
+					svc:          svc,
+					name:         service.Name,
+					templateName: service.TemplateName,
+					status:       service.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the service including its service instances and pipeline.
+func (f *ProtonService) Remove() error {
+	_, err := f.svc.DeleteService(&proton.DeleteServiceInput{
+		Name: f.name,
+	})
+
+	return err
+}
+
+func (f *ProtonService) Properties() types.Properties {
+	return types.NewProperties().
+		Set("Name", f.name).
+		Set("TemplateName", f.templateName).
+		Set("Status", f.status)
+}
+
+func (f *ProtonService) String() string {
+	return *f.name
+}
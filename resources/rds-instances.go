@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -53,6 +55,13 @@ func (i *RDSInstance) FeatureFlags(ff config.FeatureFlags) {
 	i.featureFlags = ff
 }
 
+func (i *RDSInstance) CheckRemove() error {
+	if aws.BoolValue(i.instance.DeletionProtection) && !i.featureFlags.DisableDeletionProtection.RDSInstance {
+		return fmt.Errorf("deletion protection is disabled")
+	}
+	return nil
+}
+
 func (i *RDSInstance) Remove() error {
 	if aws.BoolValue(i.instance.DeletionProtection) && i.featureFlags.DisableDeletionProtection.RDSInstance {
 		modifyParams := &rds.ModifyDBInstanceInput{
@@ -78,6 +87,10 @@ func (i *RDSInstance) Remove() error {
 	return nil
 }
 
+func (i *RDSInstance) Raw() interface{} {
+	return i.instance
+}
+
 func (i *RDSInstance) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("Identifier", i.instance.DBInstanceIdentifier)
@@ -77,6 +77,10 @@ func (i *RDSSnapshot) String() string {
 	return *i.snapshot.DBSnapshotIdentifier
 }
 
+func (i *RDSSnapshot) Raw() interface{} {
+	return i.snapshot
+}
+
 func (i *RDSSnapshot) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("ARN", i.snapshot.DBSnapshotArn)
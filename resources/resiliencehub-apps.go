@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resiliencehub"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ResilienceHubApp struct {
+	svc    *resiliencehub.ResilienceHub
+	arn    *string
+	name   *string
+	status *string
+}
+
+func init() {
+	register("ResilienceHubApp", ListResilienceHubApps)
+}
+
+func ListResilienceHubApps(sess *session.Session) ([]Resource, error) {
+	svc := resiliencehub.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListAppsPages(&resiliencehub.ListAppsInput{},
+		func(page *resiliencehub.ListAppsOutput, lastPage bool) bool {
+			for _, app := range page.AppSummaries {
+				resources = append(resources, &ResilienceHubApp{
+					svc:    svc,
+					arn:    app.AppArn,
+					name:   app.Name,
+					status: app.Status,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the application including its assessments and
+// recommendation templates.
+func (f *ResilienceHubApp) Remove() error {
+	_, err := f.svc.DeleteApp(&resiliencehub.DeleteAppInput{
+		AppArn:      f.arn,
+		ForceDelete: aws.Bool(true),
+	})
+
+	return err
+}
+
+func (f *ResilienceHubApp) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ARN", f.arn).
+		Set("Name", f.name).
+		Set("Status", f.status)
+}
+
+func (f *ResilienceHubApp) String() string {
+	return *f.name
+}
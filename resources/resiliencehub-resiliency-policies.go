@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resiliencehub"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type ResilienceHubResiliencyPolicy struct {
+	svc  *resiliencehub.ResilienceHub
+	arn  *string
+	name *string
+	tier *string
+	tags map[string]*string
+}
+
+func init() {
+	register("ResilienceHubResiliencyPolicy", ListResilienceHubResiliencyPolicies)
+}
+
+func ListResilienceHubResiliencyPolicies(sess *session.Session) ([]Resource, error) {
+	svc := resiliencehub.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListResiliencyPoliciesPages(&resiliencehub.ListResiliencyPoliciesInput{},
+		func(page *resiliencehub.ListResiliencyPoliciesOutput, lastPage bool) bool {
+			for _, policy := range page.ResiliencyPolicies {
+				resources = append(resources, &ResilienceHubResiliencyPolicy{
+					svc:  svc,
+					arn:  policy.PolicyArn,
+					name: policy.PolicyName,
+					tier: policy.Tier,
+					tags: policy.Tags,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the policy. This fails as long as an application uses it.
+func (f *ResilienceHubResiliencyPolicy) Remove() error {
+	_, err := f.svc.DeleteResiliencyPolicy(&resiliencehub.DeleteResiliencyPolicyInput{
+		PolicyArn: f.arn,
+	})
+
+	return err
+}
+
+func (f *ResilienceHubResiliencyPolicy) Properties() types.Properties {
+	properties := types.NewProperties().
+		Set("ARN", f.arn).
+		Set("Name", f.name).
+		Set("Tier", f.tier)
+	for key, value := range f.tags {
+		properties.SetTag(aws.String(key), value)
+	}
+	return properties
+}
+
+func (f *ResilienceHubResiliencyPolicy) String() string {
+	return *f.name
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/rebuy-de/aws-nuke/pkg/config"
 	"github.com/rebuy-de/aws-nuke/pkg/types"
+	log "github.com/sirupsen/logrus"
 )
 
 func init() {
@@ -19,9 +21,12 @@ func init() {
 }
 
 type S3Bucket struct {
-	svc  *s3.S3
+	svc  s3iface.S3API
 	name string
 	tags []*s3.Tag
+	lazy types.Properties
+
+	featureFlags config.FeatureFlags
 }
 
 func ListS3Buckets(s *session.Session) ([]Resource, error) {
@@ -42,7 +47,7 @@ func ListS3Buckets(s *session.Session) ([]Resource, error) {
 			if aerr, ok := err.(awserr.Error); ok {
 				if aerr.Code() == "NoSuchTagSet" {
 					resources = append(resources, &S3Bucket{
-						svc: svc,
+						svc:  svc,
 						name: name,
 						tags: make([]*s3.Tag, 0),
 					})
@@ -61,6 +66,20 @@ func ListS3Buckets(s *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+// isS3BucketEmpty returns whether the bucket neither contains object versions
+// nor delete markers. It returns nil, if this cannot be determined.
+func isS3BucketEmpty(svc s3iface.S3API, name string) *bool {
+	resp, err := svc.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket:  aws.String(name),
+		MaxKeys: aws.Int64(1),
+	})
+	if err != nil {
+		return nil
+	}
+
+	return aws.Bool(len(resp.Versions) == 0 && len(resp.DeleteMarkers) == 0)
+}
+
 func DescribeS3Buckets(svc *s3.S3) ([]string, error) {
 	resp, err := svc.ListBuckets(nil)
 	if err != nil {
@@ -102,6 +121,13 @@ func (e *S3Bucket) Remove() error {
 		return err
 	}
 
+	if e.featureFlags.UseS3Inventory {
+		err = e.RemoveInventoryVersions()
+		if err != nil {
+			log.Warnf("Failed to remove the objects of %s by its inventory, listing them instead: %v", e, err)
+		}
+	}
+
 	err = e.RemoveAllVersions()
 	if err != nil {
 		return err
@@ -119,6 +145,24 @@ func (e *S3Bucket) Remove() error {
 	return err
 }
 
+// CheckRemove fails for buckets with object lock, since locked object
+// versions cannot be deleted before their retention ends.
+func (e *S3Bucket) CheckRemove() error {
+	resp, err := e.svc.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+		Bucket: &e.name,
+	})
+	if err != nil {
+		return nil
+	}
+
+	if resp.ObjectLockConfiguration != nil &&
+		aws.StringValue(resp.ObjectLockConfiguration.ObjectLockEnabled) == s3.ObjectLockEnabledEnabled {
+		return fmt.Errorf("no object versions are retained by object lock")
+	}
+
+	return nil
+}
+
 func (e *S3Bucket) RemoveAllVersions() error {
 	params := &s3.ListObjectVersionsInput{
 		Bucket: &e.name,
@@ -128,6 +172,24 @@ func (e *S3Bucket) RemoveAllVersions() error {
 	return s3manager.NewBatchDeleteWithClient(e.svc).Delete(aws.BackgroundContext(), iterator)
 }
 
+// RemoveInventoryVersions removes the object versions of the latest report of
+// the S3 Inventory of the bucket. This avoids paginating ListObjectVersions
+// for buckets with millions of objects. Objects, which were written after the
+// report, are left to RemoveAllVersions.
+func (e *S3Bucket) RemoveInventoryVersions() error {
+	manifest, err := findS3Inventory(e.svc, e.name)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := newS3InventoryIterator(e.svc, e.name, manifest)
+	if err != nil {
+		return err
+	}
+
+	return s3manager.NewBatchDeleteWithClient(e.svc).Delete(aws.BackgroundContext(), iterator)
+}
+
 func (e *S3Bucket) RemoveAllObjects() error {
 	params := &s3.ListObjectsInput{
 		Bucket: &e.name,
@@ -137,6 +199,18 @@ func (e *S3Bucket) RemoveAllObjects() error {
 	return s3manager.NewBatchDeleteWithClient(e.svc).Delete(aws.BackgroundContext(), iterator)
 }
 
+func (e *S3Bucket) FeatureFlags(ff config.FeatureFlags) {
+	e.featureFlags = ff
+}
+
+func (e *S3Bucket) LazyProperty(key string) (string, error) {
+	if e.lazy == nil {
+		e.lazy = types.NewProperties().
+			Set("Empty", isS3BucketEmpty(e.svc, e.name))
+	}
+	return e.lazy.Get(key), nil
+}
+
 func (e *S3Bucket) Properties() types.Properties {
 	properties := types.NewProperties()
 	properties.Set("Name", e.name)
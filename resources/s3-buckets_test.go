@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/rebuy-de/aws-nuke/mocks/mock_s3iface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3Bucket_CheckRemove(t *testing.T) {
+	cases := []struct {
+		name    string
+		output  *s3.GetObjectLockConfigurationOutput
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "object lock enabled",
+			output: &s3.GetObjectLockConfigurationOutput{
+				ObjectLockConfiguration: &s3.ObjectLockConfiguration{
+					ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "object lock disabled",
+			output: &s3.GetObjectLockConfigurationOutput{},
+		},
+		{
+			name: "no object lock configuration",
+			err:  awserr.New("ObjectLockConfigurationNotFoundError", "Object Lock configuration does not exist for this bucket", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockS3 := mock_s3iface.NewMockS3API(ctrl)
+			bucket := S3Bucket{
+				svc:  mockS3,
+				name: "foobar",
+			}
+
+			mockS3.EXPECT().GetObjectLockConfiguration(gomock.Eq(&s3.GetObjectLockConfigurationInput{
+				Bucket: aws.String("foobar"),
+			})).Return(tc.output, tc.err)
+
+			err := bucket.CheckRemove()
+			a.Equal(tc.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
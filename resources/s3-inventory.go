@@ -0,0 +1,283 @@
+package resources
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// s3InventoryDatePattern matches the folders of the daily or weekly
+// inventory reports, eg 2024-11-06T01-00Z.
+var s3InventoryDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}-\d{2}Z$`)
+
+// s3InventoryManifest is the manifest.json of an S3 Inventory report. It
+// lists the gzipped CSV files, which contain the objects of the bucket.
+type s3InventoryManifest struct {
+	SourceBucket      string `json:"sourceBucket"`
+	DestinationBucket string `json:"destinationBucket"`
+	FileFormat        string `json:"fileFormat"`
+	FileSchema        string `json:"fileSchema"`
+	Files             []struct {
+		Key string `json:"key"`
+	} `json:"files"`
+
+	// bucket is the name of the destination bucket, which is an ARN in
+	// the manifest.
+	bucket string
+}
+
+// findS3Inventory returns the manifest of the latest report of an enabled CSV
+// inventory of the bucket. The inventory has to include all object versions,
+// unless versioning was never enabled for the bucket.
+func findS3Inventory(svc s3iface.S3API, bucket string) (*s3InventoryManifest, error) {
+	versioning, err := svc.GetBucketVersioning(&s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return nil, err
+	}
+	versioned := aws.StringValue(versioning.Status) != ""
+
+	var destination *s3.InventoryS3BucketDestination
+	var id string
+	params := &s3.ListBucketInventoryConfigurationsInput{
+		Bucket: aws.String(bucket),
+	}
+	for destination == nil {
+		resp, err := svc.ListBucketInventoryConfigurations(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range resp.InventoryConfigurationList {
+			if !aws.BoolValue(c.IsEnabled) || c.Destination == nil || c.Destination.S3BucketDestination == nil {
+				continue
+			}
+			if aws.StringValue(c.Destination.S3BucketDestination.Format) != s3.InventoryFormatCsv {
+				continue
+			}
+			if versioned && aws.StringValue(c.IncludedObjectVersions) != s3.InventoryIncludedObjectVersionsAll {
+				continue
+			}
+
+			destination = c.Destination.S3BucketDestination
+			id = aws.StringValue(c.Id)
+			break
+		}
+
+		if !aws.BoolValue(resp.IsTruncated) {
+			break
+		}
+		params.ContinuationToken = resp.NextContinuationToken
+	}
+
+	if destination == nil {
+		return nil, fmt.Errorf("the bucket has no enabled CSV inventory, which includes all object versions")
+	}
+
+	arn := aws.StringValue(destination.Bucket)
+	destinationBucket := arn[strings.LastIndex(arn, ":")+1:]
+	if destinationBucket == bucket {
+		return nil, fmt.Errorf("the inventory is stored in the bucket itself")
+	}
+
+	base := fmt.Sprintf("%s/%s/", bucket, id)
+	if prefix := strings.TrimSuffix(aws.StringValue(destination.Prefix), "/"); prefix != "" {
+		base = prefix + "/" + base
+	}
+
+	dates := []string{}
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:    aws.String(destinationBucket),
+		Prefix:    aws.String(base),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, p := range page.CommonPrefixes {
+			date := strings.TrimSuffix(strings.TrimPrefix(aws.StringValue(p.Prefix), base), "/")
+			if s3InventoryDatePattern.MatchString(date) {
+				dates = append(dates, date)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("the inventory %s has no reports yet", id)
+	}
+	sort.Strings(dates)
+
+	resp, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(destinationBucket),
+		Key:    aws.String(base + dates[len(dates)-1] + "/manifest.json"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	manifest := &s3InventoryManifest{bucket: destinationBucket}
+	err = json.NewDecoder(resp.Body).Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the inventory manifest: %v", err)
+	}
+
+	if manifest.FileFormat != s3.InventoryFormatCsv {
+		return nil, fmt.Errorf("the inventory report has the unsupported format %s", manifest.FileFormat)
+	}
+
+	return manifest, nil
+}
+
+// s3InventoryIterator returns the objects of the CSV files of an inventory
+// report for the batch delete. The files are read one after another, so
+// memory usage does not depend on the size of the bucket.
+type s3InventoryIterator struct {
+	svc      s3iface.S3API
+	manifest *s3InventoryManifest
+	bucket   string
+
+	keyColumn     int
+	versionColumn int
+
+	file   int
+	body   io.ReadCloser
+	reader *csv.Reader
+	object s3manager.BatchDeleteObject
+	err    error
+}
+
+func newS3InventoryIterator(svc s3iface.S3API, bucket string, manifest *s3InventoryManifest) (*s3InventoryIterator, error) {
+	iter := &s3InventoryIterator{
+		svc:           svc,
+		manifest:      manifest,
+		bucket:        bucket,
+		keyColumn:     -1,
+		versionColumn: -1,
+	}
+
+	for i, column := range strings.Split(manifest.FileSchema, ",") {
+		switch strings.TrimSpace(column) {
+		case "Key":
+			iter.keyColumn = i
+		case "VersionId":
+			iter.versionColumn = i
+		}
+	}
+
+	if iter.keyColumn < 0 {
+		return nil, fmt.Errorf("the inventory report has no Key column")
+	}
+
+	return iter, nil
+}
+
+// Next reads the next object of the inventory. It opens the next file, when
+// the current one is exhausted.
+func (iter *s3InventoryIterator) Next() bool {
+	for iter.err == nil {
+		if iter.reader == nil && !iter.openNext() {
+			return false
+		}
+
+		record, err := iter.reader.Read()
+		if err == io.EOF {
+			iter.close()
+			continue
+		}
+		if err != nil {
+			iter.err = err
+			break
+		}
+
+		iter.err = iter.parse(record)
+		return iter.err == nil
+	}
+
+	iter.close()
+	return false
+}
+
+func (iter *s3InventoryIterator) openNext() bool {
+	if iter.file >= len(iter.manifest.Files) {
+		return false
+	}
+
+	key := iter.manifest.Files[iter.file].Key
+	iter.file++
+
+	resp, err := iter.svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(iter.manifest.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		iter.err = err
+		return false
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		iter.err = fmt.Errorf("failed to read the inventory file %s: %v", key, err)
+		return false
+	}
+
+	iter.body = resp.Body
+	iter.reader = csv.NewReader(gz)
+	iter.reader.FieldsPerRecord = -1
+	return true
+}
+
+func (iter *s3InventoryIterator) close() {
+	if iter.body != nil {
+		iter.body.Close()
+	}
+	iter.body = nil
+	iter.reader = nil
+}
+
+// parse converts a row of the inventory. The keys are URL-encoded in the
+// inventory files.
+func (iter *s3InventoryIterator) parse(record []string) error {
+	if iter.keyColumn >= len(record) {
+		return fmt.Errorf("the inventory row %v has no key", record)
+	}
+
+	key, err := url.QueryUnescape(record[iter.keyColumn])
+	if err != nil {
+		return fmt.Errorf("invalid key %s in the inventory: %v", record[iter.keyColumn], err)
+	}
+
+	input := &s3.DeleteObjectInput{
+		Bucket: aws.String(iter.bucket),
+		Key:    aws.String(key),
+	}
+	if iter.versionColumn >= 0 && iter.versionColumn < len(record) && record[iter.versionColumn] != "" {
+		input.VersionId = aws.String(record[iter.versionColumn])
+	}
+
+	iter.object = s3manager.BatchDeleteObject{Object: input}
+	return nil
+}
+
+// Err returns the error, which stopped the iteration.
+func (iter *s3InventoryIterator) Err() error {
+	return iter.err
+}
+
+// DeleteObject returns the current object to be deleted.
+func (iter *s3InventoryIterator) DeleteObject() s3manager.BatchDeleteObject {
+	return iter.object
+}
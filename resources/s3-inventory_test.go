@@ -0,0 +1,178 @@
+package resources
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeS3Inventory struct {
+	s3iface.S3API
+
+	versioning string
+	configs    []*s3.InventoryConfiguration
+	objects    map[string][]byte
+}
+
+func (f *fakeS3Inventory) GetBucketVersioning(*s3.GetBucketVersioningInput) (*s3.GetBucketVersioningOutput, error) {
+	out := &s3.GetBucketVersioningOutput{}
+	if f.versioning != "" {
+		out.Status = aws.String(f.versioning)
+	}
+	return out, nil
+}
+
+func (f *fakeS3Inventory) ListBucketInventoryConfigurations(*s3.ListBucketInventoryConfigurationsInput) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	return &s3.ListBucketInventoryConfigurationsOutput{
+		InventoryConfigurationList: f.configs,
+	}, nil
+}
+
+func (f *fakeS3Inventory) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	out := &s3.ListObjectsV2Output{}
+	for _, prefix := range []string{"2024-11-05T01-00Z/", "2024-11-06T01-00Z/", "data/", "hive/"} {
+		out.CommonPrefixes = append(out.CommonPrefixes, &s3.CommonPrefix{
+			Prefix: aws.String(aws.StringValue(input.Prefix) + prefix),
+		})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (f *fakeS3Inventory) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	key := aws.StringValue(input.Bucket) + "/" + aws.StringValue(input.Key)
+	data, ok := f.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("no such key %s", key)
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func gzipped(t *testing.T, data string) []byte {
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	_, err := w.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func inventoryConfig(id, format, versions string) *s3.InventoryConfiguration {
+	return &s3.InventoryConfiguration{
+		Id:                     aws.String(id),
+		IsEnabled:              aws.Bool(true),
+		IncludedObjectVersions: aws.String(versions),
+		Destination: &s3.InventoryDestination{
+			S3BucketDestination: &s3.InventoryS3BucketDestination{
+				Bucket: aws.String("arn:aws:s3:::inventories"),
+				Format: aws.String(format),
+				Prefix: aws.String("reports"),
+			},
+		},
+	}
+}
+
+func TestS3Inventory(t *testing.T) {
+	svc := &fakeS3Inventory{
+		versioning: s3.BucketVersioningStatusEnabled,
+		configs: []*s3.InventoryConfiguration{
+			inventoryConfig("parquet", s3.InventoryFormatParquet, s3.InventoryIncludedObjectVersionsAll),
+			inventoryConfig("current", s3.InventoryFormatCsv, s3.InventoryIncludedObjectVersionsCurrent),
+			inventoryConfig("all", s3.InventoryFormatCsv, s3.InventoryIncludedObjectVersionsAll),
+		},
+		objects: map[string][]byte{
+			"inventories/reports/data-bucket/all/2024-11-05T01-00Z/manifest.json": []byte(`{}`),
+			"inventories/reports/data-bucket/all/2024-11-06T01-00Z/manifest.json": []byte(`{
+				"sourceBucket": "data-bucket",
+				"destinationBucket": "arn:aws:s3:::inventories",
+				"fileFormat": "CSV",
+				"fileSchema": "Bucket, Key, VersionId, IsLatest, IsDeleteMarker",
+				"files": [
+					{"key": "reports/data-bucket/all/data/a.csv.gz"},
+					{"key": "reports/data-bucket/all/data/b.csv.gz"}
+				]
+			}`),
+			"inventories/reports/data-bucket/all/data/a.csv.gz": gzipped(t,
+				`"data-bucket","logs/2024%2F11%2F06+a.txt","v1","true","false"`+"\n"+
+					`"data-bucket","null-version.txt","null","true","false"`+"\n"),
+			"inventories/reports/data-bucket/all/data/b.csv.gz": gzipped(t,
+				`"data-bucket","deleted.txt","v2","true","true"`+"\n"),
+		},
+	}
+
+	manifest, err := findS3Inventory(svc, "data-bucket")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "inventories", manifest.bucket)
+	assert.Len(t, manifest.Files, 2)
+
+	iter, err := newS3InventoryIterator(svc, "data-bucket", manifest)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	objects := []string{}
+	for iter.Next() {
+		o := iter.DeleteObject().Object
+		assert.Equal(t, "data-bucket", aws.StringValue(o.Bucket))
+		objects = append(objects, aws.StringValue(o.Key)+"@"+aws.StringValue(o.VersionId))
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, []string{
+		"logs/2024/11/06 a.txt@v1",
+		"null-version.txt@null",
+		"deleted.txt@v2",
+	}, objects)
+}
+
+func TestS3InventoryUnusable(t *testing.T) {
+	cases := map[string]*fakeS3Inventory{
+		"NoInventory": {},
+		"CurrentVersionsOnly": {
+			versioning: s3.BucketVersioningStatusSuspended,
+			configs: []*s3.InventoryConfiguration{
+				inventoryConfig("current", s3.InventoryFormatCsv, s3.InventoryIncludedObjectVersionsCurrent),
+			},
+		},
+		"MissingManifest": {
+			configs: []*s3.InventoryConfiguration{
+				inventoryConfig("current", s3.InventoryFormatCsv, s3.InventoryIncludedObjectVersionsCurrent),
+			},
+		},
+	}
+
+	for name, svc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := findS3Inventory(svc, "data-bucket")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestS3InventoryIteratorErrors(t *testing.T) {
+	manifest := &s3InventoryManifest{
+		bucket:     "inventories",
+		FileSchema: "Bucket, Key",
+	}
+	manifest.Files = append(manifest.Files, struct {
+		Key string `json:"key"`
+	}{Key: "missing.csv.gz"})
+
+	iter, err := newS3InventoryIterator(&fakeS3Inventory{}, "data-bucket", manifest)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, iter.Next())
+	assert.Error(t, iter.Err())
+
+	_, err = newS3InventoryIterator(&fakeS3Inventory{}, "data-bucket", &s3InventoryManifest{FileSchema: "Bucket, Size"})
+	assert.Error(t, err)
+}
@@ -1,13 +1,16 @@
 package resources
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
 )
 
 type SQSQueue struct {
 	svc      *sqs.SQS
 	queueURL *string
+	lazy     types.Properties
 }
 
 func init() {
@@ -34,6 +37,33 @@ func ListSQSQueues(sess *session.Session) ([]Resource, error) {
 	return resources, nil
 }
 
+// isSQSQueueEmpty returns whether the queue contains no visible, in-flight or
+// delayed messages. Since SQS only reports approximate numbers, it returns nil
+// if this cannot be determined.
+func isSQSQueueEmpty(svc *sqs.SQS, queueURL *string) *bool {
+	attributes := []string{
+		sqs.QueueAttributeNameApproximateNumberOfMessages,
+		sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+		sqs.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+	}
+
+	resp, err := svc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       queueURL,
+		AttributeNames: aws.StringSlice(attributes),
+	})
+	if err != nil {
+		return nil
+	}
+
+	for _, attribute := range attributes {
+		if aws.StringValue(resp.Attributes[attribute]) != "0" {
+			return aws.Bool(false)
+		}
+	}
+
+	return aws.Bool(true)
+}
+
 func (f *SQSQueue) Remove() error {
 
 	_, err := f.svc.DeleteQueue(&sqs.DeleteQueueInput{
@@ -43,6 +73,14 @@ func (f *SQSQueue) Remove() error {
 	return err
 }
 
+func (f *SQSQueue) LazyProperty(key string) (string, error) {
+	if f.lazy == nil {
+		f.lazy = types.NewProperties().
+			Set("Empty", isSQSQueueEmpty(f.svc, f.queueURL))
+	}
+	return f.lazy.Get(key), nil
+}
+
 func (f *SQSQueue) String() string {
 	return *f.queueURL
 }
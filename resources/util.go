@@ -1,6 +1,10 @@
 package resources
 
-import "github.com/aws/aws-sdk-go/aws/awserr"
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
 
 func UnPtrBool(ptr *bool, def bool) bool {
 	if ptr == nil {
@@ -36,3 +40,14 @@ func IsAWSError(err error, code string) bool {
 
 	return aerr.Code() == code
 }
+
+// latestTime returns the most recent of the given times, ignoring nil values.
+func latestTime(times ...*time.Time) *time.Time {
+	var latest *time.Time
+	for _, t := range times {
+		if t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+	return latest
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/verifiedpermissions"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type VerifiedPermissionsPolicy struct {
+	svc           *verifiedpermissions.VerifiedPermissions
+	policyStoreID *string
+	policyID      *string
+	policyType    *string
+}
+
+func init() {
+	register("VerifiedPermissionsPolicy", ListVerifiedPermissionsPolicies)
+}
+
+func ListVerifiedPermissionsPolicies(sess *session.Session) ([]Resource, error) {
+	svc := verifiedpermissions.New(sess)
+	resources := []Resource{}
+
+	stores := []*string{}
+	err := svc.ListPolicyStoresPages(&verifiedpermissions.ListPolicyStoresInput{},
+		func(page *verifiedpermissions.ListPolicyStoresOutput, lastPage bool) bool {
+			for _, store := range page.PolicyStores {
+				stores = append(stores, store.PolicyStoreId)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storeID := range stores {
+		err := svc.ListPoliciesPages(&verifiedpermissions.ListPoliciesInput{
+			PolicyStoreId: storeID,
+		}, func(page *verifiedpermissions.ListPoliciesOutput, lastPage bool) bool {
+			for _, policy := range page.Policies {
+				resources = append(resources, &VerifiedPermissionsPolicy{
+					svc:           svc,
+					policyStoreID: policy.PolicyStoreId,
+					policyID:      policy.PolicyId,
+					policyType:    policy.PolicyType,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+func (f *VerifiedPermissionsPolicy) Remove() error {
+	_, err := f.svc.DeletePolicy(&verifiedpermissions.DeletePolicyInput{
+		PolicyStoreId: f.policyStoreID,
+		PolicyId:      f.policyID,
+	})
+
+	return err
+}
+
+func (f *VerifiedPermissionsPolicy) Properties() types.Properties {
+	return types.NewProperties().
+		Set("PolicyStoreID", f.policyStoreID).
+		Set("PolicyID", f.policyID).
+		Set("PolicyType", f.policyType)
+}
+
+func (f *VerifiedPermissionsPolicy) String() string {
+	return *f.policyID
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/verifiedpermissions"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type VerifiedPermissionsPolicyStore struct {
+	svc           *verifiedpermissions.VerifiedPermissions
+	policyStoreID *string
+	arn           *string
+}
+
+func init() {
+	register("VerifiedPermissionsPolicyStore", ListVerifiedPermissionsPolicyStores)
+}
+
+func ListVerifiedPermissionsPolicyStores(sess *session.Session) ([]Resource, error) {
+	svc := verifiedpermissions.New(sess)
+	resources := []Resource{}
+
+	err := svc.ListPolicyStoresPages(&verifiedpermissions.ListPolicyStoresInput{},
+		func(page *verifiedpermissions.ListPolicyStoresOutput, lastPage bool) bool {
+			for _, store := range page.PolicyStores {
+				resources = append(resources, &VerifiedPermissionsPolicyStore{
+					svc:           svc,
+					policyStoreID: store.PolicyStoreId,
+					arn:           store.Arn,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Remove deletes the policy store including its policies, templates and
+// identity sources.
+func (f *VerifiedPermissionsPolicyStore) Remove() error {
+	_, err := f.svc.DeletePolicyStore(&verifiedpermissions.DeletePolicyStoreInput{
+		PolicyStoreId: f.policyStoreID,
+	})
+
+	return err
+}
+
+func (f *VerifiedPermissionsPolicyStore) Properties() types.Properties {
+	return types.NewProperties().
+		Set("PolicyStoreID", f.policyStoreID).
+		Set("ARN", f.arn)
+}
+
+func (f *VerifiedPermissionsPolicyStore) String() string {
+	return *f.policyStoreID
+}
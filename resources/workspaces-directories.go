@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/workspaces"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type WorkSpacesDirectory struct {
+	svc         *workspaces.WorkSpaces
+	directoryID *string
+	alias       *string
+	state       *string
+}
+
+func init() {
+	register("WorkSpacesDirectory", ListWorkSpacesDirectories)
+}
+
+func ListWorkSpacesDirectories(sess *session.Session) ([]Resource, error) {
+	svc := workspaces.New(sess)
+	resources := []Resource{}
+
+	params := &workspaces.DescribeWorkspaceDirectoriesInput{}
+
+	for {
+		output, err := svc.DescribeWorkspaceDirectories(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, directory := range output.Directories {
+			if *directory.State == workspaces.WorkspaceDirectoryStateDeregistered {
+				continue
+			}
+
+			resources = append(resources, &WorkSpacesDirectory{
+				svc:         svc,
+				directoryID: directory.DirectoryId,
+				alias:       directory.Alias,
+				state:       directory.State,
+			})
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+
+		params.NextToken = output.NextToken
+	}
+
+	return resources, nil
+}
+
+// Remove only deregisters the directory from WorkSpaces. The directory itself
+// is removed by the DirectoryServiceDirectory resource type. Deregistering
+// fails as long as the directory still has WorkSpaces.
+func (f *WorkSpacesDirectory) Remove() error {
+	_, err := f.svc.DeregisterWorkspaceDirectory(&workspaces.DeregisterWorkspaceDirectoryInput{
+		DirectoryId: f.directoryID,
+	})
+
+	return err
+}
+
+func (f *WorkSpacesDirectory) Properties() types.Properties {
+	return types.NewProperties().
+		Set("DirectoryID", f.directoryID).
+		Set("Alias", f.alias).
+		Set("State", f.state)
+}
+
+func (f *WorkSpacesDirectory) String() string {
+	return *f.directoryID
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/workspacesweb"
+	"github.com/rebuy-de/aws-nuke/pkg/types"
+)
+
+type WorkSpacesWebPortal struct {
+	svc          *workspacesweb.WorkSpacesWeb
+	arn          *string
+	displayName  *string
+	status       *string
+	creationDate string
+}
+
+func init() {
+	register("WorkSpacesWebPortal", ListWorkSpacesWebPortals)
+}
+
+func ListWorkSpacesWebPortals(sess *session.Session) ([]Resource, error) {
+	svc := workspacesweb.New(sess)
+	resources := []Resource{}
+
+	params := &workspacesweb.ListPortalsInput{}
+
+	err := svc.ListPortalsPages(params, func(page *workspacesweb.ListPortalsOutput, lastPage bool) bool {
+		for _, portal := range page.Portals {
+			r := &WorkSpacesWebPortal{
+				svc:         svc,
+				arn:         portal.PortalArn,
+				displayName: portal.DisplayName,
+				status:      portal.PortalStatus,
+			}
+			if portal.CreationDate != nil {
+				r.creationDate = portal.CreationDate.Format(time.RFC3339)
+			}
+			resources = append(resources, r)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (f *WorkSpacesWebPortal) Remove() error {
+	_, err := f.svc.DeletePortal(&workspacesweb.DeletePortalInput{
+		PortalArn: f.arn,
+	})
+
+	return err
+}
+
+func (f *WorkSpacesWebPortal) Properties() types.Properties {
+	return types.NewProperties().
+		Set("ARN", f.arn).
+		Set("DisplayName", f.displayName).
+		Set("Status", f.status).
+		Set("CreationDate", f.creationDate)
+}
+
+func (f *WorkSpacesWebPortal) String() string {
+	return *f.arn
+}
@@ -0,0 +1,461 @@
+// Command metadata extracts metadata about all resource types from the
+// sources of the resources package and writes it as JSON. The result is
+// embedded into the binary, so commands like explain and iam-policy work
+// without access to the sources.
+//
+// The extraction is based on conventions of the resources package:
+//
+//   - resource types are registered with register("Name", ListFunc) in init
+//   - the lister creates the resource structs with &Type{...}
+//   - the lister creates the API client with <service>.New(sess)
+//   - API calls are done on a variable or field called svc
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const sdkModule = "github.com/aws/aws-sdk-go"
+
+// Metadata must be kept in sync with resources.Metadata.
+type Metadata struct {
+	Service           string   `json:"service"`
+	EndpointsID       string   `json:"endpoints-id"`
+	IAMPrefix         string   `json:"iam-prefix"`
+	LegacyID          bool     `json:"legacy-id,omitempty"`
+	Properties        []string `json:"properties,omitempty"`
+	Tags              bool     `json:"tags,omitempty"`
+	DynamicProperties bool     `json:"dynamic-properties,omitempty"`
+	Raw               bool     `json:"raw,omitempty"`
+	LazyProperties    []string `json:"lazy-properties,omitempty"`
+	Actions           []string `json:"actions,omitempty"`
+}
+
+type typeInfo struct {
+	methods map[string]*ast.FuncDecl
+}
+
+type pkgInfo struct {
+	files   map[string]*ast.File
+	funcs   map[string]*ast.FuncDecl
+	types   map[string]*typeInfo
+	imports map[*ast.File]map[string]string
+}
+
+var (
+	reServiceName = regexp.MustCompile(`ServiceName\s*=\s*"([^"]+)"`)
+	reEndpointsID = regexp.MustCompile(`EndpointsID\s*=\s*"([^"]+)"`)
+	reSigningName = regexp.MustCompile(`c\.SigningName\s*=\s*"([^"]+)"`)
+)
+
+// iamPrefixOverrides are the IAM prefixes of services, which cannot be derived
+// from the SDK sources.
+var iamPrefixOverrides = map[string]string{
+	"cloudcontrolapi": "cloudformation",
+}
+
+func main() {
+	var (
+		dir    string
+		output string
+	)
+	flag.StringVar(&dir, "dir", ".", "directory of the resources package")
+	flag.StringVar(&output, "o", "metadata.json", "output file")
+	flag.Parse()
+
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sdkDir, err := sdkDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	metadata := map[string]*Metadata{}
+	for _, file := range pkg.files {
+		for name, lister := range registrations(file) {
+			fn, ok := pkg.funcs[lister]
+			if !ok {
+				log.Fatalf("lister %s of %s not found", lister, name)
+			}
+
+			meta, err := pkg.extract(file, fn, sdkDir)
+			if err != nil {
+				log.Fatalf("%s: %v", name, err)
+			}
+			metadata[name] = meta
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(metadata)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(output, buf.Bytes(), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parsePackage(dir string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &pkgInfo{
+		files:   map[string]*ast.File{},
+		funcs:   map[string]*ast.FuncDecl{},
+		types:   map[string]*typeInfo{},
+		imports: map[*ast.File]map[string]string{},
+	}
+
+	for _, p := range pkgs {
+		for path, file := range p.Files {
+			info.files[path] = file
+			info.imports[file] = fileImports(file)
+
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+
+				if fn.Recv == nil {
+					info.funcs[fn.Name.Name] = fn
+					continue
+				}
+
+				recv := receiverType(fn)
+				t, ok := info.types[recv]
+				if !ok {
+					t = &typeInfo{methods: map[string]*ast.FuncDecl{}}
+					info.types[recv] = t
+				}
+				t.methods[fn.Name.Name] = fn
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func sdkDir() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", sdkModule).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate %s: %v", sdkModule, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// fileImports maps the local package names to the AWS services they import.
+func fileImports(file *ast.File) map[string]string {
+	result := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if !strings.HasPrefix(path, sdkModule+"/service/") {
+			continue
+		}
+
+		service := strings.TrimPrefix(path, sdkModule+"/service/")
+		if strings.Contains(service, "/") {
+			continue
+		}
+
+		name := service
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		result[name] = service
+	}
+	return result
+}
+
+func registrations(file *ast.File) map[string]string {
+	result := map[string]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+
+		fn, ok := call.Fun.(*ast.Ident)
+		if !ok || fn.Name != "register" {
+			return true
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		lister, ok2 := call.Args[1].(*ast.Ident)
+		if !ok || !ok2 {
+			return true
+		}
+
+		name, _ := strconv.Unquote(lit.Value)
+		result[name] = lister.Name
+		return true
+	})
+	return result
+}
+
+func receiverType(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return ident.Name
+}
+
+func (p *pkgInfo) extract(file *ast.File, lister *ast.FuncDecl, sdkDir string) (*Metadata, error) {
+	imports := p.imports[file]
+	meta := &Metadata{}
+
+	// The service is the one, whose client is created by the lister.
+	ast.Inspect(lister, func(n ast.Node) bool {
+		if meta.Service != "" {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "New" {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if service, ok := imports[ident.Name]; ok {
+			meta.Service = service
+		}
+		return true
+	})
+	if meta.Service == "" {
+		return nil, fmt.Errorf("could not detect service of lister %s", lister.Name.Name)
+	}
+
+	err := meta.resolveService(sdkDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource types are all types with a Remove method, which are
+	// instantiated by the lister.
+	resourceTypes := map[string]bool{}
+	ast.Inspect(lister, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		ident, ok := lit.Type.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		t, ok := p.types[ident.Name]
+		if ok && t.methods["Remove"] != nil {
+			resourceTypes[ident.Name] = true
+		}
+		return true
+	})
+
+	actions := map[string]bool{}
+	visited := map[*ast.FuncDecl]bool{}
+	p.collectActions(lister, meta.IAMPrefix, actions, visited)
+
+	properties := map[string]bool{}
+	lazyProperties := map[string]bool{}
+	for name := range resourceTypes {
+		t := p.types[name]
+		for _, method := range t.methods {
+			p.collectActions(method, meta.IAMPrefix, actions, visited)
+		}
+
+		if t.methods["String"] != nil {
+			meta.LegacyID = true
+		}
+
+		if t.methods["Raw"] != nil {
+			meta.Raw = true
+		}
+
+		if fn := t.methods["Properties"]; fn != nil {
+			meta.collectProperties(fn, properties)
+		}
+
+		if fn := t.methods["LazyProperty"]; fn != nil {
+			meta.collectProperties(fn, lazyProperties)
+		}
+	}
+
+	meta.Properties = sortedKeys(properties)
+	if len(lazyProperties) > 0 {
+		meta.LazyProperties = sortedKeys(lazyProperties)
+	}
+	meta.Actions = sortedKeys(actions)
+	return meta, nil
+}
+
+func (m *Metadata) resolveService(sdkDir string) error {
+	raw, err := ioutil.ReadFile(filepath.Join(sdkDir, "service", m.Service, "service.go"))
+	if err != nil {
+		return err
+	}
+
+	match := reServiceName.FindSubmatch(raw)
+	if match == nil {
+		return fmt.Errorf("could not find service name of %s", m.Service)
+	}
+	name := string(match[1])
+
+	m.EndpointsID = name
+	if match := reEndpointsID.FindSubmatch(raw); match != nil {
+		m.EndpointsID = string(match[1])
+	}
+
+	// Service names like "api.sagemaker" or "data.mediastore" use the last
+	// part as IAM prefix. Newer services have display names like
+	// "WorkSpaces Web" or "HealthLake", where the signing name or else the
+	// endpoint ID is the IAM prefix. IAM prefixes are always lower case.
+	parts := strings.Split(name, ".")
+	m.IAMPrefix = strings.ToLower(parts[len(parts)-1])
+	if strings.Contains(name, " ") {
+		m.IAMPrefix = m.EndpointsID
+		if match := reSigningName.FindSubmatch(raw); match != nil {
+			m.IAMPrefix = string(match[1])
+		}
+	}
+	if prefix, ok := iamPrefixOverrides[m.Service]; ok {
+		m.IAMPrefix = prefix
+	}
+
+	return nil
+}
+
+func (p *pkgInfo) collectActions(fn *ast.FuncDecl, prefix string, actions map[string]bool, visited map[*ast.FuncDecl]bool) {
+	if fn == nil || visited[fn] {
+		return
+	}
+	visited[fn] = true
+
+	ast.Inspect(fn, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch f := call.Fun.(type) {
+		case *ast.Ident:
+			// Follow helper functions of the package.
+			p.collectActions(p.funcs[f.Name], prefix, actions, visited)
+
+		case *ast.SelectorExpr:
+			if !isClient(f.X) {
+				return true
+			}
+
+			action := apiAction(f.Sel.Name)
+			if action != "" {
+				actions[prefix+":"+action] = true
+			}
+		}
+		return true
+	})
+}
+
+func isClient(expr ast.Expr) bool {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name == "svc"
+	case *ast.SelectorExpr:
+		return x.Sel.Name == "svc"
+	}
+	return false
+}
+
+// apiAction converts the name of an SDK method into the name of the
+// corresponding API action.
+func apiAction(method string) string {
+	if method == "" || strings.HasPrefix(method, "WaitUntil") {
+		return ""
+	}
+	if method[0] < 'A' || method[0] > 'Z' {
+		return ""
+	}
+
+	for _, suffix := range []string{"WithContext", "Pages", "Request"} {
+		method = strings.TrimSuffix(method, suffix)
+	}
+	return method
+}
+
+func (m *Metadata) collectProperties(fn *ast.FuncDecl, properties map[string]bool) {
+	ast.Inspect(fn, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case "SetTag", "SetTagWithPrefix":
+			m.Tags = true
+		case "Set":
+			if len(call.Args) != 2 {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				m.DynamicProperties = true
+				return true
+			}
+			key, _ := strconv.Unquote(lit.Value)
+			if strings.HasPrefix(key, "tag:") {
+				m.Tags = true
+				return true
+			}
+			properties[key] = true
+		}
+		return true
+	})
+}
+
+func sortedKeys(m map[string]bool) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}